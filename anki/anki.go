@@ -0,0 +1,205 @@
+// Package anki reads and writes Anki packages (.apkg): a zip of an Anki collection,
+// which is a SQLite database, and a media index. Only the legacy collection schema
+// is supported, it's the one Anki writes with "Support older Anki versions" checked.
+package anki
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+)
+
+const (
+	// DefaultDeckName is the deck exported notes are put in.
+	DefaultDeckName = "Vocabforge"
+	// MatureInterval is the interval Anki considers a card mature at, mature cards are learned words.
+	MatureInterval = 21 * 24 * time.Hour
+
+	collectionFile       = "collection.anki2"
+	collection21File     = "collection.anki21"
+	collection21bFile    = "collection.anki21b"
+	mediaFile            = "media"
+	fieldSeparator       = "\x1f"
+	tagsSeparator        = " "
+	defaultEase          = 2.5
+	easeFactorMultiplier = 1000
+	day                  = 24 * time.Hour
+)
+
+// Fields of the cloze note type exported notes have.
+const (
+	FieldText            = "Text"
+	FieldDefinition      = "Definition"
+	FieldSpelling        = "Spelling"
+	FieldLexicalCategory = "Lexical Category"
+	FieldLanguage        = "Language"
+)
+
+var (
+	ErrUnsupportedPackage = errors.New("unsupported anki package, export it with \"Support older Anki versions\" checked")
+	ErrInvalidPackage     = errors.New("invalid anki package")
+	ErrInvalidNote        = errors.New("invalid note")
+)
+
+// CardType is the learning stage of a card.
+type CardType int
+
+const (
+	CardNew CardType = iota
+	CardLearning
+	CardReview
+	CardRelearning
+)
+
+// Note is an Anki note with the cards generated from it.
+type Note struct {
+	GUID string
+	// Fields are keyed by the field names of the note's type.
+	Fields map[string]string
+	// FieldNames are the field names in the order of the note's type.
+	FieldNames []string
+	Tags       []string
+	// Cloze is set when the note's type is a cloze one.
+	Cloze bool
+	Cards []Card
+}
+
+// Card is the review state of a card.
+type Card struct {
+	Type       CardType
+	Interval   time.Duration
+	Due        time.Time
+	LastReview time.Time
+	Ease       float64
+	Reps       uint
+	Lapses     uint
+	// Stability and Difficulty are set when Anki schedules the card with FSRS.
+	Stability  float64
+	Difficulty float64
+}
+
+// LearnStatus maps the card's stage onto a word's status: new cards are pending,
+// mature ones are learned and the rest are in progress.
+func (c Card) LearnStatus() models.LearnStatus {
+	switch {
+	case c.Type == CardNew:
+		return models.Pending
+	case c.Type == CardReview && c.Interval >= MatureInterval:
+		return models.Learned
+	default:
+		return models.InProgress
+	}
+}
+
+// ReviewState combines the cards of a word's notes into the word's status and schedule.
+// The word is learned when every card is, pending when none was studied, and is
+// scheduled as the studied card due first.
+func ReviewState(cards []Card) (models.LearnStatus, models.Schedule) {
+	learned, pending := len(cards) > 0, true
+	var first *Card
+	for i, c := range cards {
+		status := c.LearnStatus()
+		learned = learned && status == models.Learned
+		if status == models.Pending {
+			continue
+		}
+		pending = false
+		if first == nil || c.Due.Before(first.Due) {
+			first = &cards[i]
+		}
+	}
+
+	if pending {
+		return models.Pending, models.Schedule{}
+	}
+	status := models.InProgress
+	if learned {
+		status = models.Learned
+	}
+	return status, models.Schedule{
+		Ease:        first.Ease,
+		Stability:   first.Stability,
+		Difficulty:  first.Difficulty,
+		Interval:    first.Interval,
+		Due:         first.Due,
+		LastReview:  first.LastReview,
+		Repetitions: first.Reps,
+		Lapses:      first.Lapses,
+	}
+}
+
+// WordNotes turns the word into cloze notes, one per exercise. A word without exercises
+// gets a single note with the spelling hidden, so that it's learned by the definition.
+func WordNotes(w models.Word) ([]Note, error) {
+	sentences := make([]string, 0, len(w.Exercises))
+	for _, e := range w.Exercises {
+		sentences = append(sentences, e.Sentence)
+	}
+	if len(sentences) == 0 {
+		sentences = append(sentences, "")
+	}
+
+	tags := make([]string, 0, len(w.Tags))
+	for _, t := range w.Tags {
+		// tags are separated by spaces in Anki
+		tags = append(tags, strings.Join(strings.Fields(t), "_"))
+	}
+
+	notes := make([]Note, 0, len(sentences))
+	for i, s := range sentences {
+		text, err := ToCloze(s, w.Spelling)
+		if err != nil {
+			return nil, err
+		}
+		notes = append(notes, Note{
+			GUID: noteGUID(w.ID, i),
+			Fields: map[string]string{
+				FieldText:            text,
+				FieldDefinition:      escape(w.Definition),
+				FieldSpelling:        escape(w.Spelling),
+				FieldLexicalCategory: escape(w.LexicalCategory),
+				FieldLanguage:        escape(w.Language.String()),
+			},
+			FieldNames: clozeFields,
+			Tags:       tags,
+			Cloze:      true,
+			Cards:      []Card{wordCard(w)},
+		})
+	}
+	return notes, nil
+}
+
+func wordCard(w models.Word) Card {
+	s := w.Schedule
+	c := Card{
+		Type:       CardReview,
+		Interval:   s.Interval,
+		Due:        s.Due,
+		LastReview: s.LastReview,
+		Ease:       s.Ease,
+		Reps:       s.Repetitions,
+		Lapses:     s.Lapses,
+		Stability:  s.Stability,
+		Difficulty: s.Difficulty,
+	}
+
+	switch {
+	case w.LearnStatus == models.Pending:
+		return Card{Type: CardNew}
+	case w.LearnStatus == models.Learned:
+		c.Interval = max(c.Interval, MatureInterval)
+	case c.Interval < day:
+		c.Type = CardLearning
+	default:
+		// a longer interval would make the word learned when it's imported back
+		c.Interval = min(c.Interval, MatureInterval-day)
+	}
+	return c
+}
+
+func noteGUID(id models.WordID, i int) string {
+	return "vocabforge-" + id.String() + "-" + strconv.Itoa(i)
+}
@@ -0,0 +1,163 @@
+package anki
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pavelpuchok/vocabforge/models"
+)
+
+func TestWriteRead(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 9, 1, 10, 0, 0, 0, time.UTC)
+	words := []models.Word{
+		{
+			ID:              "66d4a1f0c2a4b5e6f7a8b9c0",
+			Spelling:        "Haus",
+			Definition:      "house & home",
+			LexicalCategory: "noun",
+			Language:        "de",
+			LearnStatus:     models.Learned,
+			Schedule: models.Schedule{
+				Ease:        2.6,
+				Interval:    30 * day,
+				Due:         now.Add(10 * day),
+				LastReview:  now.Add(-20 * day),
+				Repetitions: 5,
+			},
+			Exercises: []models.SentenceExercise{
+				{Sentence: "Das <%Haus%> ist groß."},
+				{Sentence: "Wir bauen ein <%Haus%>."},
+			},
+			Tags: []string{"chapter 1", "a1"},
+		},
+		{
+			ID:          "66d4a1f0c2a4b5e6f7a8b9c1",
+			Spelling:    "run",
+			Definition:  "to move fast",
+			Language:    "en-US",
+			LearnStatus: models.InProgress,
+			Schedule: models.Schedule{
+				Stability:  4.2,
+				Difficulty: 5.1,
+				Interval:   40 * day,
+				Due:        now.Add(2 * day),
+				LastReview: now.Add(-2 * day),
+				Lapses:     1,
+			},
+			Exercises: []models.SentenceExercise{{Sentence: "She <%ran%> to the <b>store</b>."}},
+		},
+		{
+			ID:          "66d4a1f0c2a4b5e6f7a8b9c2",
+			Spelling:    "fast",
+			Definition:  "quick",
+			Language:    "en-US",
+			LearnStatus: models.Pending,
+		},
+	}
+
+	var notes []Note
+	for _, w := range words {
+		n, err := WordNotes(w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		notes = append(notes, n...)
+	}
+
+	var b bytes.Buffer
+	if err := Write(context.Background(), &b, DefaultDeckName, notes, now); err != nil {
+		t.Fatal(err)
+	}
+	read, err := Read(context.Background(), bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read) != 4 {
+		t.Fatalf("expected 4 notes, got %d", len(read))
+	}
+
+	var entries []Entry
+	for _, n := range read {
+		e, err := n.Entry()
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, e)
+	}
+	expectedEntries := []Entry{
+		{Spelling: "Haus", Definition: "house & home", LexicalCategory: "noun", Language: "de", Sentence: "Das <%Haus%> ist groß.", Tags: []string{"chapter_1", "a1"}},
+		{Spelling: "Haus", Definition: "house & home", LexicalCategory: "noun", Language: "de", Sentence: "Wir bauen ein <%Haus%>.", Tags: []string{"chapter_1", "a1"}},
+		{Spelling: "run", Definition: "to move fast", Language: "en-US", Sentence: "She <%ran%> to the <b>store</b>."},
+		{Spelling: "fast", Definition: "quick", Language: "en-US"},
+	}
+	if diff := cmp.Diff(expectedEntries, entries); diff != "" {
+		t.Errorf("unexpected entries (-want +got):\n%s", diff)
+	}
+
+	status, schedule := ReviewState(append(read[0].Cards, read[1].Cards...))
+	if status != models.Learned {
+		t.Errorf("expected learned word, got %s", status.String())
+	}
+	// review cards are due on a day
+	expectedSchedule := words[0].Schedule
+	expectedSchedule.Due = expectedSchedule.Due.Truncate(day)
+	if diff := cmp.Diff(expectedSchedule, schedule); diff != "" {
+		t.Errorf("unexpected schedule (-want +got):\n%s", diff)
+	}
+
+	status, schedule = ReviewState(read[2].Cards)
+	if status != models.InProgress {
+		t.Errorf("expected word in progress, got %s", status.String())
+	}
+	expectedSchedule = words[1].Schedule
+	expectedSchedule.Due = expectedSchedule.Due.Truncate(day)
+	// longer intervals are mature, the word would become learned
+	expectedSchedule.Interval = 20 * day
+	expectedSchedule.Ease = defaultEase
+	if diff := cmp.Diff(expectedSchedule, schedule); diff != "" {
+		t.Errorf("unexpected schedule (-want +got):\n%s", diff)
+	}
+
+	if status, _ := ReviewState(read[3].Cards); status != models.Pending {
+		t.Errorf("expected pending word, got %s", status.String())
+	}
+}
+
+func TestFromCloze(t *testing.T) {
+	t.Parallel()
+
+	valid := map[string][2]string{
+		"She {{c1::ran}} to the store.":               {"She <%ran%> to the store.", "ran"},
+		"She {{c1::ran::verb}} to the {{c2::store}}.": {"She <%ran%> to the store.", "ran"},
+		"<div>Das {{c2::<b>Haus</b>}}&nbsp;ist</div>": {"Das <%Haus%> ist", "Haus"},
+		"{{c1::Haus}}":     {"", "Haus"},
+		"{{c3::Haus}}<br>": {"", "Haus"},
+	}
+	for text, expected := range valid {
+		sentence, answer, err := FromCloze(text)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", text, err)
+			continue
+		}
+		if sentence != expected[0] || answer != expected[1] {
+			t.Errorf("expected %q and %q for %q, got %q and %q", expected[0], expected[1], text, sentence, answer)
+		}
+	}
+
+	invalid := []string{
+		"She ran to the store.",
+		"She {{c1::ran}} to the {{c1::store}}.",
+		"She {{c1::}} to the store.",
+	}
+	for _, text := range invalid {
+		if _, _, err := FromCloze(text); !errors.Is(err, ErrInvalidNote) {
+			t.Errorf("expected ErrInvalidNote for %q, got %v", text, err)
+		}
+	}
+}
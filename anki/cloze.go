@@ -0,0 +1,92 @@
+package anki
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pavelpuchok/vocabforge/practice/cloze"
+)
+
+var (
+	// clozeDeletion matches {{c1::answer}} and {{c1::answer::hint}}.
+	clozeDeletion = regexp.MustCompile(`\{\{c(\d+)::(.*?)(?:::[^}]*)?\}\}`)
+	lineBreakTag  = regexp.MustCompile(`(?i)<\s*(br|/?div|/?p|/?li)\b[^>]*>`)
+	htmlTag       = regexp.MustCompile(`<[^>]*>`)
+)
+
+const answerPlaceholder = "\x00"
+
+// ToCloze turns a sentence with <% %> markup into the text of an Anki cloze note.
+// An empty sentence gives a note with the spelling alone hidden.
+func ToCloze(sentence, spelling string) (string, error) {
+	if sentence == "" {
+		return clozeText(1, escape(spelling)), nil
+	}
+	c, err := cloze.Parse(sentence)
+	if err != nil {
+		return "", fmt.Errorf("anki.ToCloze. %w", err)
+	}
+	return escape(c.Before) + clozeText(1, escape(c.Answer)) + escape(c.After), nil
+}
+
+// FromCloze turns the text of an Anki cloze note into a sentence with <% %> markup
+// around the first deletion. Other deletions are revealed. The sentence is empty when
+// there is nothing but the deletion in the text, answer is the deletion's text.
+func FromCloze(text string) (string, string, error) {
+	matches := clozeDeletion.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return "", "", fmt.Errorf("anki.FromCloze %q. %w: no cloze deletion", text, ErrInvalidNote)
+	}
+
+	first := text[matches[0][2]:matches[0][3]]
+	var answer string
+	var b strings.Builder
+	prev := 0
+	for _, m := range matches {
+		b.WriteString(text[prev:m[0]])
+		deletion := text[m[4]:m[5]]
+		if text[m[2]:m[3]] == first {
+			if answer != "" {
+				return "", "", fmt.Errorf("anki.FromCloze %q. %w: c%s hides more than one word", text, ErrInvalidNote, first)
+			}
+			answer = StripHTML(deletion)
+			// the markup would be taken for a tag, it's put in place of the placeholder after HTML is stripped
+			deletion = answerPlaceholder
+		}
+		b.WriteString(deletion)
+		prev = m[1]
+	}
+	b.WriteString(text[prev:])
+
+	if answer == "" {
+		return "", "", fmt.Errorf("anki.FromCloze %q. %w: empty cloze deletion", text, ErrInvalidNote)
+	}
+
+	marked := cloze.OpenTag + answer + cloze.CloseTag
+	sentence := strings.Replace(StripHTML(b.String()), answerPlaceholder, marked, 1)
+	if sentence == marked {
+		return "", answer, nil
+	}
+	if _, err := cloze.Parse(sentence); err != nil {
+		return "", "", fmt.Errorf("anki.FromCloze. %w: %w", ErrInvalidNote, err)
+	}
+	return sentence, answer, nil
+}
+
+// StripHTML turns an Anki field into plain text, line breaks become spaces.
+func StripHTML(field string) string {
+	s := lineBreakTag.ReplaceAllString(field, " ")
+	s = htmlTag.ReplaceAllString(s, "")
+	return strings.Join(strings.Fields(html.UnescapeString(s)), " ")
+}
+
+func clozeText(n int, text string) string {
+	return "{{c" + strconv.Itoa(n) + "::" + text + "}}"
+}
+
+func escape(s string) string {
+	return html.EscapeString(s)
+}
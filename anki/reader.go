@@ -0,0 +1,285 @@
+package anki
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Read reads the notes of every deck of the Anki package.
+func Read(ctx context.Context, r io.ReaderAt, size int64) ([]Note, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("anki.Read. %w: %w", ErrInvalidPackage, err)
+	}
+
+	files := map[string]*zip.File{}
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	// newer versions of Anki put the collection to .anki21
+	collection := files[collection21File]
+	if collection == nil {
+		// the latest schema is compressed, .anki2 has a stub telling to update Anki then
+		if files[collection21bFile] != nil {
+			return nil, fmt.Errorf("anki.Read. %w", ErrUnsupportedPackage)
+		}
+		collection = files[collectionFile]
+	}
+	if collection == nil {
+		return nil, fmt.Errorf("anki.Read. %w: no collection", ErrInvalidPackage)
+	}
+
+	dir, err := os.MkdirTemp("", "vocabforge-anki-*")
+	if err != nil {
+		return nil, fmt.Errorf("anki.Read unable to create temporary directory. %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, collectionFile)
+	if err := extract(collection, path); err != nil {
+		return nil, fmt.Errorf("anki.Read unable to extract collection. %w", err)
+	}
+
+	notes, err := readCollection(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("anki.Read unable to read collection. %w", err)
+	}
+	return notes, nil
+}
+
+func extract(f *zip.File, path string) error {
+	src, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// noteType is the part of a note type (model) needed to read notes.
+type noteType struct {
+	Type   int `json:"type"`
+	Fields []struct {
+		Name string `json:"name"`
+		Ord  int    `json:"ord"`
+	} `json:"flds"`
+}
+
+func readCollection(ctx context.Context, path string) ([]Note, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	var crt int64
+	var modelsJSON string
+	err = db.QueryRowContext(ctx, `SELECT crt, models FROM col`).Scan(&crt, &modelsJSON)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to read collection. %w", ErrInvalidPackage, err)
+	}
+	var types map[string]noteType
+	if err := json.Unmarshal([]byte(modelsJSON), &types); err != nil {
+		return nil, fmt.Errorf("%w: unable to read note types. %w", ErrInvalidPackage, err)
+	}
+	created := time.Unix(crt, 0).UTC()
+
+	cards, err := readCards(ctx, db, created)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT id, guid, mid, tags, flds FROM notes ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query notes. %w", err)
+	}
+	defer rows.Close()
+
+	var notes []Note
+	for rows.Next() {
+		var id, mid int64
+		var guid, tags, flds string
+		if err := rows.Scan(&id, &guid, &mid, &tags, &flds); err != nil {
+			return nil, fmt.Errorf("unable to scan note. %w", err)
+		}
+		typ, ok := types[strconv.FormatInt(mid, 10)]
+		if !ok {
+			return nil, fmt.Errorf("%w: note %s has unknown type %d", ErrInvalidPackage, guid, mid)
+		}
+
+		values := strings.Split(flds, fieldSeparator)
+		fields := make(map[string]string, len(typ.Fields))
+		names := make([]string, len(typ.Fields))
+		for i, f := range typ.Fields {
+			names[i] = f.Name
+			if f.Ord < len(values) {
+				fields[f.Name] = values[f.Ord]
+			}
+		}
+		notes = append(notes, Note{
+			GUID:       guid,
+			Fields:     fields,
+			FieldNames: names,
+			Tags:       splitTags(tags),
+			Cloze:      typ.Type == clozeModelTyp,
+			Cards:      cards[id],
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read notes. %w", err)
+	}
+	return notes, nil
+}
+
+func splitTags(tags string) []string {
+	if strings.TrimSpace(tags) == "" {
+		return nil
+	}
+	return strings.Fields(tags)
+}
+
+// readCards returns cards by their note IDs.
+func readCards(ctx context.Context, db *sql.DB, created time.Time) (map[int64][]Card, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT c.nid, c.type, c.due, c.odue, c.odid, c.ivl, c.factor, c.reps, c.lapses, c.data, MAX(r.id)
+		FROM cards c LEFT JOIN revlog r ON r.cid = c.id
+		GROUP BY c.id ORDER BY c.nid, c.ord`)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query cards. %w", err)
+	}
+	defer rows.Close()
+
+	cards := map[int64][]Card{}
+	for rows.Next() {
+		var noteID, due, odue, odid, ivl, factor int64
+		var typ CardType
+		var reps, lapses uint
+		var data string
+		var lastReview sql.NullInt64
+		if err := rows.Scan(&noteID, &typ, &due, &odue, &odid, &ivl, &factor, &reps, &lapses, &data, &lastReview); err != nil {
+			return nil, fmt.Errorf("unable to scan card. %w", err)
+		}
+		if odid != 0 && odue != 0 {
+			// the card is in a filtered deck, the original due date is the one it's scheduled by
+			due = odue
+		}
+
+		c := Card{Type: typ, Reps: reps, Lapses: lapses}
+		if typ != CardNew {
+			c.Ease = float64(factor) / easeFactorMultiplier
+			c.Due = dueTime(due, created)
+			c.Interval = interval(ivl)
+			c.LastReview = c.Due.Add(-c.Interval)
+		}
+		if lastReview.Valid {
+			c.LastReview = time.UnixMilli(lastReview.Int64).UTC()
+		}
+
+		var memory cardData
+		if data != "" {
+			if err := json.Unmarshal([]byte(data), &memory); err != nil {
+				return nil, fmt.Errorf("%w: invalid data of a card of note %d. %w", ErrInvalidPackage, noteID, err)
+			}
+		}
+		c.Stability, c.Difficulty = memory.Stability, memory.Difficulty
+
+		cards[noteID] = append(cards[noteID], c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read cards. %w", err)
+	}
+	return cards, nil
+}
+
+// timestampThreshold tells timestamps of learning cards from day numbers of review ones.
+const timestampThreshold = 1_000_000_000
+
+func dueTime(due int64, created time.Time) time.Time {
+	if due > timestampThreshold {
+		return time.Unix(due, 0).UTC()
+	}
+	return created.Add(time.Duration(due) * day)
+}
+
+// interval converts the interval of a card, negative ones are in seconds.
+func interval(ivl int64) time.Duration {
+	if ivl < 0 {
+		return time.Duration(-ivl) * time.Second
+	}
+	return time.Duration(ivl) * day
+}
+
+// Entry is a word described by a note.
+type Entry struct {
+	Spelling        string
+	Definition      string
+	LexicalCategory string
+	// Language is the text of the note's language field, empty if it has none.
+	Language string
+	// Sentence has <% %> markup, it's empty when the note has nothing to make an exercise of.
+	Sentence string
+	Tags     []string
+}
+
+// Entry reads the word from the note. Notes of types other than the exported one are
+// read by the first two fields: Text and Back Extra of cloze ones, or the front and
+// the back of basic ones.
+func (n Note) Entry() (Entry, error) {
+	e := Entry{
+		Definition:      StripHTML(n.Fields[FieldDefinition]),
+		Spelling:        StripHTML(n.Fields[FieldSpelling]),
+		LexicalCategory: StripHTML(n.Fields[FieldLexicalCategory]),
+		Language:        StripHTML(n.Fields[FieldLanguage]),
+		Tags:            n.Tags,
+	}
+
+	var first, second string
+	if len(n.FieldNames) > 0 {
+		first = n.Fields[n.FieldNames[0]]
+	}
+	if len(n.FieldNames) > 1 {
+		second = n.Fields[n.FieldNames[1]]
+	}
+
+	if n.Cloze {
+		sentence, answer, err := FromCloze(first)
+		if err != nil {
+			return Entry{}, err
+		}
+		e.Sentence = sentence
+		if e.Spelling == "" {
+			e.Spelling = answer
+		}
+	} else if e.Spelling == "" {
+		e.Spelling = StripHTML(first)
+	}
+	if e.Definition == "" {
+		e.Definition = StripHTML(second)
+	}
+
+	if e.Spelling == "" {
+		return Entry{}, fmt.Errorf("anki.Note.Entry %s. %w: empty spelling", n.GUID, ErrInvalidNote)
+	}
+	if e.Definition == "" {
+		return Entry{}, fmt.Errorf("anki.Note.Entry %s. %w: empty definition", n.GUID, ErrInvalidNote)
+	}
+	return e, nil
+}
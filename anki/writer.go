@@ -0,0 +1,387 @@
+package anki
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha1" //nolint:gosec
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	// registers "sqlite" database/sql driver
+	_ "modernc.org/sqlite"
+)
+
+// schema is the legacy collection schema, version 11.
+const schema = `
+CREATE TABLE col (
+	id integer PRIMARY KEY, crt integer NOT NULL, mod integer NOT NULL, scm integer NOT NULL,
+	ver integer NOT NULL, dty integer NOT NULL, usn integer NOT NULL, ls integer NOT NULL,
+	conf text NOT NULL, models text NOT NULL, decks text NOT NULL, dconf text NOT NULL, tags text NOT NULL
+);
+CREATE TABLE notes (
+	id integer PRIMARY KEY, guid text NOT NULL, mid integer NOT NULL, mod integer NOT NULL,
+	usn integer NOT NULL, tags text NOT NULL, flds text NOT NULL, sfld integer NOT NULL,
+	csum integer NOT NULL, flags integer NOT NULL, data text NOT NULL
+);
+CREATE TABLE cards (
+	id integer PRIMARY KEY, nid integer NOT NULL, did integer NOT NULL, ord integer NOT NULL,
+	mod integer NOT NULL, usn integer NOT NULL, type integer NOT NULL, queue integer NOT NULL,
+	due integer NOT NULL, ivl integer NOT NULL, factor integer NOT NULL, reps integer NOT NULL,
+	lapses integer NOT NULL, left integer NOT NULL, odue integer NOT NULL, odid integer NOT NULL,
+	flags integer NOT NULL, data text NOT NULL
+);
+CREATE TABLE revlog (
+	id integer PRIMARY KEY, cid integer NOT NULL, usn integer NOT NULL, ease integer NOT NULL,
+	ivl integer NOT NULL, lastIvl integer NOT NULL, factor integer NOT NULL, time integer NOT NULL,
+	type integer NOT NULL
+);
+CREATE TABLE graves (usn integer NOT NULL, oid integer NOT NULL, type integer NOT NULL);
+CREATE INDEX ix_notes_usn ON notes (usn);
+CREATE INDEX ix_cards_usn ON cards (usn);
+CREATE INDEX ix_revlog_usn ON revlog (usn);
+CREATE INDEX ix_cards_nid ON cards (nid);
+CREATE INDEX ix_cards_sched ON cards (did, queue, due);
+CREATE INDEX ix_revlog_cid ON revlog (cid);
+CREATE INDEX ix_notes_csum ON notes (csum);
+`
+
+const (
+	schemaVersion = 11
+	defaultDeckID = 1
+	clozeModelTyp = 1
+
+	clozeModelName = "Vocabforge Cloze"
+	clozeQuestion  = "{{cloze:" + FieldText + "}}<div class=definition>{{" + FieldDefinition + "}}</div>"
+	clozeAnswer    = "{{cloze:" + FieldText + "}}<hr id=answer><b>{{" + FieldSpelling + "}}</b> <i>{{" + FieldLexicalCategory + "}}</i>" +
+		"<div class=definition>{{" + FieldDefinition + "}}</div>"
+	clozeCSS = ".card { font-family: arial; font-size: 20px; text-align: center; }\n" +
+		".cloze { font-weight: bold; color: blue; }\n.definition { margin-top: 1em; color: grey; }\n"
+
+	collectionConf = `{"nextPos":1,"estTimes":true,"activeDecks":[1],"sortType":"noteFld","timeLim":0,` +
+		`"sortBackwards":false,"addToCur":true,"curDeck":1,"newBust":true,"dueCounts":true,"collapseTime":1200}`
+	deckConf = `{"1":{"id":1,"name":"Default","mod":0,"usn":0,"maxTaken":60,"autoplay":true,"timer":0,` +
+		`"replayq":true,"dyn":false,"new":{"bury":false,"delays":[1,10],"initialFactor":2500,"ints":[1,4,0],` +
+		`"order":1,"perDay":20},"rev":{"bury":false,"ease4":1.3,"ivlFct":1,"maxIvl":36500,"perDay":200,` +
+		`"hardFactor":1.2},"lapse":{"delays":[10],"leechAction":1,"leechFails":8,"minInt":1,"mult":0}}}`
+)
+
+// clozeFields are the fields of the exported note type, in order.
+var clozeFields = []string{FieldText, FieldDefinition, FieldSpelling, FieldLexicalCategory, FieldLanguage}
+
+// Write writes the notes to w as an Anki package with a single deck. Notes have to
+// be cloze ones with clozeFields, see WordNotes.
+func Write(ctx context.Context, w io.Writer, deckName string, notes []Note, now time.Time) error {
+	dir, err := os.MkdirTemp("", "vocabforge-anki-*")
+	if err != nil {
+		return fmt.Errorf("anki.Write unable to create temporary directory. %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, collectionFile)
+	if err := writeCollection(ctx, path, deckName, notes, now); err != nil {
+		return fmt.Errorf("anki.Write unable to write collection. %w", err)
+	}
+
+	collection, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("anki.Write unable to open collection. %w", err)
+	}
+	defer collection.Close()
+
+	zw := zip.NewWriter(w)
+	f, err := zw.Create(collectionFile)
+	if err != nil {
+		return fmt.Errorf("anki.Write unable to add collection. %w", err)
+	}
+	if _, err := io.Copy(f, collection); err != nil {
+		return fmt.Errorf("anki.Write unable to add collection. %w", err)
+	}
+	// words have no media, the index maps nothing
+	f, err = zw.Create(mediaFile)
+	if err != nil {
+		return fmt.Errorf("anki.Write unable to add media index. %w", err)
+	}
+	if _, err := io.WriteString(f, "{}"); err != nil {
+		return fmt.Errorf("anki.Write unable to add media index. %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("anki.Write unable to finish package. %w", err)
+	}
+	return nil
+}
+
+type model struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Type      int        `json:"type"`
+	Mod       int64      `json:"mod"`
+	USN       int        `json:"usn"`
+	SortField int        `json:"sortf"`
+	DeckID    int64      `json:"did"`
+	Templates []template `json:"tmpls"`
+	Fields    []field    `json:"flds"`
+	CSS       string     `json:"css"`
+	LatexPre  string     `json:"latexPre"`
+	LatexPost string     `json:"latexPost"`
+	Tags      []string   `json:"tags"`
+	Vers      []int      `json:"vers"`
+	Req       [][]any    `json:"req"`
+}
+
+type template struct {
+	Name     string `json:"name"`
+	Ord      int    `json:"ord"`
+	Question string `json:"qfmt"`
+	Answer   string `json:"afmt"`
+	DeckID   *int64 `json:"did"`
+	BQFmt    string `json:"bqfmt"`
+	BAFmt    string `json:"bafmt"`
+}
+
+type field struct {
+	Name   string `json:"name"`
+	Ord    int    `json:"ord"`
+	Sticky bool   `json:"sticky"`
+	RTL    bool   `json:"rtl"`
+	Font   string `json:"font"`
+	Size   int    `json:"size"`
+	Media  []any  `json:"media"`
+}
+
+type deck struct {
+	ID               int64  `json:"id"`
+	Name             string `json:"name"`
+	Mod              int64  `json:"mod"`
+	USN              int    `json:"usn"`
+	LearnToday       [2]int `json:"lrnToday"`
+	ReviewToday      [2]int `json:"revToday"`
+	NewToday         [2]int `json:"newToday"`
+	TimeToday        [2]int `json:"timeToday"`
+	Collapsed        bool   `json:"collapsed"`
+	BrowserCollapsed bool   `json:"browserCollapsed"`
+	Desc             string `json:"desc"`
+	Dyn              int    `json:"dyn"`
+	Conf             int64  `json:"conf"`
+	ExtendNew        int    `json:"extendNew"`
+	ExtendRev        int    `json:"extendRev"`
+}
+
+func writeCollection(ctx context.Context, path, deckName string, notes []Note, now time.Time) error {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.ExecContext(ctx, schema); err != nil {
+		return fmt.Errorf("unable to create schema. %w", err)
+	}
+
+	// ids are creation times in milliseconds in Anki, they just have to be unique
+	base := now.UnixMilli()
+	modelID, deckID := base, base+1
+	// due dates of review cards are days since the collection's creation
+	created := now.UTC().Truncate(day)
+
+	if err := insertCol(ctx, tx, created, now, modelID, deckID, deckName); err != nil {
+		return fmt.Errorf("unable to insert collection. %w", err)
+	}
+
+	cards := cardWriter{tx: tx, deckID: deckID, created: created, now: now, nextID: base, revlogIDs: map[int64]bool{}}
+	for i, n := range notes {
+		id := base + int64(i)
+		flds := make([]string, len(clozeFields))
+		for j, name := range clozeFields {
+			flds[j] = n.Fields[name]
+		}
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO notes (id, guid, mid, mod, usn, tags, flds, sfld, csum, flags, data) VALUES (?, ?, ?, ?, -1, ?, ?, ?, ?, 0, '')`,
+			id, n.GUID, modelID, now.Unix(), joinTags(n.Tags), strings.Join(flds, fieldSeparator),
+			StripHTML(n.Fields[FieldSpelling]), checksum(flds[0]),
+		)
+		if err != nil {
+			return fmt.Errorf("unable to insert note %s. %w", n.GUID, err)
+		}
+
+		for ord, c := range n.Cards {
+			if err := cards.insert(ctx, id, ord, i, c); err != nil {
+				return fmt.Errorf("unable to insert card of note %s. %w", n.GUID, err)
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+func insertCol(ctx context.Context, tx *sql.Tx, created, now time.Time, modelID, deckID int64, deckName string) error {
+	fields := make([]field, len(clozeFields))
+	for i, name := range clozeFields {
+		//nolint:mnd
+		fields[i] = field{Name: name, Ord: i, Font: "Arial", Size: 20, Media: []any{}}
+	}
+	models := map[string]model{
+		itoa64(modelID): {
+			ID:   modelID,
+			Name: clozeModelName,
+			Type: clozeModelTyp,
+			Mod:  now.Unix(),
+			USN:  -1,
+			// the browser sorts notes by spelling
+			SortField: 2,
+			DeckID:    deckID,
+			Templates: []template{{Name: "Cloze", Question: clozeQuestion, Answer: clozeAnswer}},
+			Fields:    fields,
+			CSS:       clozeCSS,
+			LatexPre:  "\\documentclass[12pt]{article}\n\\special{papersize=3in,5in}\n\\usepackage{amssymb,amsmath}\n\\pagestyle{empty}\n\\setlength{\\parindent}{0in}\n\\begin{document}\n",
+			LatexPost: "\\end{document}",
+			Tags:      []string{},
+			Vers:      []int{},
+			Req:       [][]any{{0, "any", []int{0}}},
+		},
+	}
+	decks := map[string]deck{
+		itoa64(defaultDeckID): {ID: defaultDeckID, Name: "Default", Conf: 1},
+		itoa64(deckID):        {ID: deckID, Name: deckName, Mod: now.Unix(), USN: -1, Conf: 1},
+	}
+
+	modelsJSON, err := json.Marshal(models)
+	if err != nil {
+		return err
+	}
+	decksJSON, err := json.Marshal(decks)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO col (id, crt, mod, scm, ver, dty, usn, ls, conf, models, decks, dconf, tags) VALUES (1, ?, ?, ?, ?, 0, 0, 0, ?, ?, ?, ?, '{}')`,
+		created.Unix(), now.UnixMilli(), now.UnixMilli(), schemaVersion, collectionConf, string(modelsJSON), string(decksJSON), deckConf,
+	)
+	return err
+}
+
+// Card queues, they differ from types for suspended and buried cards only.
+const (
+	queueNew      = 0
+	queueLearning = 1
+	queueReview   = 2
+)
+
+type cardWriter struct {
+	tx      *sql.Tx
+	deckID  int64
+	created time.Time
+	now     time.Time
+	nextID  int64
+	// revlogIDs are review times in milliseconds, cards reviewed at once need distinct ones
+	revlogIDs map[int64]bool
+}
+
+// insert adds the card of the note, pos is the card's position among new ones.
+func (w *cardWriter) insert(ctx context.Context, noteID int64, ord, pos int, c Card) error {
+	id := w.nextID
+	w.nextID++
+	created, now := w.created, w.now
+
+	var typ, queue int
+	var due, ivl, factor int64
+	switch c.Type {
+	case CardNew:
+		// due is the position in the new cards queue
+		typ, queue, due = int(CardNew), queueNew, int64(pos)
+	case CardLearning, CardRelearning:
+		// due is a timestamp in seconds and negative interval is in seconds for learning cards
+		typ, queue = int(c.Type), queueLearning
+		due, ivl = dueOrNow(c.Due, now).Unix(), -int64(c.Interval/time.Second)
+	default:
+		typ, queue = int(CardReview), queueReview
+		due = int64(dueOrNow(c.Due, now).Sub(created) / day)
+		ivl = max(int64(c.Interval/day), 1)
+	}
+	if c.Type != CardNew {
+		ease := c.Ease
+		if ease == 0 {
+			ease = defaultEase
+		}
+		factor = int64(ease * easeFactorMultiplier)
+	}
+
+	data := "{}"
+	if c.Stability > 0 {
+		b, err := json.Marshal(cardData{Stability: c.Stability, Difficulty: c.Difficulty})
+		if err != nil {
+			return err
+		}
+		data = string(b)
+	}
+
+	_, err := w.tx.ExecContext(ctx,
+		`INSERT INTO cards (id, nid, did, ord, mod, usn, type, queue, due, ivl, factor, reps, lapses, left, odue, odid, flags, data)
+		VALUES (?, ?, ?, ?, ?, -1, ?, ?, ?, ?, ?, ?, ?, 0, 0, 0, 0, ?)`,
+		id, noteID, w.deckID, ord, now.Unix(), typ, queue, due, ivl, factor, c.Reps, c.Lapses, data,
+	)
+	if err != nil {
+		return err
+	}
+
+	if c.LastReview.IsZero() {
+		return nil
+	}
+	revlogID := c.LastReview.UnixMilli()
+	for w.revlogIDs[revlogID] {
+		revlogID++
+	}
+	w.revlogIDs[revlogID] = true
+	// the last review is kept in the review log only, as a "good" answer
+	_, err = w.tx.ExecContext(ctx,
+		`INSERT INTO revlog (id, cid, usn, ease, ivl, lastIvl, factor, time, type) VALUES (?, ?, -1, 3, ?, 0, ?, 0, ?)`,
+		revlogID, id, ivl, factor, typ-1,
+	)
+	return err
+}
+
+// cardData is the FSRS memory state Anki keeps in the data column of a card.
+type cardData struct {
+	Stability  float64 `json:"s,omitempty"`
+	Difficulty float64 `json:"d,omitempty"`
+}
+
+func dueOrNow(due, now time.Time) time.Time {
+	if due.IsZero() {
+		return now
+	}
+	return due
+}
+
+// checksum is the first 8 hex digits of the field's SHA-1 Anki finds duplicates by.
+func checksum(field string) int64 {
+	//nolint:gosec
+	sum := sha1.Sum([]byte(StripHTML(field)))
+	return int64(binary.BigEndian.Uint32(sum[:4]))
+}
+
+func joinTags(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	// Anki surrounds tags with spaces to match them with LIKE
+	return tagsSeparator + strings.Join(tags, tagsSeparator) + tagsSeparator
+}
+
+func itoa64(i int64) string {
+	return strconv.FormatInt(i, 10)
+}
@@ -0,0 +1,237 @@
+// Package backup defines the versioned archive a user's data is backed up to, and
+// reads and writes it as a single JSON document or as JSON lines.
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+)
+
+// Version is the version of archives written. Archives of later versions aren't read.
+const Version = 1
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported archive version")
+	ErrInvalidArchive     = errors.New("invalid archive")
+)
+
+// Archive is a user's profile and words with their exercises and learning state.
+// IDs are the ones the data had when it was backed up.
+type Archive struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	User      User      `json:"user"`
+	Words     []Word    `json:"words,omitempty"`
+}
+
+type User struct {
+	ID              string   `json:"id"`
+	DisplayName     string   `json:"displayName,omitempty"`
+	NativeLanguage  string   `json:"nativeLanguage"`
+	TargetLanguages []string `json:"targetLanguages,omitempty"`
+	Timezone        string   `json:"timezone,omitempty"`
+	DailyGoal       int      `json:"dailyGoal,omitempty"`
+	ExerciseTypes   []string `json:"exerciseTypes,omitempty"`
+	SessionSize     int      `json:"sessionSize,omitempty"`
+}
+
+type Word struct {
+	ID                 string     `json:"id"`
+	Spelling           string     `json:"spelling"`
+	Definition         string     `json:"definition"`
+	DefinitionLanguage string     `json:"definitionLanguage,omitempty"`
+	LexicalCategory    string     `json:"lexicalCategory,omitempty"`
+	Language           string     `json:"language"`
+	LearnStatus        string     `json:"learnStatus"`
+	AnsweredCount      uint       `json:"answeredCount"`
+	Exercises          []Exercise `json:"exercises"`
+	Archived           bool       `json:"archived,omitempty"`
+	Schedule           Schedule   `json:"schedule"`
+	Tags               []string   `json:"tags,omitempty"`
+	Source             string     `json:"source,omitempty"`
+}
+
+type Exercise struct {
+	Sentence string `json:"sentence"`
+	Answered bool   `json:"answered"`
+}
+
+// Schedule is the review history of a word as the schedulers keep it.
+type Schedule struct {
+	Ease       float64 `json:"ease,omitempty"`
+	Stability  float64 `json:"stability,omitempty"`
+	Difficulty float64 `json:"difficulty,omitempty"`
+	// Interval is a Go duration, for ex: 72h0m0s.
+	Interval    string     `json:"interval,omitempty"`
+	Due         *time.Time `json:"due,omitempty"`
+	LastReview  *time.Time `json:"lastReview,omitempty"`
+	Repetitions uint       `json:"repetitions,omitempty"`
+	Lapses      uint       `json:"lapses,omitempty"`
+}
+
+// New makes an archive of the user and the words.
+func New(u models.User, words []models.Word, now time.Time) Archive {
+	a := Archive{
+		Version:   Version,
+		CreatedAt: now.UTC(),
+		User: User{
+			ID:             u.ID.String(),
+			DisplayName:    u.DisplayName,
+			NativeLanguage: u.NativeLanguage.String(),
+			Timezone:       u.Timezone,
+			DailyGoal:      u.DailyGoal,
+			SessionSize:    u.SessionSize,
+		},
+		Words: make([]Word, len(words)),
+	}
+	for _, l := range u.TargetLanguages {
+		a.User.TargetLanguages = append(a.User.TargetLanguages, l.String())
+	}
+	for _, t := range u.ExerciseTypes {
+		a.User.ExerciseTypes = append(a.User.ExerciseTypes, t.String())
+	}
+	for i, w := range words {
+		a.Words[i] = wordFromModel(w)
+	}
+	return a
+}
+
+func wordFromModel(w models.Word) Word {
+	res := Word{
+		ID:                 w.ID.String(),
+		Spelling:           w.Spelling,
+		Definition:         w.Definition,
+		DefinitionLanguage: w.DefinitionLanguage.String(),
+		LexicalCategory:    w.LexicalCategory,
+		Language:           w.Language.String(),
+		LearnStatus:        w.LearnStatus.String(),
+		AnsweredCount:      w.AnsweredCount,
+		Exercises:          make([]Exercise, len(w.Exercises)),
+		Archived:           w.Archived,
+		Schedule: Schedule{
+			Ease:        w.Schedule.Ease,
+			Stability:   w.Schedule.Stability,
+			Difficulty:  w.Schedule.Difficulty,
+			Due:         timePtr(w.Schedule.Due),
+			LastReview:  timePtr(w.Schedule.LastReview),
+			Repetitions: w.Schedule.Repetitions,
+			Lapses:      w.Schedule.Lapses,
+		},
+		Tags:   w.Tags,
+		Source: w.Source,
+	}
+	if w.Schedule.Interval != 0 {
+		res.Schedule.Interval = w.Schedule.Interval.String()
+	}
+	for i, e := range w.Exercises {
+		res.Exercises[i] = Exercise{Sentence: e.Sentence, Answered: e.Answered}
+	}
+	return res
+}
+
+// Profile returns the archived user, the ID is the one the user had.
+func (u User) Profile() (models.User, error) {
+	nativeLang, err := models.LanguageFromText(u.NativeLanguage)
+	if err != nil {
+		return models.User{}, fmt.Errorf("backup.User.Profile. %w: %w", ErrInvalidArchive, err)
+	}
+	var targetLangs []models.Language
+	for _, l := range u.TargetLanguages {
+		lang, err := models.LanguageFromText(l)
+		if err != nil {
+			return models.User{}, fmt.Errorf("backup.User.Profile. %w: %w", ErrInvalidArchive, err)
+		}
+		targetLangs = append(targetLangs, lang)
+	}
+	var exerciseTypes []models.ExerciseType
+	for _, t := range u.ExerciseTypes {
+		typ, err := models.ExerciseTypeFromText(t)
+		if err != nil {
+			return models.User{}, fmt.Errorf("backup.User.Profile. %w: %w", ErrInvalidArchive, err)
+		}
+		exerciseTypes = append(exerciseTypes, typ)
+	}
+
+	return models.User{
+		ID:              models.UserID(u.ID),
+		DisplayName:     u.DisplayName,
+		NativeLanguage:  nativeLang,
+		TargetLanguages: targetLangs,
+		Timezone:        u.Timezone,
+		DailyGoal:       u.DailyGoal,
+		ExerciseTypes:   exerciseTypes,
+		SessionSize:     u.SessionSize,
+	}, nil
+}
+
+// Model returns the archived word, the ID is the one the word had.
+func (w Word) Model() (models.Word, error) {
+	if w.Spelling == "" {
+		return models.Word{}, fmt.Errorf("backup.Word.Model %s. %w: empty spelling", w.ID, ErrInvalidArchive)
+	}
+	lang, err := models.LanguageFromText(w.Language)
+	if err != nil {
+		return models.Word{}, fmt.Errorf("backup.Word.Model %s. %w: %w", w.ID, ErrInvalidArchive, err)
+	}
+	var definitionLang models.Language
+	if w.DefinitionLanguage != "" {
+		definitionLang, err = models.LanguageFromText(w.DefinitionLanguage)
+		if err != nil {
+			return models.Word{}, fmt.Errorf("backup.Word.Model %s. %w: %w", w.ID, ErrInvalidArchive, err)
+		}
+	}
+	status, err := models.LearnStatusFromText(w.LearnStatus)
+	if err != nil {
+		return models.Word{}, fmt.Errorf("backup.Word.Model %s. %w: %w", w.ID, ErrInvalidArchive, err)
+	}
+	var interval time.Duration
+	if w.Schedule.Interval != "" {
+		interval, err = time.ParseDuration(w.Schedule.Interval)
+		if err != nil {
+			return models.Word{}, fmt.Errorf("backup.Word.Model %s. %w: %w", w.ID, ErrInvalidArchive, err)
+		}
+	}
+
+	res := models.Word{
+		ID:                 models.WordID(w.ID),
+		Spelling:           w.Spelling,
+		Definition:         w.Definition,
+		LexicalCategory:    w.LexicalCategory,
+		Language:           lang,
+		DefinitionLanguage: definitionLang,
+		LearnStatus:        status,
+		AnsweredCount:      w.AnsweredCount,
+		Exercises:          make([]models.SentenceExercise, len(w.Exercises)),
+		Archived:           w.Archived,
+		Schedule: models.Schedule{
+			Ease:        w.Schedule.Ease,
+			Stability:   w.Schedule.Stability,
+			Difficulty:  w.Schedule.Difficulty,
+			Interval:    interval,
+			Repetitions: w.Schedule.Repetitions,
+			Lapses:      w.Schedule.Lapses,
+		},
+		Tags:   w.Tags,
+		Source: w.Source,
+	}
+	if w.Schedule.Due != nil {
+		res.Schedule.Due = *w.Schedule.Due
+	}
+	if w.Schedule.LastReview != nil {
+		res.Schedule.LastReview = *w.Schedule.LastReview
+	}
+	for i, e := range w.Exercises {
+		res.Exercises[i] = models.SentenceExercise{Sentence: e.Sentence, Answered: e.Answered}
+	}
+	return res, nil
+}
+
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,105 @@
+package backup
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pavelpuchok/vocabforge/models"
+)
+
+func TestWriteRead(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 9, 1, 10, 0, 0, 0, time.UTC)
+	usr := models.User{
+		ID:              "66d43a8ef6c9a3d6b9c1a001",
+		DisplayName:     "Pavel",
+		NativeLanguage:  "ru",
+		TargetLanguages: []models.Language{"en-US"},
+		Timezone:        "Europe/Berlin",
+		DailyGoal:       10,
+		SessionSize:     20,
+	}
+	words := []models.Word{
+		{
+			ID:                 "66d43a8ef6c9a3d6b9c1a002",
+			Spelling:           "run",
+			Definition:         "бежать",
+			LexicalCategory:    "verb",
+			Language:           "en-US",
+			DefinitionLanguage: "ru",
+			LearnStatus:        models.InProgress,
+			AnsweredCount:      1,
+			Exercises:          []models.SentenceExercise{{Sentence: "She <%ran%> home.", Answered: true}},
+			Schedule: models.Schedule{
+				Ease:        2.5,
+				Interval:    72 * time.Hour,
+				Due:         now.Add(72 * time.Hour),
+				LastReview:  now,
+				Repetitions: 2,
+			},
+			Tags:   []string{"kafka"},
+			Source: "Der Process",
+		},
+		{
+			ID:          "66d43a8ef6c9a3d6b9c1a003",
+			Spelling:    "walk",
+			Language:    "en-US",
+			LearnStatus: models.Pending,
+			Exercises:   []models.SentenceExercise{},
+			Archived:    true,
+		},
+	}
+
+	for _, f := range []Format{JSON, JSONL} {
+		var buf bytes.Buffer
+		if err := Write(&buf, New(usr, words, now), f); err != nil {
+			t.Fatal(err)
+		}
+		a, err := Read(&buf, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		profile, err := a.User.Profile()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(usr, profile); diff != "" {
+			t.Errorf("%s: unexpected user (-want +got):\n%s", f.String(), diff)
+		}
+		var got []models.Word
+		for _, w := range a.Words {
+			m, err := w.Model()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, m)
+		}
+		if diff := cmp.Diff(words, got); diff != "" {
+			t.Errorf("%s: unexpected words (-want +got):\n%s", f.String(), diff)
+		}
+	}
+}
+
+func TestReadVersion(t *testing.T) {
+	t.Parallel()
+
+	archives := map[Format]string{
+		JSON:  `{"version": 2, "user": {"id": "1", "nativeLanguage": "ru"}}`,
+		JSONL: "{\"version\": 2, \"user\": {\"id\": \"1\", \"nativeLanguage\": \"ru_RU\"}}\n",
+	}
+	for f, s := range archives {
+		if _, err := Read(strings.NewReader(s), f); !errors.Is(err, ErrUnsupportedVersion) {
+			t.Errorf("%s: expected ErrUnsupportedVersion, got %v", f.String(), err)
+		}
+	}
+
+	if _, err := Read(strings.NewReader("\n"), JSONL); !errors.Is(err, ErrInvalidArchive) {
+		t.Errorf("expected ErrInvalidArchive for empty archive, got %v", err)
+	}
+}
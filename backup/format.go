@@ -0,0 +1,147 @@
+package backup
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format is the way an archive is written. JSONL puts the archive without words on
+// the first line and a word per line after it, so large archives can be streamed.
+type Format int
+
+const (
+	JSON Format = iota
+	JSONL
+)
+
+// maxLineSize is the max size of a JSONL line, words with many exercises are long.
+const maxLineSize = 16 << 20
+
+func (f *Format) String() string {
+	txt, err := f.MarshalText()
+	if err != nil {
+		return "unknown"
+	}
+	return txt
+}
+
+func (f *Format) MarshalText() (string, error) {
+	switch *f {
+	case JSON:
+		return "json", nil
+	case JSONL:
+		return "jsonl", nil
+	default:
+		return "", fmt.Errorf("%d is unknown Format", *f)
+	}
+}
+
+func (f *Format) UnmarshalText(text string) error {
+	switch text {
+	case "json":
+		*f = JSON
+	case "jsonl":
+		*f = JSONL
+	default:
+		return fmt.Errorf("%s is unknown Format representation", text)
+	}
+	return nil
+}
+
+func FormatFromText(s string) (Format, error) {
+	var f Format
+	err := f.UnmarshalText(s)
+	if err != nil {
+		return 0, fmt.Errorf("backup.FormatFromText invalid format string %s. %w", s, err)
+	}
+	return f, nil
+}
+
+// FormatFromFileName guesses the format by the file extension, JSON is the default.
+func FormatFromFileName(name string) Format {
+	lower := strings.ToLower(name)
+	if strings.HasSuffix(lower, ".jsonl") || strings.HasSuffix(lower, ".ndjson") {
+		return JSONL
+	}
+	return JSON
+}
+
+func Write(w io.Writer, a Archive, f Format) error {
+	enc := json.NewEncoder(w)
+	if f == JSON {
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(a); err != nil {
+			return fmt.Errorf("backup.Write. %w", err)
+		}
+		return nil
+	}
+
+	words := a.Words
+	a.Words = nil
+	if err := enc.Encode(a); err != nil {
+		return fmt.Errorf("backup.Write unable to write header. %w", err)
+	}
+	for _, word := range words {
+		if err := enc.Encode(word); err != nil {
+			return fmt.Errorf("backup.Write unable to write word %s. %w", word.ID, err)
+		}
+	}
+	return nil
+}
+
+func Read(r io.Reader, f Format) (Archive, error) {
+	if f == JSON {
+		var a Archive
+		if err := json.NewDecoder(r).Decode(&a); err != nil {
+			return Archive{}, fmt.Errorf("backup.Read. %w: %w", ErrInvalidArchive, err)
+		}
+		if err := checkVersion(a.Version); err != nil {
+			return Archive{}, fmt.Errorf("backup.Read. %w", err)
+		}
+		return a, nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineSize)
+	var a Archive
+	header := false
+	for line := 1; scanner.Scan(); line++ {
+		b := scanner.Bytes()
+		if len(strings.TrimSpace(string(b))) == 0 {
+			continue
+		}
+		if !header {
+			header = true
+			if err := json.Unmarshal(b, &a); err != nil {
+				return Archive{}, fmt.Errorf("backup.Read header. %w: %w", ErrInvalidArchive, err)
+			}
+			if err := checkVersion(a.Version); err != nil {
+				return Archive{}, fmt.Errorf("backup.Read. %w", err)
+			}
+			continue
+		}
+
+		var word Word
+		if err := json.Unmarshal(b, &word); err != nil {
+			return Archive{}, fmt.Errorf("backup.Read line %d. %w: %w", line, ErrInvalidArchive, err)
+		}
+		a.Words = append(a.Words, word)
+	}
+	if err := scanner.Err(); err != nil {
+		return Archive{}, fmt.Errorf("backup.Read. %w", err)
+	}
+	if !header {
+		return Archive{}, fmt.Errorf("backup.Read. %w: no header", ErrInvalidArchive)
+	}
+	return a, nil
+}
+
+func checkVersion(v int) error {
+	if v < 1 || v > Version {
+		return fmt.Errorf("%w %d, expected 1 to %d", ErrUnsupportedVersion, v, Version)
+	}
+	return nil
+}
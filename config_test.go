@@ -2,7 +2,9 @@ package main
 
 import (
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -26,7 +28,7 @@ func TestParseConfig(t *testing.T) {
 		var actualEnvs = map[string]string{
 			EnvPrefix + "MONGO_URI":      "",
 			EnvPrefix + "MONGO_DATABASE": "",
-			EnvPrefix + "CHATGPT_TOKEN":  "",
+			EnvPrefix + "AI_TOKEN":       "",
 		}
 
 		setEnv(actualEnvs)
@@ -47,10 +49,13 @@ func TestParseConfig(t *testing.T) {
 		var actualEnvs = map[string]string{
 			EnvPrefix + "MONGO_URI":      "foobar",
 			EnvPrefix + "MONGO_DATABASE": "bazbaz",
-			EnvPrefix + "CHATGPT_TOKEN":  "atata",
+			EnvPrefix + "AI_TOKEN":       "atata",
+			EnvPrefix + "AI_TEMPERATURE": "0",
 		}
 
 		setEnv(actualEnvs)
+		// later cases don't reset the temperature
+		t.Cleanup(func() { os.Unsetenv(EnvPrefix + "AI_TEMPERATURE") })
 
 		cfg, err := ParseConfig([]string{"foo", string(CreateUser)})
 		if err != nil {
@@ -60,7 +65,98 @@ func TestParseConfig(t *testing.T) {
 		expectedCfg := configWithDefaults(CreateUser)
 		expectedCfg.Mongo.URI = "foobar"
 		expectedCfg.Mongo.DatabaseName = "bazbaz"
-		expectedCfg.ChatGPT.APIToken = "atata"
+		expectedCfg.AI.APIToken = "atata"
+		// zero temperature is set explicitly, unlike the default one
+		expectedCfg.AI.Temperature = new(float32)
+		if diff := cmp.Diff(expectedCfg, cfg); diff != "" {
+			t.Errorf("unexpected config (-want +got):\n%s", diff)
+		}
+	})
+
+	//nolint:paralleltest
+	t.Run("env storage values", func(t *testing.T) {
+		var actualEnvs = map[string]string{
+			EnvPrefix + "MONGO_URI":        "",
+			EnvPrefix + "MONGO_DATABASE":   "",
+			EnvPrefix + "AI_TOKEN":         "",
+			EnvPrefix + "STORAGE_TYPE":     "memory",
+			EnvPrefix + "STORAGE_SNAPSHOT": "vocabforge.json",
+		}
+
+		setEnv(actualEnvs)
+		t.Cleanup(func() {
+			setEnv(map[string]string{EnvPrefix + "STORAGE_TYPE": "", EnvPrefix + "STORAGE_SNAPSHOT": ""})
+		})
+
+		cfg, err := ParseConfig([]string{"foo", string(CreateUser)})
+		if err != nil {
+			t.Errorf("unexpected error %s", err)
+		}
+
+		expectedCfg := configWithDefaults(CreateUser)
+		expectedCfg.Storage.Type = StorageMemory
+		expectedCfg.Storage.Snapshot = "vocabforge.json"
+		if diff := cmp.Diff(expectedCfg, cfg); diff != "" {
+			t.Errorf("unexpected config (-want +got):\n%s", diff)
+		}
+	})
+
+	//nolint:paralleltest
+	t.Run("env postgres values", func(t *testing.T) {
+		var actualEnvs = map[string]string{
+			EnvPrefix + "MONGO_URI":                "",
+			EnvPrefix + "MONGO_DATABASE":           "",
+			EnvPrefix + "AI_TOKEN":                 "",
+			EnvPrefix + "STORAGE_TYPE":             "postgres",
+			EnvPrefix + "POSTGRES_URL":             "postgres://localhost/vocabforge",
+			EnvPrefix + "POSTGRES_MAXCONNS":        "20",
+			EnvPrefix + "POSTGRES_MAXCONNIDLETIME": "5m",
+		}
+
+		setEnv(actualEnvs)
+		t.Cleanup(func() {
+			setEnv(map[string]string{
+				EnvPrefix + "STORAGE_TYPE":             "",
+				EnvPrefix + "POSTGRES_URL":             "",
+				EnvPrefix + "POSTGRES_MAXCONNS":        "",
+				EnvPrefix + "POSTGRES_MAXCONNIDLETIME": "",
+			})
+		})
+
+		cfg, err := ParseConfig([]string{"foo", string(CreateUser)})
+		if err != nil {
+			t.Errorf("unexpected error %s", err)
+		}
+
+		expectedCfg := configWithDefaults(CreateUser)
+		expectedCfg.Storage.Type = StoragePostgres
+		expectedCfg.Postgres.URL = "postgres://localhost/vocabforge"
+		expectedCfg.Postgres.MaxConns = 20
+		expectedCfg.Postgres.MaxConnIdleTime = 5 * time.Minute
+		if diff := cmp.Diff(expectedCfg, cfg); diff != "" {
+			t.Errorf("unexpected config (-want +got):\n%s", diff)
+		}
+	})
+
+	//nolint:paralleltest
+	t.Run("cli create-user values", func(t *testing.T) {
+		var actualEnvs = map[string]string{
+			EnvPrefix + "MONGO_URI":      "",
+			EnvPrefix + "MONGO_DATABASE": "",
+			EnvPrefix + "AI_TOKEN":       "",
+		}
+
+		setEnv(actualEnvs)
+
+		cfg, err := ParseConfig([]string{"foo", string(CreateUser), "-native-language=ru", "-target-languages=en_GB,de"})
+		if err != nil {
+			t.Errorf("unexpected error %s", err)
+		}
+
+		expectedCfg := configWithDefaults(CreateUser)
+		expectedCfg.NativeLanguage = "ru"
+		expectedCfg.TargetLanguages = "en_GB,de"
+
 		if diff := cmp.Diff(expectedCfg, cfg); diff != "" {
 			t.Errorf("unexpected config (-want +got):\n%s", diff)
 		}
@@ -71,7 +167,7 @@ func TestParseConfig(t *testing.T) {
 		var actualEnvs = map[string]string{
 			EnvPrefix + "MONGO_URI":      "",
 			EnvPrefix + "MONGO_DATABASE": "",
-			EnvPrefix + "CHATGPT_TOKEN":  "",
+			EnvPrefix + "AI_TOKEN":       "",
 		}
 
 		setEnv(actualEnvs)
@@ -88,6 +184,320 @@ func TestParseConfig(t *testing.T) {
 		expectedCfg.Language = "en_GB"
 		expectedCfg.LexicalCategory = "adverb"
 
+		if diff := cmp.Diff(expectedCfg, cfg); diff != "" {
+			t.Errorf("unexpected config (-want +got):\n%s", diff)
+		}
+	})
+	//nolint:paralleltest
+	t.Run("cli list-words values", func(t *testing.T) {
+		var actualEnvs = map[string]string{
+			EnvPrefix + "MONGO_URI":      "",
+			EnvPrefix + "MONGO_DATABASE": "",
+			EnvPrefix + "AI_TOKEN":       "",
+		}
+
+		setEnv(actualEnvs)
+
+		cfg, err := ParseConfig([]string{"foo", string(ListWords), "-user-id=abc", "-status=learned", "-prefix=fo", "-sort=-spelling", "-limit=10"})
+		if err != nil {
+			t.Errorf("unexpected error %s", err)
+		}
+
+		expectedCfg := configWithDefaults(ListWords)
+		expectedCfg.UserID = "abc"
+		expectedCfg.Language = ""
+		expectedCfg.LearnStatus = "learned"
+		expectedCfg.SpellingPrefix = "fo"
+		expectedCfg.Sort = "-spelling"
+		expectedCfg.Limit = 10
+
+		if diff := cmp.Diff(expectedCfg, cfg); diff != "" {
+			t.Errorf("unexpected config (-want +got):\n%s", diff)
+		}
+	})
+	//nolint:paralleltest
+	t.Run("cli edit-word values", func(t *testing.T) {
+		var actualEnvs = map[string]string{
+			EnvPrefix + "MONGO_URI":      "",
+			EnvPrefix + "MONGO_DATABASE": "",
+			EnvPrefix + "AI_TOKEN":       "",
+		}
+
+		setEnv(actualEnvs)
+
+		cfg, err := ParseConfig([]string{"foo", string(EditWord), "-user-id=abc", "-word-id=def", "-definition=ddd", "-regenerate-exercises"})
+		if err != nil {
+			t.Errorf("unexpected error %s", err)
+		}
+
+		expectedCfg := configWithDefaults(EditWord)
+		expectedCfg.UserID = "abc"
+		expectedCfg.WordID = "def"
+		expectedCfg.Language = ""
+		expectedCfg.Definition = "ddd"
+		expectedCfg.RegenerateExercises = true
+
+		if diff := cmp.Diff(expectedCfg, cfg); diff != "" {
+			t.Errorf("unexpected config (-want +got):\n%s", diff)
+		}
+	})
+	//nolint:paralleltest
+	t.Run("cli practice values", func(t *testing.T) {
+		var actualEnvs = map[string]string{
+			EnvPrefix + "MONGO_URI":      "",
+			EnvPrefix + "MONGO_DATABASE": "",
+			EnvPrefix + "AI_TOKEN":       "",
+		}
+
+		setEnv(actualEnvs)
+
+		cfg, err := ParseConfig([]string{"foo", string(Practice), "-user-id=abc", "-session-size=5", "-exercise-type=definition"})
+		if err != nil {
+			t.Errorf("unexpected error %s", err)
+		}
+
+		expectedCfg := configWithDefaults(Practice)
+		expectedCfg.UserID = "abc"
+		expectedCfg.Language = ""
+		expectedCfg.SessionSize = 5
+		expectedCfg.ExerciseType = "definition"
+
+		if diff := cmp.Diff(expectedCfg, cfg); diff != "" {
+			t.Errorf("unexpected config (-want +got):\n%s", diff)
+		}
+	})
+	//nolint:paralleltest
+	t.Run("cli tui values", func(t *testing.T) {
+		var actualEnvs = map[string]string{
+			EnvPrefix + "MONGO_URI":      "",
+			EnvPrefix + "MONGO_DATABASE": "",
+			EnvPrefix + "AI_TOKEN":       "",
+		}
+
+		setEnv(actualEnvs)
+
+		cfg, err := ParseConfig([]string{"foo", string(TUI), "-user-id=abc", "-language=de"})
+		if err != nil {
+			t.Errorf("unexpected error %s", err)
+		}
+
+		expectedCfg := configWithDefaults(TUI)
+		expectedCfg.UserID = "abc"
+		expectedCfg.Language = "de"
+
+		if diff := cmp.Diff(expectedCfg, cfg); diff != "" {
+			t.Errorf("unexpected config (-want +got):\n%s", diff)
+		}
+	})
+	//nolint:paralleltest
+	t.Run("cli import values", func(t *testing.T) {
+		var actualEnvs = map[string]string{
+			EnvPrefix + "MONGO_URI":      "",
+			EnvPrefix + "MONGO_DATABASE": "",
+			EnvPrefix + "AI_TOKEN":       "",
+		}
+
+		setEnv(actualEnvs)
+
+		cfg, err := ParseConfig([]string{"foo", string(Import), "-user-id=abc", "-file=words.tsv", "-parallelism=8"})
+		if err != nil {
+			t.Errorf("unexpected error %s", err)
+		}
+
+		expectedCfg := configWithDefaults(Import)
+		expectedCfg.UserID = "abc"
+		expectedCfg.File = "words.tsv"
+		expectedCfg.Parallelism = 8
+
+		if diff := cmp.Diff(expectedCfg, cfg); diff != "" {
+			t.Errorf("unexpected config (-want +got):\n%s", diff)
+		}
+	})
+	//nolint:paralleltest
+	t.Run("cli cache purge values", func(t *testing.T) {
+		var actualEnvs = map[string]string{
+			EnvPrefix + "MONGO_URI":      "",
+			EnvPrefix + "MONGO_DATABASE": "",
+			EnvPrefix + "AI_TOKEN":       "",
+		}
+
+		setEnv(actualEnvs)
+
+		cfg, err := ParseConfig([]string{"foo", "cache", "purge", "-expired-only"})
+		if err != nil {
+			t.Errorf("unexpected error %s", err)
+		}
+
+		expectedCfg := configWithDefaults(CachePurge)
+		expectedCfg.ExpiredOnly = true
+
+		if diff := cmp.Diff(expectedCfg, cfg); diff != "" {
+			t.Errorf("unexpected config (-want +got):\n%s", diff)
+		}
+	})
+	//nolint:paralleltest
+	t.Run("cli migrate values", func(t *testing.T) {
+		var actualEnvs = map[string]string{
+			EnvPrefix + "MONGO_URI":      "",
+			EnvPrefix + "MONGO_DATABASE": "",
+			EnvPrefix + "AI_TOKEN":       "",
+		}
+
+		setEnv(actualEnvs)
+
+		for _, sb := range []Subcommand{MigrateUp, MigrateDown, MigrateStatus} {
+			cfg, err := ParseConfig(append([]string{"foo"}, strings.Fields(string(sb))...))
+			if err != nil {
+				t.Errorf("unexpected error %s", err)
+			}
+
+			if diff := cmp.Diff(configWithDefaults(sb), cfg); diff != "" {
+				t.Errorf("unexpected config of %s (-want +got):\n%s", sb, diff)
+			}
+		}
+	})
+	//nolint:paralleltest
+	t.Run("cli anki export values", func(t *testing.T) {
+		var actualEnvs = map[string]string{
+			EnvPrefix + "MONGO_URI":      "",
+			EnvPrefix + "MONGO_DATABASE": "",
+			EnvPrefix + "AI_TOKEN":       "",
+		}
+
+		setEnv(actualEnvs)
+
+		cfg, err := ParseConfig([]string{"foo", "anki", "export", "-user-id=abc", "-language=de", "-deck=German"})
+		if err != nil {
+			t.Errorf("unexpected error %s", err)
+		}
+
+		expectedCfg := configWithDefaults(AnkiExport)
+		expectedCfg.UserID = "abc"
+		expectedCfg.File = "vocabforge.apkg"
+		expectedCfg.Language = "de"
+		expectedCfg.Deck = "German"
+
+		if diff := cmp.Diff(expectedCfg, cfg); diff != "" {
+			t.Errorf("unexpected config (-want +got):\n%s", diff)
+		}
+	})
+	//nolint:paralleltest
+	t.Run("cli anki import values", func(t *testing.T) {
+		var actualEnvs = map[string]string{
+			EnvPrefix + "MONGO_URI":      "",
+			EnvPrefix + "MONGO_DATABASE": "",
+			EnvPrefix + "AI_TOKEN":       "",
+		}
+
+		setEnv(actualEnvs)
+
+		cfg, err := ParseConfig([]string{"foo", "anki", "import", "-user-id=abc", "-file=deck.apkg"})
+		if err != nil {
+			t.Errorf("unexpected error %s", err)
+		}
+
+		expectedCfg := configWithDefaults(AnkiImport)
+		expectedCfg.UserID = "abc"
+		expectedCfg.File = "deck.apkg"
+		expectedCfg.Language = ""
+
+		if diff := cmp.Diff(expectedCfg, cfg); diff != "" {
+			t.Errorf("unexpected config (-want +got):\n%s", diff)
+		}
+	})
+	//nolint:paralleltest
+	t.Run("cli kindle import values", func(t *testing.T) {
+		var actualEnvs = map[string]string{
+			EnvPrefix + "MONGO_URI":      "",
+			EnvPrefix + "MONGO_DATABASE": "",
+			EnvPrefix + "AI_TOKEN":       "",
+		}
+
+		setEnv(actualEnvs)
+
+		cfg, err := ParseConfig([]string{"foo", "kindle", "import", "-user-id=abc", "-language=en"})
+		if err != nil {
+			t.Errorf("unexpected error %s", err)
+		}
+
+		expectedCfg := configWithDefaults(KindleImport)
+		expectedCfg.UserID = "abc"
+		expectedCfg.File = "vocab.db"
+		expectedCfg.Language = "en"
+
+		if diff := cmp.Diff(expectedCfg, cfg); diff != "" {
+			t.Errorf("unexpected config (-want +got):\n%s", diff)
+		}
+	})
+	//nolint:paralleltest
+	t.Run("cli backup values", func(t *testing.T) {
+		var actualEnvs = map[string]string{
+			EnvPrefix + "MONGO_URI":      "",
+			EnvPrefix + "MONGO_DATABASE": "",
+			EnvPrefix + "AI_TOKEN":       "",
+		}
+
+		setEnv(actualEnvs)
+
+		cfg, err := ParseConfig([]string{"foo", string(Backup), "-user-id=abc", "-file=abc.jsonl"})
+		if err != nil {
+			t.Errorf("unexpected error %s", err)
+		}
+
+		expectedCfg := configWithDefaults(Backup)
+		expectedCfg.UserID = "abc"
+		expectedCfg.File = "abc.jsonl"
+
+		if diff := cmp.Diff(expectedCfg, cfg); diff != "" {
+			t.Errorf("unexpected config (-want +got):\n%s", diff)
+		}
+	})
+	//nolint:paralleltest
+	t.Run("cli restore values", func(t *testing.T) {
+		var actualEnvs = map[string]string{
+			EnvPrefix + "MONGO_URI":      "",
+			EnvPrefix + "MONGO_DATABASE": "",
+			EnvPrefix + "AI_TOKEN":       "",
+		}
+
+		setEnv(actualEnvs)
+
+		cfg, err := ParseConfig([]string{"foo", string(Restore), "-file=abc.json", "-format=json", "-dry-run"})
+		if err != nil {
+			t.Errorf("unexpected error %s", err)
+		}
+
+		expectedCfg := configWithDefaults(Restore)
+		expectedCfg.File = "abc.json"
+		expectedCfg.Format = "json"
+		expectedCfg.DryRun = true
+
+		if diff := cmp.Diff(expectedCfg, cfg); diff != "" {
+			t.Errorf("unexpected config (-want +got):\n%s", diff)
+		}
+	})
+	//nolint:paralleltest
+	t.Run("cli update-user values", func(t *testing.T) {
+		var actualEnvs = map[string]string{
+			EnvPrefix + "MONGO_URI":      "",
+			EnvPrefix + "MONGO_DATABASE": "",
+			EnvPrefix + "AI_TOKEN":       "",
+		}
+
+		setEnv(actualEnvs)
+
+		cfg, err := ParseConfig([]string{"foo", string(UpdateUser), "-user-id=abc", "-timezone=Europe/Berlin", "-daily-goal=20"})
+		if err != nil {
+			t.Errorf("unexpected error %s", err)
+		}
+
+		expectedCfg := configWithDefaults(UpdateUser)
+		expectedCfg.UserID = "abc"
+		expectedCfg.NativeLanguage = ""
+		expectedCfg.Timezone = "Europe/Berlin"
+		expectedCfg.DailyGoal = 20
+		expectedCfg.SessionSize = -1
+
 		if diff := cmp.Diff(expectedCfg, cfg); diff != "" {
 			t.Errorf("unexpected config (-want +got):\n%s", diff)
 		}
@@ -0,0 +1,72 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pavelpuchok/vocabforge/grpcapi/vocabforgev1"
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/users"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// methodScopes are the scopes required by every method, methods not listed here are refused.
+var methodScopes = map[string]users.Scope{
+	vocabforgev1.VocabforgeService_CreateUser_FullMethodName:        users.ScopeOperator,
+	vocabforgev1.VocabforgeService_GetUser_FullMethodName:           users.ScopeReadOnly,
+	vocabforgev1.VocabforgeService_AddWord_FullMethodName:           users.ScopePractice,
+	vocabforgev1.VocabforgeService_GetWord_FullMethodName:           users.ScopeReadOnly,
+	vocabforgev1.VocabforgeService_ListWords_FullMethodName:         users.ScopeReadOnly,
+	vocabforgev1.VocabforgeService_UpdateWord_FullMethodName:        users.ScopePractice,
+	vocabforgev1.VocabforgeService_DeleteWord_FullMethodName:        users.ScopePractice,
+	vocabforgev1.VocabforgeService_GenerateExercises_FullMethodName: users.ScopePractice,
+	vocabforgev1.VocabforgeService_StartPractice_FullMethodName:     users.ScopePractice,
+	vocabforgev1.VocabforgeService_SubmitAnswer_FullMethodName:      users.ScopePractice,
+}
+
+// UnaryAuthInterceptor resolves the principal by the bearer token in "authorization"
+// metadata and lets the call through only if its scope allows the method's one.
+func (s Server) UnaryAuthInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	required, ok := methodScopes[info.FullMethod]
+	if !ok {
+		return nil, s.toStatus(ctx, fmt.Errorf("%w: unknown method %s", errPermissionDenied, info.FullMethod))
+	}
+
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, v := range md.Get("authorization") {
+			if t, ok := strings.CutPrefix(v, "Bearer "); ok {
+				token = strings.TrimSpace(t)
+			}
+		}
+	}
+	if token == "" {
+		return nil, s.toStatus(ctx, fmt.Errorf("missing bearer token. %w", users.ErrUnauthenticated))
+	}
+
+	p, err := s.Auth.Authenticate(ctx, token)
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+	if !p.Scope.Allows(required) {
+		return nil, s.toStatus(ctx, fmt.Errorf("%w: %s scope is required", errPermissionDenied, required.String()))
+	}
+
+	return handler(users.ContextWithPrincipal(ctx, p), req)
+}
+
+// authorizedUserID parses the user ID of a request if the principal may access the user's data.
+func authorizedUserID(ctx context.Context, s string) (models.UserID, error) {
+	userID, err := models.UserIDFromText(s)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", errInvalidArgument, err)
+	}
+
+	p, ok := users.PrincipalFromContext(ctx)
+	if !ok || !p.CanAccess(userID) {
+		return "", fmt.Errorf("%w: no access to user %s", errPermissionDenied, userID)
+	}
+	return userID, nil
+}
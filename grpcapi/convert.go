@@ -0,0 +1,109 @@
+package grpcapi
+
+import (
+	"fmt"
+
+	pb "github.com/pavelpuchok/vocabforge/grpcapi/vocabforgev1"
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/practice"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var learnStatuses = map[models.LearnStatus]pb.LearnStatus{
+	models.Pending:    pb.LearnStatus_LEARN_STATUS_PENDING,
+	models.InProgress: pb.LearnStatus_LEARN_STATUS_IN_PROGRESS,
+	models.Learned:    pb.LearnStatus_LEARN_STATUS_LEARNED,
+}
+
+var exerciseTypes = map[models.ExerciseType]pb.ExerciseType{
+	models.ClozeExercise:      pb.ExerciseType_EXERCISE_TYPE_CLOZE,
+	models.DefinitionExercise: pb.ExerciseType_EXERCISE_TYPE_DEFINITION,
+}
+
+var sortOrders = map[pb.SortOrder]vocabulary.SortOrder{
+	pb.SortOrder_SORT_ORDER_UNSPECIFIED:   vocabulary.SortByCreatedAsc,
+	pb.SortOrder_SORT_ORDER_CREATED_ASC:   vocabulary.SortByCreatedAsc,
+	pb.SortOrder_SORT_ORDER_CREATED_DESC:  vocabulary.SortByCreatedDesc,
+	pb.SortOrder_SORT_ORDER_SPELLING_ASC:  vocabulary.SortBySpellingAsc,
+	pb.SortOrder_SORT_ORDER_SPELLING_DESC: vocabulary.SortBySpellingDesc,
+	pb.SortOrder_SORT_ORDER_DUE_ASC:       vocabulary.SortByDueAsc,
+}
+
+func learnStatusFromProto(s pb.LearnStatus) (models.LearnStatus, error) {
+	for m, p := range learnStatuses {
+		if p == s {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unknown learn status %s", errInvalidArgument, s)
+}
+
+func exerciseTypeFromProto(t pb.ExerciseType) (models.ExerciseType, error) {
+	for m, p := range exerciseTypes {
+		if p == t {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unknown exercise type %s", errInvalidArgument, t)
+}
+
+func languageFromProto(s string) (models.Language, error) {
+	if s == "" {
+		return "", nil
+	}
+	return models.LanguageFromText(s)
+}
+
+func userToProto(u models.User) *pb.User {
+	res := &pb.User{
+		Id:             u.ID.String(),
+		DisplayName:    u.DisplayName,
+		NativeLanguage: string(u.NativeLanguage),
+		Timezone:       u.Timezone,
+		DailyGoal:      int32(u.DailyGoal),
+		SessionSize:    int32(u.SessionSize),
+	}
+	for _, l := range u.TargetLanguages {
+		res.TargetLanguages = append(res.TargetLanguages, string(l))
+	}
+	for _, t := range u.ExerciseTypes {
+		res.ExerciseTypes = append(res.ExerciseTypes, exerciseTypes[t])
+	}
+	return res
+}
+
+func wordToProto(w models.Word) *pb.Word {
+	res := &pb.Word{
+		Id:                 w.ID.String(),
+		UserId:             w.UserID.String(),
+		Spelling:           w.Spelling,
+		Definition:         w.Definition,
+		DefinitionLanguage: string(w.DefinitionLanguage),
+		LexicalCategory:    w.LexicalCategory,
+		Language:           string(w.Language),
+		LearnStatus:        learnStatuses[w.LearnStatus],
+		AnsweredCount:      uint32(w.AnsweredCount),
+		Archived:           w.Archived,
+	}
+	for _, e := range w.Exercises {
+		res.Exercises = append(res.Exercises, &pb.SentenceExercise{Sentence: e.Sentence, Answered: e.Answered})
+	}
+	if !w.Schedule.Due.IsZero() {
+		res.Due = timestamppb.New(w.Schedule.Due)
+	}
+	return res
+}
+
+func sessionToProto(s practice.Session) *pb.StartPracticeResponse {
+	res := &pb.StartPracticeResponse{}
+	for _, item := range s.Items {
+		res.Items = append(res.Items, &pb.PracticeItem{
+			WordId:        item.Word.ID.String(),
+			ExerciseType:  exerciseTypes[item.Type],
+			ExerciseIndex: int32(item.ExerciseIndex),
+			Prompt:        item.Prompt(),
+		})
+	}
+	return res
+}
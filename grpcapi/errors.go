@@ -0,0 +1,67 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/practice"
+	"github.com/pavelpuchok/vocabforge/usecases/addword"
+	"github.com/pavelpuchok/vocabforge/users"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+	"github.com/pavelpuchok/vocabforge/vocabulary/sentences"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	errInvalidArgument  = errors.New("invalid argument")
+	errPermissionDenied = errors.New("permission denied")
+)
+
+// toStatus maps use case errors to gRPC status. Details of internal errors are logged only.
+func (s Server) toStatus(ctx context.Context, err error) error {
+	code := classifyError(err)
+	if code == codes.Internal {
+		s.Logger.ErrorContext(ctx, "grpcapi: call failed", slog.String("err", err.Error()))
+		return status.Error(code, "internal error")
+	}
+	return status.Error(code, err.Error())
+}
+
+func classifyError(err error) codes.Code {
+	switch {
+	case errors.Is(err, errInvalidArgument),
+		errors.Is(err, models.ErrInvalidLanguage),
+		errors.Is(err, users.ErrInvalidProfile),
+		errors.Is(err, vocabulary.ErrInvalidCursor),
+		errors.Is(err, vocabulary.ErrInvalidPatch),
+		errors.Is(err, addword.ErrMissingLanguage):
+		return codes.InvalidArgument
+	case errors.Is(err, users.ErrUnauthenticated):
+		return codes.Unauthenticated
+	case errors.Is(err, errPermissionDenied):
+		return codes.PermissionDenied
+	case errors.Is(err, users.ErrUserNotFound),
+		errors.Is(err, vocabulary.ErrWordNotFound),
+		errors.Is(err, practice.ErrExerciseNotFound):
+		return codes.NotFound
+	case errors.Is(err, sentences.ErrRateLimited),
+		errors.Is(err, sentences.ErrProviderUnavailable),
+		errors.Is(err, sentences.ErrCircuitOpen):
+		return codes.Unavailable
+	case errors.Is(err, sentences.ErrQuotaExhausted):
+		return codes.ResourceExhausted
+	case errors.Is(err, sentences.ErrContentRefused),
+		errors.Is(err, sentences.ErrNotEnoughSentences),
+		errors.Is(err, vocabulary.ErrMissingDefinition):
+		return codes.FailedPrecondition
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	default:
+		return codes.Internal
+	}
+}
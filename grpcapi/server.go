@@ -0,0 +1,374 @@
+// Package grpcapi serves the use cases over gRPC, see proto/vocabforge/v1 for the contract.
+package grpcapi
+
+//go:generate sh -c "cd .. && buf generate"
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"time"
+
+	pb "github.com/pavelpuchok/vocabforge/grpcapi/vocabforgev1"
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/practice"
+	"github.com/pavelpuchok/vocabforge/users"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+	"google.golang.org/grpc"
+)
+
+// Server implements VocabforgeService. Every field has to be set.
+type Server struct {
+	pb.UnimplementedVocabforgeServiceServer
+
+	UseCases UseCases
+	Auth     Authenticator
+	Logger   *slog.Logger
+}
+
+// UseCases are kept apart from Server as their names clash with the service methods.
+type UseCases struct {
+	CreateUser        CreateUserUseCase
+	GetUser           GetUserUseCase
+	AddWord           AddWordUseCase
+	GetWord           GetWordUseCase
+	ListWords         ListWordsUseCase
+	EditWord          EditWordUseCase
+	DeleteWord        DeleteWordUseCase
+	GenerateExercises GenerateExercisesUseCase
+	StartPractice     StartPracticeUseCase
+	Answer            AnswerUseCase
+}
+
+type CreateUserUseCase interface {
+	Run(ctx context.Context, profile models.User) (models.User, error)
+}
+
+type GetUserUseCase interface {
+	Run(ctx context.Context, userID models.UserID) (models.User, error)
+}
+
+type AddWordUseCase interface {
+	Run(ctx context.Context, userID models.UserID, spell, definition, lexicalCategory string, lang models.Language) (models.Word, error)
+}
+
+type GetWordUseCase interface {
+	Run(ctx context.Context, userID models.UserID, wordID models.WordID) (models.Word, error)
+}
+
+type ListWordsUseCase interface {
+	Run(ctx context.Context, userID models.UserID, filter vocabulary.ListFilter) (vocabulary.WordsPage, error)
+}
+
+type EditWordUseCase interface {
+	Run(ctx context.Context, userID models.UserID, wordID models.WordID, patch vocabulary.WordPatch, regenerateExercises bool) (models.Word, error)
+}
+
+type DeleteWordUseCase interface {
+	Run(ctx context.Context, userID models.UserID, wordID models.WordID, archive bool) error
+}
+
+type GenerateExercisesUseCase interface {
+	Run(ctx context.Context, userID models.UserID, wordID models.WordID) (models.Word, error)
+}
+
+type StartPracticeUseCase interface {
+	Run(ctx context.Context, userID models.UserID, lang models.Language, size int, t *practice.ExerciseType) (practice.Session, error)
+}
+
+type AnswerUseCase interface {
+	Run(ctx context.Context, userID models.UserID, wordID models.WordID, t practice.ExerciseType, exerciseIndex int, answer string) (practice.Result, error)
+}
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (users.Principal, error)
+}
+
+// NewGRPCServer returns gRPC server with s registered and authentication enabled. Every call
+// is bounded by requestTimeout.
+func NewGRPCServer(s Server, requestTimeout time.Duration) *grpc.Server {
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(UnaryTimeoutInterceptor(requestTimeout), s.UnaryAuthInterceptor))
+	pb.RegisterVocabforgeServiceServer(srv, s)
+	return srv
+}
+
+// UnaryTimeoutInterceptor cancels the context of a call once timeout passes, the same as
+// httpapi.WithRequestTimeout does for HTTP requests.
+func UnaryTimeoutInterceptor(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
+
+// Serve handles calls on l until ctx is done, then stops accepting new calls
+// and waits up to shutdownTimeout for the active ones to finish.
+func Serve(ctx context.Context, l net.Listener, srv *grpc.Server, shutdownTimeout time.Duration) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(l)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("grpcapi.Serve server failed. %w", err)
+	case <-ctx.Done():
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		srv.Stop()
+		return fmt.Errorf("grpcapi.Serve active calls didn't finish in %s", shutdownTimeout)
+	}
+	return nil
+}
+
+func (s Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
+	if req.GetNativeLanguage() == "" {
+		return nil, s.toStatus(ctx, fmt.Errorf("%w: native_language is required", errInvalidArgument))
+	}
+	nativeLang, err := models.LanguageFromText(req.GetNativeLanguage())
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+
+	profile := models.User{
+		DisplayName:    req.GetDisplayName(),
+		NativeLanguage: nativeLang,
+		Timezone:       req.GetTimezone(),
+		DailyGoal:      int(req.GetDailyGoal()),
+		SessionSize:    int(req.GetSessionSize()),
+	}
+	for _, l := range req.GetTargetLanguages() {
+		lang, err := models.LanguageFromText(l)
+		if err != nil {
+			return nil, s.toStatus(ctx, err)
+		}
+		profile.TargetLanguages = append(profile.TargetLanguages, lang)
+	}
+	for _, et := range req.GetExerciseTypes() {
+		t, err := exerciseTypeFromProto(et)
+		if err != nil {
+			return nil, s.toStatus(ctx, err)
+		}
+		profile.ExerciseTypes = append(profile.ExerciseTypes, t)
+	}
+
+	usr, err := s.UseCases.CreateUser.Run(ctx, profile)
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+	return &pb.CreateUserResponse{User: userToProto(usr)}, nil
+}
+
+func (s Server) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
+	userID, err := authorizedUserID(ctx, req.GetUserId())
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+
+	usr, err := s.UseCases.GetUser.Run(ctx, userID)
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+	return &pb.GetUserResponse{User: userToProto(usr)}, nil
+}
+
+func (s Server) AddWord(ctx context.Context, req *pb.AddWordRequest) (*pb.AddWordResponse, error) {
+	userID, err := authorizedUserID(ctx, req.GetUserId())
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+	if req.GetSpelling() == "" || req.GetDefinition() == "" {
+		return nil, s.toStatus(ctx, fmt.Errorf("%w: spelling and definition are required", errInvalidArgument))
+	}
+	lang, err := languageFromProto(req.GetLanguage())
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+
+	word, err := s.UseCases.AddWord.Run(ctx, userID, req.GetSpelling(), req.GetDefinition(), req.GetLexicalCategory(), lang)
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+	return &pb.AddWordResponse{Word: wordToProto(word)}, nil
+}
+
+func (s Server) GetWord(ctx context.Context, req *pb.GetWordRequest) (*pb.GetWordResponse, error) {
+	userID, wordID, err := wordIDs(ctx, req.GetUserId(), req.GetWordId())
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+
+	word, err := s.UseCases.GetWord.Run(ctx, userID, wordID)
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+	return &pb.GetWordResponse{Word: wordToProto(word)}, nil
+}
+
+func (s Server) ListWords(ctx context.Context, req *pb.ListWordsRequest) (*pb.ListWordsResponse, error) {
+	userID, err := authorizedUserID(ctx, req.GetUserId())
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+	if req.GetLimit() < 0 {
+		return nil, s.toStatus(ctx, fmt.Errorf("%w: negative limit", errInvalidArgument))
+	}
+
+	sort, ok := sortOrders[req.GetSort()]
+	if !ok {
+		return nil, s.toStatus(ctx, fmt.Errorf("%w: unknown sort order %s", errInvalidArgument, req.GetSort()))
+	}
+	filter := vocabulary.ListFilter{
+		LexicalCategory: req.GetLexicalCategory(),
+		SpellingPrefix:  req.GetSpellingPrefix(),
+		IncludeArchived: req.GetIncludeArchived(),
+		Sort:            sort,
+		Cursor:          req.GetCursor(),
+		Limit:           int(req.GetLimit()),
+	}
+	if filter.Language, err = languageFromProto(req.GetLanguage()); err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+	if req.GetLearnStatus() != pb.LearnStatus_LEARN_STATUS_UNSPECIFIED {
+		status, err := learnStatusFromProto(req.GetLearnStatus())
+		if err != nil {
+			return nil, s.toStatus(ctx, err)
+		}
+		filter.LearnStatus = &status
+	}
+
+	page, err := s.UseCases.ListWords.Run(ctx, userID, filter)
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+
+	res := &pb.ListWordsResponse{NextCursor: page.NextCursor}
+	for _, w := range page.Words {
+		res.Words = append(res.Words, wordToProto(w))
+	}
+	return res, nil
+}
+
+func (s Server) UpdateWord(ctx context.Context, req *pb.UpdateWordRequest) (*pb.UpdateWordResponse, error) {
+	userID, wordID, err := wordIDs(ctx, req.GetUserId(), req.GetWordId())
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+
+	patch := vocabulary.WordPatch{
+		Spelling:        req.Spelling,
+		Definition:      req.Definition,
+		LexicalCategory: req.LexicalCategory,
+	}
+	if req.Language != nil {
+		lang, err := models.LanguageFromText(req.GetLanguage())
+		if err != nil {
+			return nil, s.toStatus(ctx, err)
+		}
+		patch.Language = &lang
+	}
+
+	word, err := s.UseCases.EditWord.Run(ctx, userID, wordID, patch, req.GetRegenerateExercises())
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+	return &pb.UpdateWordResponse{Word: wordToProto(word)}, nil
+}
+
+func (s Server) DeleteWord(ctx context.Context, req *pb.DeleteWordRequest) (*pb.DeleteWordResponse, error) {
+	userID, wordID, err := wordIDs(ctx, req.GetUserId(), req.GetWordId())
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+
+	if err := s.UseCases.DeleteWord.Run(ctx, userID, wordID, req.GetArchive()); err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+	return &pb.DeleteWordResponse{}, nil
+}
+
+func (s Server) GenerateExercises(ctx context.Context, req *pb.GenerateExercisesRequest) (*pb.GenerateExercisesResponse, error) {
+	userID, wordID, err := wordIDs(ctx, req.GetUserId(), req.GetWordId())
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+
+	word, err := s.UseCases.GenerateExercises.Run(ctx, userID, wordID)
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+	return &pb.GenerateExercisesResponse{Word: wordToProto(word)}, nil
+}
+
+func (s Server) StartPractice(ctx context.Context, req *pb.StartPracticeRequest) (*pb.StartPracticeResponse, error) {
+	userID, err := authorizedUserID(ctx, req.GetUserId())
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+	if req.GetSize() < 0 {
+		return nil, s.toStatus(ctx, fmt.Errorf("%w: negative size", errInvalidArgument))
+	}
+	lang, err := languageFromProto(req.GetLanguage())
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+
+	var exerciseType *practice.ExerciseType
+	if req.GetExerciseType() != pb.ExerciseType_EXERCISE_TYPE_UNSPECIFIED {
+		t, err := exerciseTypeFromProto(req.GetExerciseType())
+		if err != nil {
+			return nil, s.toStatus(ctx, err)
+		}
+		exerciseType = &t
+	}
+
+	session, err := s.UseCases.StartPractice.Run(ctx, userID, lang, int(req.GetSize()), exerciseType)
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+	return sessionToProto(session), nil
+}
+
+func (s Server) SubmitAnswer(ctx context.Context, req *pb.SubmitAnswerRequest) (*pb.SubmitAnswerResponse, error) {
+	userID, wordID, err := wordIDs(ctx, req.GetUserId(), req.GetWordId())
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+	exerciseType, err := exerciseTypeFromProto(req.GetExerciseType())
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+
+	res, err := s.UseCases.Answer.Run(ctx, userID, wordID, exerciseType, int(req.GetExerciseIndex()), req.GetAnswer())
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+	return &pb.SubmitAnswerResponse{
+		Correct:     res.Correct,
+		Grade:       res.Grade.String(),
+		Expected:    res.Expected,
+		LearnStatus: learnStatuses[res.Word.LearnStatus],
+	}, nil
+}
+
+func wordIDs(ctx context.Context, userIDText, wordIDText string) (models.UserID, models.WordID, error) {
+	userID, err := authorizedUserID(ctx, userIDText)
+	if err != nil {
+		return "", "", err
+	}
+	wordID, err := models.WordIDFromText(wordIDText)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %w", errInvalidArgument, err)
+	}
+	return userID, wordID, nil
+}
@@ -0,0 +1,254 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	pb "github.com/pavelpuchok/vocabforge/grpcapi/vocabforgev1"
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/users"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	testUserID    = "000000000000000000000001"
+	testWordID    = "000000000000000000000002"
+	readOnlyToken = "vfk_read"
+	practiceToken = "vfk_practice"
+	adminToken    = "vfk_admin"
+	operatorToken = "vfk_operator"
+)
+
+type fakeAuth map[string]users.Principal
+
+func (a fakeAuth) Authenticate(_ context.Context, token string) (users.Principal, error) {
+	p, ok := a[token]
+	if !ok {
+		return users.Principal{}, users.ErrUnauthenticated
+	}
+	return p, nil
+}
+
+type fakeAddWord struct {
+	lang models.Language
+}
+
+func (f *fakeAddWord) Run(_ context.Context, userID models.UserID, spell, definition, lexicalCategory string, lang models.Language) (models.Word, error) {
+	f.lang = lang
+	return models.Word{ID: testWordID, UserID: userID, Spelling: spell, Definition: definition, LexicalCategory: lexicalCategory, Language: lang}, nil
+}
+
+type fakeGetWord struct{}
+
+func (fakeGetWord) Run(_ context.Context, userID models.UserID, wordID models.WordID) (models.Word, error) {
+	if wordID != testWordID {
+		return models.Word{}, fmt.Errorf("getword.UseCase.Run unable to get word. %w", vocabulary.ErrWordNotFound)
+	}
+	return models.Word{ID: wordID, UserID: userID, Spelling: "run", LearnStatus: models.InProgress}, nil
+}
+
+type fakeListWords struct {
+	filter vocabulary.ListFilter
+}
+
+func (f *fakeListWords) Run(_ context.Context, _ models.UserID, filter vocabulary.ListFilter) (vocabulary.WordsPage, error) {
+	f.filter = filter
+	return vocabulary.WordsPage{Words: []models.Word{{ID: testWordID, Spelling: "run"}}, NextCursor: "next"}, nil
+}
+
+type fakeDeleteWord struct{}
+
+func (fakeDeleteWord) Run(context.Context, models.UserID, models.WordID, bool) error {
+	return errors.New("connection lost")
+}
+
+func newTestClient(t *testing.T, s Server) pb.VocabforgeServiceClient {
+	t.Helper()
+
+	s.Auth = fakeAuth{
+		readOnlyToken: {UserID: testUserID, Scope: users.ScopeReadOnly},
+		practiceToken: {UserID: testUserID, Scope: users.ScopePractice},
+		adminToken:    {UserID: "000000000000000000000009", Scope: users.ScopeAdmin},
+		operatorToken: {UserID: "000000000000000000000009", Scope: users.ScopeOperator},
+	}
+	s.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	l := bufconn.Listen(1 << 20)
+	srv := NewGRPCServer(s, time.Minute)
+	go func() {
+		_ = srv.Serve(l)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return l.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewVocabforgeServiceClient(conn)
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestServer_Auth(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, Server{UseCases: UseCases{GetWord: fakeGetWord{}, AddWord: &fakeAddWord{}}})
+
+	cases := []struct {
+		name string
+		call func() error
+		code codes.Code
+	}{
+		{"missing token", func() error {
+			_, err := client.GetWord(context.Background(), &pb.GetWordRequest{UserId: testUserID, WordId: testWordID})
+			return err
+		}, codes.Unauthenticated},
+		{"unknown token", func() error {
+			_, err := client.GetWord(withToken("vfk_unknown"), &pb.GetWordRequest{UserId: testUserID, WordId: testWordID})
+			return err
+		}, codes.Unauthenticated},
+		{"read only scope", func() error {
+			_, err := client.GetWord(withToken(readOnlyToken), &pb.GetWordRequest{UserId: testUserID, WordId: testWordID})
+			return err
+		}, codes.OK},
+		{"read only scope writes", func() error {
+			_, err := client.AddWord(withToken(readOnlyToken), &pb.AddWordRequest{UserId: testUserID, Spelling: "run", Definition: "d"})
+			return err
+		}, codes.PermissionDenied},
+		{"other user", func() error {
+			_, err := client.GetWord(withToken(practiceToken), &pb.GetWordRequest{UserId: "000000000000000000000002", WordId: testWordID})
+			return err
+		}, codes.PermissionDenied},
+		{"admin of other user", func() error {
+			_, err := client.GetWord(withToken(adminToken), &pb.GetWordRequest{UserId: testUserID, WordId: testWordID})
+			return err
+		}, codes.PermissionDenied},
+		{"operator accesses other user", func() error {
+			_, err := client.GetWord(withToken(operatorToken), &pb.GetWordRequest{UserId: testUserID, WordId: testWordID})
+			return err
+		}, codes.OK},
+	}
+	for _, c := range cases {
+		if code := status.Code(c.call()); code != c.code {
+			t.Errorf("%s: expected code %s, got %s", c.name, c.code, code)
+		}
+	}
+}
+
+func TestServer_Words(t *testing.T) {
+	t.Parallel()
+
+	addWord := &fakeAddWord{}
+	listWords := &fakeListWords{}
+	client := newTestClient(t, Server{UseCases: UseCases{
+		AddWord:    addWord,
+		GetWord:    fakeGetWord{},
+		ListWords:  listWords,
+		DeleteWord: fakeDeleteWord{},
+	}})
+	ctx := withToken(practiceToken)
+
+	added, err := client.AddWord(ctx, &pb.AddWordRequest{UserId: testUserID, Spelling: "run", Definition: "move fast", Language: "en_GB"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added.GetWord().GetLanguage() != "en-GB" || addWord.lang != "en-GB" {
+		t.Errorf("unexpected word %v", added.GetWord())
+	}
+
+	got, err := client.GetWord(ctx, &pb.GetWordRequest{UserId: testUserID, WordId: testWordID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.GetWord().GetLearnStatus() != pb.LearnStatus_LEARN_STATUS_IN_PROGRESS {
+		t.Errorf("unexpected learn status %s", got.GetWord().GetLearnStatus())
+	}
+
+	page, err := client.ListWords(ctx, &pb.ListWordsRequest{
+		UserId:      testUserID,
+		LearnStatus: pb.LearnStatus_LEARN_STATUS_LEARNED,
+		Sort:        pb.SortOrder_SORT_ORDER_SPELLING_DESC,
+		Limit:       10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.GetNextCursor() != "next" || len(page.GetWords()) != 1 {
+		t.Errorf("unexpected page %v", page)
+	}
+	if listWords.filter.LearnStatus == nil || *listWords.filter.LearnStatus != models.Learned ||
+		listWords.filter.Sort != vocabulary.SortBySpellingDesc || listWords.filter.Limit != 10 {
+		t.Errorf("unexpected filter %+v", listWords.filter)
+	}
+
+	cases := []struct {
+		name string
+		call func() error
+		code codes.Code
+	}{
+		{"word not found", func() error {
+			_, err := client.GetWord(ctx, &pb.GetWordRequest{UserId: testUserID, WordId: "000000000000000000000003"})
+			return err
+		}, codes.NotFound},
+		{"invalid word id", func() error {
+			_, err := client.GetWord(ctx, &pb.GetWordRequest{UserId: testUserID, WordId: "abc"})
+			return err
+		}, codes.InvalidArgument},
+		{"missing definition", func() error {
+			_, err := client.AddWord(ctx, &pb.AddWordRequest{UserId: testUserID, Spelling: "run"})
+			return err
+		}, codes.InvalidArgument},
+		{"invalid language", func() error {
+			_, err := client.AddWord(ctx, &pb.AddWordRequest{UserId: testUserID, Spelling: "run", Definition: "d", Language: "!"})
+			return err
+		}, codes.InvalidArgument},
+		{"negative limit", func() error {
+			_, err := client.ListWords(ctx, &pb.ListWordsRequest{UserId: testUserID, Limit: -1})
+			return err
+		}, codes.InvalidArgument},
+		{"internal error", func() error {
+			_, err := client.DeleteWord(ctx, &pb.DeleteWordRequest{UserId: testUserID, WordId: testWordID})
+			if status.Convert(err).Message() != "internal error" {
+				t.Errorf("internal error details leaked: %s", err)
+			}
+			return err
+		}, codes.Internal},
+	}
+	for _, c := range cases {
+		if code := status.Code(c.call()); code != c.code {
+			t.Errorf("%s: expected code %s, got %s", c.name, c.code, code)
+		}
+	}
+}
+
+func TestUnaryTimeoutInterceptor(t *testing.T) {
+	t.Parallel()
+
+	interceptor := UnaryTimeoutInterceptor(time.Millisecond)
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ any) (any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
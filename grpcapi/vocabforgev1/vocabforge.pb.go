@@ -0,0 +1,2521 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: vocabforge/v1/vocabforge.proto
+
+package vocabforgev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LearnStatus int32
+
+const (
+	LearnStatus_LEARN_STATUS_UNSPECIFIED LearnStatus = 0
+	LearnStatus_LEARN_STATUS_PENDING     LearnStatus = 1
+	LearnStatus_LEARN_STATUS_IN_PROGRESS LearnStatus = 2
+	LearnStatus_LEARN_STATUS_LEARNED     LearnStatus = 3
+)
+
+// Enum value maps for LearnStatus.
+var (
+	LearnStatus_name = map[int32]string{
+		0: "LEARN_STATUS_UNSPECIFIED",
+		1: "LEARN_STATUS_PENDING",
+		2: "LEARN_STATUS_IN_PROGRESS",
+		3: "LEARN_STATUS_LEARNED",
+	}
+	LearnStatus_value = map[string]int32{
+		"LEARN_STATUS_UNSPECIFIED": 0,
+		"LEARN_STATUS_PENDING":     1,
+		"LEARN_STATUS_IN_PROGRESS": 2,
+		"LEARN_STATUS_LEARNED":     3,
+	}
+)
+
+func (x LearnStatus) Enum() *LearnStatus {
+	p := new(LearnStatus)
+	*p = x
+	return p
+}
+
+func (x LearnStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LearnStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_vocabforge_v1_vocabforge_proto_enumTypes[0].Descriptor()
+}
+
+func (LearnStatus) Type() protoreflect.EnumType {
+	return &file_vocabforge_v1_vocabforge_proto_enumTypes[0]
+}
+
+func (x LearnStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LearnStatus.Descriptor instead.
+func (LearnStatus) EnumDescriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{0}
+}
+
+type ExerciseType int32
+
+const (
+	ExerciseType_EXERCISE_TYPE_UNSPECIFIED ExerciseType = 0
+	ExerciseType_EXERCISE_TYPE_CLOZE       ExerciseType = 1
+	ExerciseType_EXERCISE_TYPE_DEFINITION  ExerciseType = 2
+)
+
+// Enum value maps for ExerciseType.
+var (
+	ExerciseType_name = map[int32]string{
+		0: "EXERCISE_TYPE_UNSPECIFIED",
+		1: "EXERCISE_TYPE_CLOZE",
+		2: "EXERCISE_TYPE_DEFINITION",
+	}
+	ExerciseType_value = map[string]int32{
+		"EXERCISE_TYPE_UNSPECIFIED": 0,
+		"EXERCISE_TYPE_CLOZE":       1,
+		"EXERCISE_TYPE_DEFINITION":  2,
+	}
+)
+
+func (x ExerciseType) Enum() *ExerciseType {
+	p := new(ExerciseType)
+	*p = x
+	return p
+}
+
+func (x ExerciseType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExerciseType) Descriptor() protoreflect.EnumDescriptor {
+	return file_vocabforge_v1_vocabforge_proto_enumTypes[1].Descriptor()
+}
+
+func (ExerciseType) Type() protoreflect.EnumType {
+	return &file_vocabforge_v1_vocabforge_proto_enumTypes[1]
+}
+
+func (x ExerciseType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExerciseType.Descriptor instead.
+func (ExerciseType) EnumDescriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{1}
+}
+
+type SortOrder int32
+
+const (
+	SortOrder_SORT_ORDER_UNSPECIFIED   SortOrder = 0
+	SortOrder_SORT_ORDER_CREATED_ASC   SortOrder = 1
+	SortOrder_SORT_ORDER_CREATED_DESC  SortOrder = 2
+	SortOrder_SORT_ORDER_SPELLING_ASC  SortOrder = 3
+	SortOrder_SORT_ORDER_SPELLING_DESC SortOrder = 4
+	SortOrder_SORT_ORDER_DUE_ASC       SortOrder = 5
+)
+
+// Enum value maps for SortOrder.
+var (
+	SortOrder_name = map[int32]string{
+		0: "SORT_ORDER_UNSPECIFIED",
+		1: "SORT_ORDER_CREATED_ASC",
+		2: "SORT_ORDER_CREATED_DESC",
+		3: "SORT_ORDER_SPELLING_ASC",
+		4: "SORT_ORDER_SPELLING_DESC",
+		5: "SORT_ORDER_DUE_ASC",
+	}
+	SortOrder_value = map[string]int32{
+		"SORT_ORDER_UNSPECIFIED":   0,
+		"SORT_ORDER_CREATED_ASC":   1,
+		"SORT_ORDER_CREATED_DESC":  2,
+		"SORT_ORDER_SPELLING_ASC":  3,
+		"SORT_ORDER_SPELLING_DESC": 4,
+		"SORT_ORDER_DUE_ASC":       5,
+	}
+)
+
+func (x SortOrder) Enum() *SortOrder {
+	p := new(SortOrder)
+	*p = x
+	return p
+}
+
+func (x SortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_vocabforge_v1_vocabforge_proto_enumTypes[2].Descriptor()
+}
+
+func (SortOrder) Type() protoreflect.EnumType {
+	return &file_vocabforge_v1_vocabforge_proto_enumTypes[2]
+}
+
+func (x SortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortOrder.Descriptor instead.
+func (SortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{2}
+}
+
+type User struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id              string         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DisplayName     string         `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	NativeLanguage  string         `protobuf:"bytes,3,opt,name=native_language,json=nativeLanguage,proto3" json:"native_language,omitempty"`
+	TargetLanguages []string       `protobuf:"bytes,4,rep,name=target_languages,json=targetLanguages,proto3" json:"target_languages,omitempty"`
+	Timezone        string         `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	DailyGoal       int32          `protobuf:"varint,6,opt,name=daily_goal,json=dailyGoal,proto3" json:"daily_goal,omitempty"`
+	ExerciseTypes   []ExerciseType `protobuf:"varint,7,rep,packed,name=exercise_types,json=exerciseTypes,proto3,enum=vocabforge.v1.ExerciseType" json:"exercise_types,omitempty"`
+	SessionSize     int32          `protobuf:"varint,8,opt,name=session_size,json=sessionSize,proto3" json:"session_size,omitempty"`
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *User) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *User) GetNativeLanguage() string {
+	if x != nil {
+		return x.NativeLanguage
+	}
+	return ""
+}
+
+func (x *User) GetTargetLanguages() []string {
+	if x != nil {
+		return x.TargetLanguages
+	}
+	return nil
+}
+
+func (x *User) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *User) GetDailyGoal() int32 {
+	if x != nil {
+		return x.DailyGoal
+	}
+	return 0
+}
+
+func (x *User) GetExerciseTypes() []ExerciseType {
+	if x != nil {
+		return x.ExerciseTypes
+	}
+	return nil
+}
+
+func (x *User) GetSessionSize() int32 {
+	if x != nil {
+		return x.SessionSize
+	}
+	return 0
+}
+
+type CreateUserRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DisplayName string `protobuf:"bytes,1,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	// BCP 47 tag, for ex: en-US. Required.
+	NativeLanguage  string         `protobuf:"bytes,2,opt,name=native_language,json=nativeLanguage,proto3" json:"native_language,omitempty"`
+	TargetLanguages []string       `protobuf:"bytes,3,rep,name=target_languages,json=targetLanguages,proto3" json:"target_languages,omitempty"`
+	Timezone        string         `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	DailyGoal       int32          `protobuf:"varint,5,opt,name=daily_goal,json=dailyGoal,proto3" json:"daily_goal,omitempty"`
+	ExerciseTypes   []ExerciseType `protobuf:"varint,6,rep,packed,name=exercise_types,json=exerciseTypes,proto3,enum=vocabforge.v1.ExerciseType" json:"exercise_types,omitempty"`
+	SessionSize     int32          `protobuf:"varint,7,opt,name=session_size,json=sessionSize,proto3" json:"session_size,omitempty"`
+}
+
+func (x *CreateUserRequest) Reset() {
+	*x = CreateUserRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserRequest) ProtoMessage() {}
+
+func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
+func (*CreateUserRequest) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateUserRequest) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetNativeLanguage() string {
+	if x != nil {
+		return x.NativeLanguage
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetTargetLanguages() []string {
+	if x != nil {
+		return x.TargetLanguages
+	}
+	return nil
+}
+
+func (x *CreateUserRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetDailyGoal() int32 {
+	if x != nil {
+		return x.DailyGoal
+	}
+	return 0
+}
+
+func (x *CreateUserRequest) GetExerciseTypes() []ExerciseType {
+	if x != nil {
+		return x.ExerciseTypes
+	}
+	return nil
+}
+
+func (x *CreateUserRequest) GetSessionSize() int32 {
+	if x != nil {
+		return x.SessionSize
+	}
+	return 0
+}
+
+type CreateUserResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	User *User `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+}
+
+func (x *CreateUserResponse) Reset() {
+	*x = CreateUserResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserResponse) ProtoMessage() {}
+
+func (x *CreateUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserResponse.ProtoReflect.Descriptor instead.
+func (*CreateUserResponse) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type GetUserRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetUserResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	User *User `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+}
+
+func (x *GetUserResponse) Reset() {
+	*x = GetUserResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserResponse) ProtoMessage() {}
+
+func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserResponse.ProtoReflect.Descriptor instead.
+func (*GetUserResponse) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type SentenceExercise struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sentence string `protobuf:"bytes,1,opt,name=sentence,proto3" json:"sentence,omitempty"`
+	Answered bool   `protobuf:"varint,2,opt,name=answered,proto3" json:"answered,omitempty"`
+}
+
+func (x *SentenceExercise) Reset() {
+	*x = SentenceExercise{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SentenceExercise) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SentenceExercise) ProtoMessage() {}
+
+func (x *SentenceExercise) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SentenceExercise.ProtoReflect.Descriptor instead.
+func (*SentenceExercise) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SentenceExercise) GetSentence() string {
+	if x != nil {
+		return x.Sentence
+	}
+	return ""
+}
+
+func (x *SentenceExercise) GetAnswered() bool {
+	if x != nil {
+		return x.Answered
+	}
+	return false
+}
+
+type Word struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId             string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Spelling           string                 `protobuf:"bytes,3,opt,name=spelling,proto3" json:"spelling,omitempty"`
+	Definition         string                 `protobuf:"bytes,4,opt,name=definition,proto3" json:"definition,omitempty"`
+	DefinitionLanguage string                 `protobuf:"bytes,5,opt,name=definition_language,json=definitionLanguage,proto3" json:"definition_language,omitempty"`
+	LexicalCategory    string                 `protobuf:"bytes,6,opt,name=lexical_category,json=lexicalCategory,proto3" json:"lexical_category,omitempty"`
+	Language           string                 `protobuf:"bytes,7,opt,name=language,proto3" json:"language,omitempty"`
+	LearnStatus        LearnStatus            `protobuf:"varint,8,opt,name=learn_status,json=learnStatus,proto3,enum=vocabforge.v1.LearnStatus" json:"learn_status,omitempty"`
+	AnsweredCount      uint32                 `protobuf:"varint,9,opt,name=answered_count,json=answeredCount,proto3" json:"answered_count,omitempty"`
+	Exercises          []*SentenceExercise    `protobuf:"bytes,10,rep,name=exercises,proto3" json:"exercises,omitempty"`
+	Archived           bool                   `protobuf:"varint,11,opt,name=archived,proto3" json:"archived,omitempty"`
+	Due                *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=due,proto3" json:"due,omitempty"`
+}
+
+func (x *Word) Reset() {
+	*x = Word{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Word) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Word) ProtoMessage() {}
+
+func (x *Word) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Word.ProtoReflect.Descriptor instead.
+func (*Word) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Word) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Word) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Word) GetSpelling() string {
+	if x != nil {
+		return x.Spelling
+	}
+	return ""
+}
+
+func (x *Word) GetDefinition() string {
+	if x != nil {
+		return x.Definition
+	}
+	return ""
+}
+
+func (x *Word) GetDefinitionLanguage() string {
+	if x != nil {
+		return x.DefinitionLanguage
+	}
+	return ""
+}
+
+func (x *Word) GetLexicalCategory() string {
+	if x != nil {
+		return x.LexicalCategory
+	}
+	return ""
+}
+
+func (x *Word) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Word) GetLearnStatus() LearnStatus {
+	if x != nil {
+		return x.LearnStatus
+	}
+	return LearnStatus_LEARN_STATUS_UNSPECIFIED
+}
+
+func (x *Word) GetAnsweredCount() uint32 {
+	if x != nil {
+		return x.AnsweredCount
+	}
+	return 0
+}
+
+func (x *Word) GetExercises() []*SentenceExercise {
+	if x != nil {
+		return x.Exercises
+	}
+	return nil
+}
+
+func (x *Word) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *Word) GetDue() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Due
+	}
+	return nil
+}
+
+type AddWordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId          string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Spelling        string `protobuf:"bytes,2,opt,name=spelling,proto3" json:"spelling,omitempty"`
+	Definition      string `protobuf:"bytes,3,opt,name=definition,proto3" json:"definition,omitempty"`
+	LexicalCategory string `protobuf:"bytes,4,opt,name=lexical_category,json=lexicalCategory,proto3" json:"lexical_category,omitempty"`
+	// The user's first target language if empty.
+	Language string `protobuf:"bytes,5,opt,name=language,proto3" json:"language,omitempty"`
+}
+
+func (x *AddWordRequest) Reset() {
+	*x = AddWordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddWordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddWordRequest) ProtoMessage() {}
+
+func (x *AddWordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddWordRequest.ProtoReflect.Descriptor instead.
+func (*AddWordRequest) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AddWordRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AddWordRequest) GetSpelling() string {
+	if x != nil {
+		return x.Spelling
+	}
+	return ""
+}
+
+func (x *AddWordRequest) GetDefinition() string {
+	if x != nil {
+		return x.Definition
+	}
+	return ""
+}
+
+func (x *AddWordRequest) GetLexicalCategory() string {
+	if x != nil {
+		return x.LexicalCategory
+	}
+	return ""
+}
+
+func (x *AddWordRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type AddWordResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Word *Word `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+}
+
+func (x *AddWordResponse) Reset() {
+	*x = AddWordResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddWordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddWordResponse) ProtoMessage() {}
+
+func (x *AddWordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddWordResponse.ProtoReflect.Descriptor instead.
+func (*AddWordResponse) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AddWordResponse) GetWord() *Word {
+	if x != nil {
+		return x.Word
+	}
+	return nil
+}
+
+type GetWordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WordId string `protobuf:"bytes,2,opt,name=word_id,json=wordId,proto3" json:"word_id,omitempty"`
+}
+
+func (x *GetWordRequest) Reset() {
+	*x = GetWordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetWordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWordRequest) ProtoMessage() {}
+
+func (x *GetWordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWordRequest.ProtoReflect.Descriptor instead.
+func (*GetWordRequest) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetWordRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetWordRequest) GetWordId() string {
+	if x != nil {
+		return x.WordId
+	}
+	return ""
+}
+
+type GetWordResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Word *Word `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+}
+
+func (x *GetWordResponse) Reset() {
+	*x = GetWordResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetWordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWordResponse) ProtoMessage() {}
+
+func (x *GetWordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWordResponse.ProtoReflect.Descriptor instead.
+func (*GetWordResponse) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetWordResponse) GetWord() *Word {
+	if x != nil {
+		return x.Word
+	}
+	return nil
+}
+
+type ListWordsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId          string      `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Language        string      `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	LearnStatus     LearnStatus `protobuf:"varint,3,opt,name=learn_status,json=learnStatus,proto3,enum=vocabforge.v1.LearnStatus" json:"learn_status,omitempty"`
+	LexicalCategory string      `protobuf:"bytes,4,opt,name=lexical_category,json=lexicalCategory,proto3" json:"lexical_category,omitempty"`
+	SpellingPrefix  string      `protobuf:"bytes,5,opt,name=spelling_prefix,json=spellingPrefix,proto3" json:"spelling_prefix,omitempty"`
+	Sort            SortOrder   `protobuf:"varint,6,opt,name=sort,proto3,enum=vocabforge.v1.SortOrder" json:"sort,omitempty"`
+	Cursor          string      `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit           int32       `protobuf:"varint,8,opt,name=limit,proto3" json:"limit,omitempty"`
+	IncludeArchived bool        `protobuf:"varint,9,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+}
+
+func (x *ListWordsRequest) Reset() {
+	*x = ListWordsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListWordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWordsRequest) ProtoMessage() {}
+
+func (x *ListWordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWordsRequest.ProtoReflect.Descriptor instead.
+func (*ListWordsRequest) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListWordsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListWordsRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *ListWordsRequest) GetLearnStatus() LearnStatus {
+	if x != nil {
+		return x.LearnStatus
+	}
+	return LearnStatus_LEARN_STATUS_UNSPECIFIED
+}
+
+func (x *ListWordsRequest) GetLexicalCategory() string {
+	if x != nil {
+		return x.LexicalCategory
+	}
+	return ""
+}
+
+func (x *ListWordsRequest) GetSpellingPrefix() string {
+	if x != nil {
+		return x.SpellingPrefix
+	}
+	return ""
+}
+
+func (x *ListWordsRequest) GetSort() SortOrder {
+	if x != nil {
+		return x.Sort
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+func (x *ListWordsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListWordsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListWordsRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+type ListWordsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Words []*Word `protobuf:"bytes,1,rep,name=words,proto3" json:"words,omitempty"`
+	// Empty when there are no more words.
+	NextCursor string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+}
+
+func (x *ListWordsResponse) Reset() {
+	*x = ListWordsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListWordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWordsResponse) ProtoMessage() {}
+
+func (x *ListWordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWordsResponse.ProtoReflect.Descriptor instead.
+func (*ListWordsResponse) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListWordsResponse) GetWords() []*Word {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+func (x *ListWordsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type UpdateWordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId          string  `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WordId          string  `protobuf:"bytes,2,opt,name=word_id,json=wordId,proto3" json:"word_id,omitempty"`
+	Spelling        *string `protobuf:"bytes,3,opt,name=spelling,proto3,oneof" json:"spelling,omitempty"`
+	Definition      *string `protobuf:"bytes,4,opt,name=definition,proto3,oneof" json:"definition,omitempty"`
+	LexicalCategory *string `protobuf:"bytes,5,opt,name=lexical_category,json=lexicalCategory,proto3,oneof" json:"lexical_category,omitempty"`
+	Language        *string `protobuf:"bytes,6,opt,name=language,proto3,oneof" json:"language,omitempty"`
+	// Regenerate exercises when spelling or definition changes.
+	RegenerateExercises bool `protobuf:"varint,7,opt,name=regenerate_exercises,json=regenerateExercises,proto3" json:"regenerate_exercises,omitempty"`
+}
+
+func (x *UpdateWordRequest) Reset() {
+	*x = UpdateWordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateWordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWordRequest) ProtoMessage() {}
+
+func (x *UpdateWordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWordRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWordRequest) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateWordRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateWordRequest) GetWordId() string {
+	if x != nil {
+		return x.WordId
+	}
+	return ""
+}
+
+func (x *UpdateWordRequest) GetSpelling() string {
+	if x != nil && x.Spelling != nil {
+		return *x.Spelling
+	}
+	return ""
+}
+
+func (x *UpdateWordRequest) GetDefinition() string {
+	if x != nil && x.Definition != nil {
+		return *x.Definition
+	}
+	return ""
+}
+
+func (x *UpdateWordRequest) GetLexicalCategory() string {
+	if x != nil && x.LexicalCategory != nil {
+		return *x.LexicalCategory
+	}
+	return ""
+}
+
+func (x *UpdateWordRequest) GetLanguage() string {
+	if x != nil && x.Language != nil {
+		return *x.Language
+	}
+	return ""
+}
+
+func (x *UpdateWordRequest) GetRegenerateExercises() bool {
+	if x != nil {
+		return x.RegenerateExercises
+	}
+	return false
+}
+
+type UpdateWordResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Word *Word `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+}
+
+func (x *UpdateWordResponse) Reset() {
+	*x = UpdateWordResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateWordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWordResponse) ProtoMessage() {}
+
+func (x *UpdateWordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWordResponse.ProtoReflect.Descriptor instead.
+func (*UpdateWordResponse) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateWordResponse) GetWord() *Word {
+	if x != nil {
+		return x.Word
+	}
+	return nil
+}
+
+type DeleteWordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WordId string `protobuf:"bytes,2,opt,name=word_id,json=wordId,proto3" json:"word_id,omitempty"`
+	// Archive the word instead of deleting it.
+	Archive bool `protobuf:"varint,3,opt,name=archive,proto3" json:"archive,omitempty"`
+}
+
+func (x *DeleteWordRequest) Reset() {
+	*x = DeleteWordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteWordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWordRequest) ProtoMessage() {}
+
+func (x *DeleteWordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWordRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWordRequest) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteWordRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteWordRequest) GetWordId() string {
+	if x != nil {
+		return x.WordId
+	}
+	return ""
+}
+
+func (x *DeleteWordRequest) GetArchive() bool {
+	if x != nil {
+		return x.Archive
+	}
+	return false
+}
+
+type DeleteWordResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteWordResponse) Reset() {
+	*x = DeleteWordResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteWordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWordResponse) ProtoMessage() {}
+
+func (x *DeleteWordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWordResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWordResponse) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{16}
+}
+
+type GenerateExercisesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WordId string `protobuf:"bytes,2,opt,name=word_id,json=wordId,proto3" json:"word_id,omitempty"`
+}
+
+func (x *GenerateExercisesRequest) Reset() {
+	*x = GenerateExercisesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenerateExercisesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateExercisesRequest) ProtoMessage() {}
+
+func (x *GenerateExercisesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateExercisesRequest.ProtoReflect.Descriptor instead.
+func (*GenerateExercisesRequest) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GenerateExercisesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GenerateExercisesRequest) GetWordId() string {
+	if x != nil {
+		return x.WordId
+	}
+	return ""
+}
+
+type GenerateExercisesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Word *Word `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+}
+
+func (x *GenerateExercisesResponse) Reset() {
+	*x = GenerateExercisesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenerateExercisesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateExercisesResponse) ProtoMessage() {}
+
+func (x *GenerateExercisesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateExercisesResponse.ProtoReflect.Descriptor instead.
+func (*GenerateExercisesResponse) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GenerateExercisesResponse) GetWord() *Word {
+	if x != nil {
+		return x.Word
+	}
+	return nil
+}
+
+type StartPracticeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId   string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Language string `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	// The user's preferences are used for zero size and unspecified type.
+	Size         int32        `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	ExerciseType ExerciseType `protobuf:"varint,4,opt,name=exercise_type,json=exerciseType,proto3,enum=vocabforge.v1.ExerciseType" json:"exercise_type,omitempty"`
+}
+
+func (x *StartPracticeRequest) Reset() {
+	*x = StartPracticeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartPracticeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartPracticeRequest) ProtoMessage() {}
+
+func (x *StartPracticeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartPracticeRequest.ProtoReflect.Descriptor instead.
+func (*StartPracticeRequest) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *StartPracticeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *StartPracticeRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *StartPracticeRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *StartPracticeRequest) GetExerciseType() ExerciseType {
+	if x != nil {
+		return x.ExerciseType
+	}
+	return ExerciseType_EXERCISE_TYPE_UNSPECIFIED
+}
+
+type PracticeItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WordId        string       `protobuf:"bytes,1,opt,name=word_id,json=wordId,proto3" json:"word_id,omitempty"`
+	ExerciseType  ExerciseType `protobuf:"varint,2,opt,name=exercise_type,json=exerciseType,proto3,enum=vocabforge.v1.ExerciseType" json:"exercise_type,omitempty"`
+	ExerciseIndex int32        `protobuf:"varint,3,opt,name=exercise_index,json=exerciseIndex,proto3" json:"exercise_index,omitempty"`
+	Prompt        string       `protobuf:"bytes,4,opt,name=prompt,proto3" json:"prompt,omitempty"`
+}
+
+func (x *PracticeItem) Reset() {
+	*x = PracticeItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PracticeItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PracticeItem) ProtoMessage() {}
+
+func (x *PracticeItem) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PracticeItem.ProtoReflect.Descriptor instead.
+func (*PracticeItem) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PracticeItem) GetWordId() string {
+	if x != nil {
+		return x.WordId
+	}
+	return ""
+}
+
+func (x *PracticeItem) GetExerciseType() ExerciseType {
+	if x != nil {
+		return x.ExerciseType
+	}
+	return ExerciseType_EXERCISE_TYPE_UNSPECIFIED
+}
+
+func (x *PracticeItem) GetExerciseIndex() int32 {
+	if x != nil {
+		return x.ExerciseIndex
+	}
+	return 0
+}
+
+func (x *PracticeItem) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+type StartPracticeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*PracticeItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *StartPracticeResponse) Reset() {
+	*x = StartPracticeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartPracticeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartPracticeResponse) ProtoMessage() {}
+
+func (x *StartPracticeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartPracticeResponse.ProtoReflect.Descriptor instead.
+func (*StartPracticeResponse) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *StartPracticeResponse) GetItems() []*PracticeItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type SubmitAnswerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId       string       `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WordId       string       `protobuf:"bytes,2,opt,name=word_id,json=wordId,proto3" json:"word_id,omitempty"`
+	ExerciseType ExerciseType `protobuf:"varint,3,opt,name=exercise_type,json=exerciseType,proto3,enum=vocabforge.v1.ExerciseType" json:"exercise_type,omitempty"`
+	// Ignored for definition exercises.
+	ExerciseIndex int32  `protobuf:"varint,4,opt,name=exercise_index,json=exerciseIndex,proto3" json:"exercise_index,omitempty"`
+	Answer        string `protobuf:"bytes,5,opt,name=answer,proto3" json:"answer,omitempty"`
+}
+
+func (x *SubmitAnswerRequest) Reset() {
+	*x = SubmitAnswerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubmitAnswerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitAnswerRequest) ProtoMessage() {}
+
+func (x *SubmitAnswerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitAnswerRequest.ProtoReflect.Descriptor instead.
+func (*SubmitAnswerRequest) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SubmitAnswerRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SubmitAnswerRequest) GetWordId() string {
+	if x != nil {
+		return x.WordId
+	}
+	return ""
+}
+
+func (x *SubmitAnswerRequest) GetExerciseType() ExerciseType {
+	if x != nil {
+		return x.ExerciseType
+	}
+	return ExerciseType_EXERCISE_TYPE_UNSPECIFIED
+}
+
+func (x *SubmitAnswerRequest) GetExerciseIndex() int32 {
+	if x != nil {
+		return x.ExerciseIndex
+	}
+	return 0
+}
+
+func (x *SubmitAnswerRequest) GetAnswer() string {
+	if x != nil {
+		return x.Answer
+	}
+	return ""
+}
+
+type SubmitAnswerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Correct     bool        `protobuf:"varint,1,opt,name=correct,proto3" json:"correct,omitempty"`
+	Grade       string      `protobuf:"bytes,2,opt,name=grade,proto3" json:"grade,omitempty"`
+	Expected    string      `protobuf:"bytes,3,opt,name=expected,proto3" json:"expected,omitempty"`
+	LearnStatus LearnStatus `protobuf:"varint,4,opt,name=learn_status,json=learnStatus,proto3,enum=vocabforge.v1.LearnStatus" json:"learn_status,omitempty"`
+}
+
+func (x *SubmitAnswerResponse) Reset() {
+	*x = SubmitAnswerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubmitAnswerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitAnswerResponse) ProtoMessage() {}
+
+func (x *SubmitAnswerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vocabforge_v1_vocabforge_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitAnswerResponse.ProtoReflect.Descriptor instead.
+func (*SubmitAnswerResponse) Descriptor() ([]byte, []int) {
+	return file_vocabforge_v1_vocabforge_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SubmitAnswerResponse) GetCorrect() bool {
+	if x != nil {
+		return x.Correct
+	}
+	return false
+}
+
+func (x *SubmitAnswerResponse) GetGrade() string {
+	if x != nil {
+		return x.Grade
+	}
+	return ""
+}
+
+func (x *SubmitAnswerResponse) GetExpected() string {
+	if x != nil {
+		return x.Expected
+	}
+	return ""
+}
+
+func (x *SubmitAnswerResponse) GetLearnStatus() LearnStatus {
+	if x != nil {
+		return x.LearnStatus
+	}
+	return LearnStatus_LEARN_STATUS_UNSPECIFIED
+}
+
+var File_vocabforge_v1_vocabforge_proto protoreflect.FileDescriptor
+
+var file_vocabforge_v1_vocabforge_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2f, 0x76, 0x31, 0x2f,
+	0x76, 0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x0d, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x1a,
+	0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0xaf, 0x02, 0x0a, 0x04, 0x55, 0x73, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73,
+	0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x0f,
+	0x6e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x4c, 0x61, 0x6e,
+	0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f,
+	0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
+	0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x67, 0x6f, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x09, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x47, 0x6f, 0x61, 0x6c, 0x12, 0x42, 0x0a, 0x0e, 0x65,
+	0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x07, 0x20,
+	0x03, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x0d, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12,
+	0x21, 0x0a, 0x0c, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x69,
+	0x7a, 0x65, 0x22, 0xac, 0x02, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70,
+	0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x6e,
+	0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x4c, 0x61, 0x6e, 0x67,
+	0x75, 0x61, 0x67, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6c,
+	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x64,
+	0x61, 0x69, 0x6c, 0x79, 0x5f, 0x67, 0x6f, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x09, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x47, 0x6f, 0x61, 0x6c, 0x12, 0x42, 0x0a, 0x0e, 0x65, 0x78,
+	0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03,
+	0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x0d, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x21,
+	0x0a, 0x0c, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x69, 0x7a,
+	0x65, 0x22, 0x3d, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72,
+	0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72,
+	0x22, 0x29, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x3a, 0x0a, 0x0f, 0x47,
+	0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27,
+	0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76,
+	0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65,
+	0x72, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x22, 0x4a, 0x0a, 0x10, 0x53, 0x65, 0x6e, 0x74, 0x65,
+	0x6e, 0x63, 0x65, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x65, 0x6e, 0x74, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73,
+	0x65, 0x6e, 0x74, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x65, 0x64, 0x22, 0xd2, 0x03, 0x0a, 0x04, 0x57, 0x6f, 0x72, 0x64, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x70, 0x65, 0x6c, 0x6c, 0x69, 0x6e,
+	0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x70, 0x65, 0x6c, 0x6c, 0x69, 0x6e,
+	0x67, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x2f, 0x0a, 0x13, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12,
+	0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61,
+	0x67, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x63, 0x61,
+	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6c, 0x65,
+	0x78, 0x69, 0x63, 0x61, 0x6c, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x1a, 0x0a,
+	0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x3d, 0x0a, 0x0c, 0x6c, 0x65, 0x61,
+	0x72, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1a, 0x2e, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x65, 0x61, 0x72, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0b, 0x6c, 0x65, 0x61,
+	0x72, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0d, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x3d, 0x0a, 0x09, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x74, 0x65, 0x6e, 0x63, 0x65, 0x45, 0x78, 0x65, 0x72, 0x63,
+	0x69, 0x73, 0x65, 0x52, 0x09, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x08, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x12, 0x2c, 0x0a, 0x03, 0x64, 0x75,
+	0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x03, 0x64, 0x75, 0x65, 0x22, 0xac, 0x01, 0x0a, 0x0e, 0x41, 0x64, 0x64,
+	0x57, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x70, 0x65, 0x6c, 0x6c, 0x69, 0x6e, 0x67,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x70, 0x65, 0x6c, 0x6c, 0x69, 0x6e, 0x67,
+	0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x29, 0x0a, 0x10, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x63, 0x61, 0x74, 0x65,
+	0x67, 0x6f, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6c, 0x65, 0x78, 0x69,
+	0x63, 0x61, 0x6c, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x6c,
+	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c,
+	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x22, 0x3a, 0x0a, 0x0f, 0x41, 0x64, 0x64, 0x57, 0x6f,
+	0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x04, 0x77, 0x6f,
+	0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x6f, 0x63, 0x61, 0x62,
+	0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x04, 0x77,
+	0x6f, 0x72, 0x64, 0x22, 0x42, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x17,
+	0x0a, 0x07, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x77, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x22, 0x3a, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x57, 0x6f,
+	0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x04, 0x77, 0x6f,
+	0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x6f, 0x63, 0x61, 0x62,
+	0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x04, 0x77,
+	0x6f, 0x72, 0x64, 0x22, 0xe1, 0x02, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x6f, 0x72, 0x64,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x3d, 0x0a,
+	0x0c, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x65, 0x61, 0x72, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x0b, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x29, 0x0a, 0x10,
+	0x6c, 0x65, 0x78, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x61, 0x6c, 0x43,
+	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x70, 0x65, 0x6c, 0x6c,
+	0x69, 0x6e, 0x67, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x73, 0x70, 0x65, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78,
+	0x12, 0x2c, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18,
+	0x2e, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x29, 0x0a, 0x10,
+	0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x41,
+	0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x22, 0x5f, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x57,
+	0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x05,
+	0x77, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x6f,
+	0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x64,
+	0x52, 0x05, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f,
+	0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65,
+	0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0xcd, 0x02, 0x0a, 0x11, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x77, 0x6f, 0x72, 0x64, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x6f, 0x72, 0x64, 0x49, 0x64,
+	0x12, 0x1f, 0x0a, 0x08, 0x73, 0x70, 0x65, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x73, 0x70, 0x65, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x88, 0x01,
+	0x01, 0x12, 0x23, 0x0a, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x2e, 0x0a, 0x10, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x61,
+	0x6c, 0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x02, 0x52, 0x0f, 0x6c, 0x65, 0x78, 0x69, 0x63, 0x61, 0x6c, 0x43, 0x61, 0x74, 0x65, 0x67,
+	0x6f, 0x72, 0x79, 0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61,
+	0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x03, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67,
+	0x75, 0x61, 0x67, 0x65, 0x88, 0x01, 0x01, 0x12, 0x31, 0x0a, 0x14, 0x72, 0x65, 0x67, 0x65, 0x6e,
+	0x65, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x72, 0x65, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x65, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x73,
+	0x70, 0x65, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x64, 0x65, 0x66, 0x69,
+	0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x6c, 0x65, 0x78, 0x69, 0x63,
+	0x61, 0x6c, 0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x42, 0x0b, 0x0a, 0x09, 0x5f,
+	0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x22, 0x3d, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27,
+	0x0a, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76,
+	0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72,
+	0x64, 0x52, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x5f, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x22, 0x14, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x4c,
+	0x0a, 0x18, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69,
+	0x73, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x22, 0x44, 0x0a, 0x19,
+	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x04, 0x77, 0x6f, 0x72,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x66,
+	0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x04, 0x77, 0x6f,
+	0x72, 0x64, 0x22, 0xa1, 0x01, 0x0a, 0x14, 0x53, 0x74, 0x61, 0x72, 0x74, 0x50, 0x72, 0x61, 0x63,
+	0x74, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
+	0x73, 0x69, 0x7a, 0x65, 0x12, 0x40, 0x0a, 0x0d, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x76, 0x6f,
+	0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x65, 0x72,
+	0x63, 0x69, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0c, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69,
+	0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x22, 0xa8, 0x01, 0x0a, 0x0c, 0x50, 0x72, 0x61, 0x63, 0x74,
+	0x69, 0x63, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x17, 0x0a, 0x07, 0x77, 0x6f, 0x72, 0x64, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x6f, 0x72, 0x64, 0x49, 0x64,
+	0x12, 0x40, 0x0a, 0x0d, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x66,
+	0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x0c, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x5f, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x65, 0x78, 0x65, 0x72,
+	0x63, 0x69, 0x73, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x6f,
+	0x6d, 0x70, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6d, 0x70,
+	0x74, 0x22, 0x4a, 0x0a, 0x15, 0x53, 0x74, 0x61, 0x72, 0x74, 0x50, 0x72, 0x61, 0x63, 0x74, 0x69,
+	0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x05, 0x69, 0x74,
+	0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x76, 0x6f, 0x63, 0x61,
+	0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x61, 0x63, 0x74, 0x69,
+	0x63, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0xc8, 0x01,
+	0x0a, 0x13, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x17,
+	0x0a, 0x07, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x77, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x12, 0x40, 0x0a, 0x0d, 0x65, 0x78, 0x65, 0x72, 0x63,
+	0x69, 0x73, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b,
+	0x2e, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45,
+	0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0c, 0x65, 0x78, 0x65,
+	0x72, 0x63, 0x69, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x78, 0x65,
+	0x72, 0x63, 0x69, 0x73, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0d, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x16, 0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x22, 0xa1, 0x01, 0x0a, 0x14, 0x53, 0x75, 0x62,
+	0x6d, 0x69, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x67,
+	0x72, 0x61, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x61, 0x64,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x3d, 0x0a,
+	0x0c, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x65, 0x61, 0x72, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x0b, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2a, 0x7d, 0x0a, 0x0b,
+	0x4c, 0x65, 0x61, 0x72, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x18, 0x4c,
+	0x45, 0x41, 0x52, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x4c, 0x45, 0x41,
+	0x52, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e,
+	0x47, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x4c, 0x45, 0x41, 0x52, 0x4e, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x55, 0x53, 0x5f, 0x49, 0x4e, 0x5f, 0x50, 0x52, 0x4f, 0x47, 0x52, 0x45, 0x53, 0x53, 0x10,
+	0x02, 0x12, 0x18, 0x0a, 0x14, 0x4c, 0x45, 0x41, 0x52, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55,
+	0x53, 0x5f, 0x4c, 0x45, 0x41, 0x52, 0x4e, 0x45, 0x44, 0x10, 0x03, 0x2a, 0x64, 0x0a, 0x0c, 0x45,
+	0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x19, 0x45,
+	0x58, 0x45, 0x52, 0x43, 0x49, 0x53, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x45, 0x58,
+	0x45, 0x52, 0x43, 0x49, 0x53, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4c, 0x4f, 0x5a,
+	0x45, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x58, 0x45, 0x52, 0x43, 0x49, 0x53, 0x45, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x10,
+	0x02, 0x2a, 0xb3, 0x01, 0x0a, 0x09, 0x53, 0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12,
+	0x1a, 0x0a, 0x16, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x53,
+	0x4f, 0x52, 0x54, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45,
+	0x44, 0x5f, 0x41, 0x53, 0x43, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x4f, 0x52, 0x54, 0x5f,
+	0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x5f, 0x44, 0x45,
+	0x53, 0x43, 0x10, 0x02, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x4f, 0x52, 0x44,
+	0x45, 0x52, 0x5f, 0x53, 0x50, 0x45, 0x4c, 0x4c, 0x49, 0x4e, 0x47, 0x5f, 0x41, 0x53, 0x43, 0x10,
+	0x03, 0x12, 0x1c, 0x0a, 0x18, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f,
+	0x53, 0x50, 0x45, 0x4c, 0x4c, 0x49, 0x4e, 0x47, 0x5f, 0x44, 0x45, 0x53, 0x43, 0x10, 0x04, 0x12,
+	0x16, 0x0a, 0x12, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x44, 0x55,
+	0x45, 0x5f, 0x41, 0x53, 0x43, 0x10, 0x05, 0x32, 0xd7, 0x06, 0x0a, 0x11, 0x56, 0x6f, 0x63, 0x61,
+	0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x51, 0x0a,
+	0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x20, 0x2e, 0x76, 0x6f,
+	0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e,
+	0x76, 0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x48, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x12, 0x1d, 0x2e, 0x76, 0x6f,
+	0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x55,
+	0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x76, 0x6f, 0x63,
+	0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73,
+	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x07, 0x41, 0x64,
+	0x64, 0x57, 0x6f, 0x72, 0x64, 0x12, 0x1d, 0x2e, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72,
+	0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x64, 0x12,
+	0x1d, 0x2e, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e,
+	0x2e, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e,
+	0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x1f, 0x2e, 0x76, 0x6f,
+	0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x57, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x76,
+	0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51,
+	0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x64, 0x12, 0x20, 0x2e, 0x76,
+	0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
+	0x2e, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x51, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x64, 0x12,
+	0x20, 0x2e, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x21, 0x2e, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x66, 0x0a, 0x11, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x12, 0x27, 0x2e, 0x76, 0x6f, 0x63, 0x61,
+	0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61,
+	0x74, 0x65, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x28, 0x2e, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x45, 0x78, 0x65, 0x72, 0x63,
+	0x69, 0x73, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x0d,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x50, 0x72, 0x61, 0x63, 0x74, 0x69, 0x63, 0x65, 0x12, 0x23, 0x2e,
+	0x76, 0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x50, 0x72, 0x61, 0x63, 0x74, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x24, 0x2e, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x50, 0x72, 0x61, 0x63, 0x74, 0x69, 0x63, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x6d,
+	0x69, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x22, 0x2e, 0x76, 0x6f, 0x63, 0x61, 0x62,
+	0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x41,
+	0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x76,
+	0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62,
+	0x6d, 0x69, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x42, 0x45, 0x5a, 0x43, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x70, 0x61, 0x76, 0x65, 0x6c, 0x70, 0x75, 0x63, 0x68, 0x6f, 0x6b, 0x2f, 0x76, 0x6f, 0x63, 0x61,
+	0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x61, 0x70, 0x69, 0x2f, 0x76,
+	0x6f, 0x63, 0x61, 0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x76, 0x31, 0x3b, 0x76, 0x6f, 0x63, 0x61,
+	0x62, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_vocabforge_v1_vocabforge_proto_rawDescOnce sync.Once
+	file_vocabforge_v1_vocabforge_proto_rawDescData = file_vocabforge_v1_vocabforge_proto_rawDesc
+)
+
+func file_vocabforge_v1_vocabforge_proto_rawDescGZIP() []byte {
+	file_vocabforge_v1_vocabforge_proto_rawDescOnce.Do(func() {
+		file_vocabforge_v1_vocabforge_proto_rawDescData = protoimpl.X.CompressGZIP(file_vocabforge_v1_vocabforge_proto_rawDescData)
+	})
+	return file_vocabforge_v1_vocabforge_proto_rawDescData
+}
+
+var file_vocabforge_v1_vocabforge_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_vocabforge_v1_vocabforge_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_vocabforge_v1_vocabforge_proto_goTypes = []any{
+	(LearnStatus)(0),                  // 0: vocabforge.v1.LearnStatus
+	(ExerciseType)(0),                 // 1: vocabforge.v1.ExerciseType
+	(SortOrder)(0),                    // 2: vocabforge.v1.SortOrder
+	(*User)(nil),                      // 3: vocabforge.v1.User
+	(*CreateUserRequest)(nil),         // 4: vocabforge.v1.CreateUserRequest
+	(*CreateUserResponse)(nil),        // 5: vocabforge.v1.CreateUserResponse
+	(*GetUserRequest)(nil),            // 6: vocabforge.v1.GetUserRequest
+	(*GetUserResponse)(nil),           // 7: vocabforge.v1.GetUserResponse
+	(*SentenceExercise)(nil),          // 8: vocabforge.v1.SentenceExercise
+	(*Word)(nil),                      // 9: vocabforge.v1.Word
+	(*AddWordRequest)(nil),            // 10: vocabforge.v1.AddWordRequest
+	(*AddWordResponse)(nil),           // 11: vocabforge.v1.AddWordResponse
+	(*GetWordRequest)(nil),            // 12: vocabforge.v1.GetWordRequest
+	(*GetWordResponse)(nil),           // 13: vocabforge.v1.GetWordResponse
+	(*ListWordsRequest)(nil),          // 14: vocabforge.v1.ListWordsRequest
+	(*ListWordsResponse)(nil),         // 15: vocabforge.v1.ListWordsResponse
+	(*UpdateWordRequest)(nil),         // 16: vocabforge.v1.UpdateWordRequest
+	(*UpdateWordResponse)(nil),        // 17: vocabforge.v1.UpdateWordResponse
+	(*DeleteWordRequest)(nil),         // 18: vocabforge.v1.DeleteWordRequest
+	(*DeleteWordResponse)(nil),        // 19: vocabforge.v1.DeleteWordResponse
+	(*GenerateExercisesRequest)(nil),  // 20: vocabforge.v1.GenerateExercisesRequest
+	(*GenerateExercisesResponse)(nil), // 21: vocabforge.v1.GenerateExercisesResponse
+	(*StartPracticeRequest)(nil),      // 22: vocabforge.v1.StartPracticeRequest
+	(*PracticeItem)(nil),              // 23: vocabforge.v1.PracticeItem
+	(*StartPracticeResponse)(nil),     // 24: vocabforge.v1.StartPracticeResponse
+	(*SubmitAnswerRequest)(nil),       // 25: vocabforge.v1.SubmitAnswerRequest
+	(*SubmitAnswerResponse)(nil),      // 26: vocabforge.v1.SubmitAnswerResponse
+	(*timestamppb.Timestamp)(nil),     // 27: google.protobuf.Timestamp
+}
+var file_vocabforge_v1_vocabforge_proto_depIdxs = []int32{
+	1,  // 0: vocabforge.v1.User.exercise_types:type_name -> vocabforge.v1.ExerciseType
+	1,  // 1: vocabforge.v1.CreateUserRequest.exercise_types:type_name -> vocabforge.v1.ExerciseType
+	3,  // 2: vocabforge.v1.CreateUserResponse.user:type_name -> vocabforge.v1.User
+	3,  // 3: vocabforge.v1.GetUserResponse.user:type_name -> vocabforge.v1.User
+	0,  // 4: vocabforge.v1.Word.learn_status:type_name -> vocabforge.v1.LearnStatus
+	8,  // 5: vocabforge.v1.Word.exercises:type_name -> vocabforge.v1.SentenceExercise
+	27, // 6: vocabforge.v1.Word.due:type_name -> google.protobuf.Timestamp
+	9,  // 7: vocabforge.v1.AddWordResponse.word:type_name -> vocabforge.v1.Word
+	9,  // 8: vocabforge.v1.GetWordResponse.word:type_name -> vocabforge.v1.Word
+	0,  // 9: vocabforge.v1.ListWordsRequest.learn_status:type_name -> vocabforge.v1.LearnStatus
+	2,  // 10: vocabforge.v1.ListWordsRequest.sort:type_name -> vocabforge.v1.SortOrder
+	9,  // 11: vocabforge.v1.ListWordsResponse.words:type_name -> vocabforge.v1.Word
+	9,  // 12: vocabforge.v1.UpdateWordResponse.word:type_name -> vocabforge.v1.Word
+	9,  // 13: vocabforge.v1.GenerateExercisesResponse.word:type_name -> vocabforge.v1.Word
+	1,  // 14: vocabforge.v1.StartPracticeRequest.exercise_type:type_name -> vocabforge.v1.ExerciseType
+	1,  // 15: vocabforge.v1.PracticeItem.exercise_type:type_name -> vocabforge.v1.ExerciseType
+	23, // 16: vocabforge.v1.StartPracticeResponse.items:type_name -> vocabforge.v1.PracticeItem
+	1,  // 17: vocabforge.v1.SubmitAnswerRequest.exercise_type:type_name -> vocabforge.v1.ExerciseType
+	0,  // 18: vocabforge.v1.SubmitAnswerResponse.learn_status:type_name -> vocabforge.v1.LearnStatus
+	4,  // 19: vocabforge.v1.VocabforgeService.CreateUser:input_type -> vocabforge.v1.CreateUserRequest
+	6,  // 20: vocabforge.v1.VocabforgeService.GetUser:input_type -> vocabforge.v1.GetUserRequest
+	10, // 21: vocabforge.v1.VocabforgeService.AddWord:input_type -> vocabforge.v1.AddWordRequest
+	12, // 22: vocabforge.v1.VocabforgeService.GetWord:input_type -> vocabforge.v1.GetWordRequest
+	14, // 23: vocabforge.v1.VocabforgeService.ListWords:input_type -> vocabforge.v1.ListWordsRequest
+	16, // 24: vocabforge.v1.VocabforgeService.UpdateWord:input_type -> vocabforge.v1.UpdateWordRequest
+	18, // 25: vocabforge.v1.VocabforgeService.DeleteWord:input_type -> vocabforge.v1.DeleteWordRequest
+	20, // 26: vocabforge.v1.VocabforgeService.GenerateExercises:input_type -> vocabforge.v1.GenerateExercisesRequest
+	22, // 27: vocabforge.v1.VocabforgeService.StartPractice:input_type -> vocabforge.v1.StartPracticeRequest
+	25, // 28: vocabforge.v1.VocabforgeService.SubmitAnswer:input_type -> vocabforge.v1.SubmitAnswerRequest
+	5,  // 29: vocabforge.v1.VocabforgeService.CreateUser:output_type -> vocabforge.v1.CreateUserResponse
+	7,  // 30: vocabforge.v1.VocabforgeService.GetUser:output_type -> vocabforge.v1.GetUserResponse
+	11, // 31: vocabforge.v1.VocabforgeService.AddWord:output_type -> vocabforge.v1.AddWordResponse
+	13, // 32: vocabforge.v1.VocabforgeService.GetWord:output_type -> vocabforge.v1.GetWordResponse
+	15, // 33: vocabforge.v1.VocabforgeService.ListWords:output_type -> vocabforge.v1.ListWordsResponse
+	17, // 34: vocabforge.v1.VocabforgeService.UpdateWord:output_type -> vocabforge.v1.UpdateWordResponse
+	19, // 35: vocabforge.v1.VocabforgeService.DeleteWord:output_type -> vocabforge.v1.DeleteWordResponse
+	21, // 36: vocabforge.v1.VocabforgeService.GenerateExercises:output_type -> vocabforge.v1.GenerateExercisesResponse
+	24, // 37: vocabforge.v1.VocabforgeService.StartPractice:output_type -> vocabforge.v1.StartPracticeResponse
+	26, // 38: vocabforge.v1.VocabforgeService.SubmitAnswer:output_type -> vocabforge.v1.SubmitAnswerResponse
+	29, // [29:39] is the sub-list for method output_type
+	19, // [19:29] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
+}
+
+func init() { file_vocabforge_v1_vocabforge_proto_init() }
+func file_vocabforge_v1_vocabforge_proto_init() {
+	if File_vocabforge_v1_vocabforge_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_vocabforge_v1_vocabforge_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*User); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vocabforge_v1_vocabforge_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateUserRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vocabforge_v1_vocabforge_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateUserResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vocabforge_v1_vocabforge_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*GetUserRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vocabforge_v1_vocabforge_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*GetUserResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vocabforge_v1_vocabforge_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*SentenceExercise); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vocabforge_v1_vocabforge_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*Word); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vocabforge_v1_vocabforge_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*AddWordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vocabforge_v1_vocabforge_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*AddWordResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vocabforge_v1_vocabforge_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*GetWordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vocabforge_v1_vocabforge_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*GetWordResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vocabforge_v1_vocabforge_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*ListWordsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vocabforge_v1_vocabforge_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*ListWordsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vocabforge_v1_vocabforge_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateWordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vocabforge_v1_vocabforge_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateWordResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vocabforge_v1_vocabforge_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteWordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vocabforge_v1_vocabforge_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteWordResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vocabforge_v1_vocabforge_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*GenerateExercisesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vocabforge_v1_vocabforge_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*GenerateExercisesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vocabforge_v1_vocabforge_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*StartPracticeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vocabforge_v1_vocabforge_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*PracticeItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vocabforge_v1_vocabforge_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*StartPracticeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vocabforge_v1_vocabforge_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*SubmitAnswerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vocabforge_v1_vocabforge_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*SubmitAnswerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_vocabforge_v1_vocabforge_proto_msgTypes[13].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_vocabforge_v1_vocabforge_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_vocabforge_v1_vocabforge_proto_goTypes,
+		DependencyIndexes: file_vocabforge_v1_vocabforge_proto_depIdxs,
+		EnumInfos:         file_vocabforge_v1_vocabforge_proto_enumTypes,
+		MessageInfos:      file_vocabforge_v1_vocabforge_proto_msgTypes,
+	}.Build()
+	File_vocabforge_v1_vocabforge_proto = out.File
+	file_vocabforge_v1_vocabforge_proto_rawDesc = nil
+	file_vocabforge_v1_vocabforge_proto_goTypes = nil
+	file_vocabforge_v1_vocabforge_proto_depIdxs = nil
+}
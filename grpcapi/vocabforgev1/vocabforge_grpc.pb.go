@@ -0,0 +1,473 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: vocabforge/v1/vocabforge.proto
+
+package vocabforgev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	VocabforgeService_CreateUser_FullMethodName        = "/vocabforge.v1.VocabforgeService/CreateUser"
+	VocabforgeService_GetUser_FullMethodName           = "/vocabforge.v1.VocabforgeService/GetUser"
+	VocabforgeService_AddWord_FullMethodName           = "/vocabforge.v1.VocabforgeService/AddWord"
+	VocabforgeService_GetWord_FullMethodName           = "/vocabforge.v1.VocabforgeService/GetWord"
+	VocabforgeService_ListWords_FullMethodName         = "/vocabforge.v1.VocabforgeService/ListWords"
+	VocabforgeService_UpdateWord_FullMethodName        = "/vocabforge.v1.VocabforgeService/UpdateWord"
+	VocabforgeService_DeleteWord_FullMethodName        = "/vocabforge.v1.VocabforgeService/DeleteWord"
+	VocabforgeService_GenerateExercises_FullMethodName = "/vocabforge.v1.VocabforgeService/GenerateExercises"
+	VocabforgeService_StartPractice_FullMethodName     = "/vocabforge.v1.VocabforgeService/StartPractice"
+	VocabforgeService_SubmitAnswer_FullMethodName      = "/vocabforge.v1.VocabforgeService/SubmitAnswer"
+)
+
+// VocabforgeServiceClient is the client API for VocabforgeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// VocabforgeService exposes users, vocabulary and practice. Every call requires
+// "authorization: Bearer <token>" metadata with an API key or a session token.
+type VocabforgeServiceClient interface {
+	// CreateUser requires admin scope.
+	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error)
+	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error)
+	AddWord(ctx context.Context, in *AddWordRequest, opts ...grpc.CallOption) (*AddWordResponse, error)
+	GetWord(ctx context.Context, in *GetWordRequest, opts ...grpc.CallOption) (*GetWordResponse, error)
+	ListWords(ctx context.Context, in *ListWordsRequest, opts ...grpc.CallOption) (*ListWordsResponse, error)
+	UpdateWord(ctx context.Context, in *UpdateWordRequest, opts ...grpc.CallOption) (*UpdateWordResponse, error)
+	DeleteWord(ctx context.Context, in *DeleteWordRequest, opts ...grpc.CallOption) (*DeleteWordResponse, error)
+	// GenerateExercises replaces the word's exercises with freshly generated ones.
+	GenerateExercises(ctx context.Context, in *GenerateExercisesRequest, opts ...grpc.CallOption) (*GenerateExercisesResponse, error)
+	StartPractice(ctx context.Context, in *StartPracticeRequest, opts ...grpc.CallOption) (*StartPracticeResponse, error)
+	SubmitAnswer(ctx context.Context, in *SubmitAnswerRequest, opts ...grpc.CallOption) (*SubmitAnswerResponse, error)
+}
+
+type vocabforgeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVocabforgeServiceClient(cc grpc.ClientConnInterface) VocabforgeServiceClient {
+	return &vocabforgeServiceClient{cc}
+}
+
+func (c *vocabforgeServiceClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateUserResponse)
+	err := c.cc.Invoke(ctx, VocabforgeService_CreateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vocabforgeServiceClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserResponse)
+	err := c.cc.Invoke(ctx, VocabforgeService_GetUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vocabforgeServiceClient) AddWord(ctx context.Context, in *AddWordRequest, opts ...grpc.CallOption) (*AddWordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddWordResponse)
+	err := c.cc.Invoke(ctx, VocabforgeService_AddWord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vocabforgeServiceClient) GetWord(ctx context.Context, in *GetWordRequest, opts ...grpc.CallOption) (*GetWordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWordResponse)
+	err := c.cc.Invoke(ctx, VocabforgeService_GetWord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vocabforgeServiceClient) ListWords(ctx context.Context, in *ListWordsRequest, opts ...grpc.CallOption) (*ListWordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWordsResponse)
+	err := c.cc.Invoke(ctx, VocabforgeService_ListWords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vocabforgeServiceClient) UpdateWord(ctx context.Context, in *UpdateWordRequest, opts ...grpc.CallOption) (*UpdateWordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateWordResponse)
+	err := c.cc.Invoke(ctx, VocabforgeService_UpdateWord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vocabforgeServiceClient) DeleteWord(ctx context.Context, in *DeleteWordRequest, opts ...grpc.CallOption) (*DeleteWordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteWordResponse)
+	err := c.cc.Invoke(ctx, VocabforgeService_DeleteWord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vocabforgeServiceClient) GenerateExercises(ctx context.Context, in *GenerateExercisesRequest, opts ...grpc.CallOption) (*GenerateExercisesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateExercisesResponse)
+	err := c.cc.Invoke(ctx, VocabforgeService_GenerateExercises_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vocabforgeServiceClient) StartPractice(ctx context.Context, in *StartPracticeRequest, opts ...grpc.CallOption) (*StartPracticeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartPracticeResponse)
+	err := c.cc.Invoke(ctx, VocabforgeService_StartPractice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vocabforgeServiceClient) SubmitAnswer(ctx context.Context, in *SubmitAnswerRequest, opts ...grpc.CallOption) (*SubmitAnswerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitAnswerResponse)
+	err := c.cc.Invoke(ctx, VocabforgeService_SubmitAnswer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VocabforgeServiceServer is the server API for VocabforgeService service.
+// All implementations must embed UnimplementedVocabforgeServiceServer
+// for forward compatibility.
+//
+// VocabforgeService exposes users, vocabulary and practice. Every call requires
+// "authorization: Bearer <token>" metadata with an API key or a session token.
+type VocabforgeServiceServer interface {
+	// CreateUser requires admin scope.
+	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
+	GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error)
+	AddWord(context.Context, *AddWordRequest) (*AddWordResponse, error)
+	GetWord(context.Context, *GetWordRequest) (*GetWordResponse, error)
+	ListWords(context.Context, *ListWordsRequest) (*ListWordsResponse, error)
+	UpdateWord(context.Context, *UpdateWordRequest) (*UpdateWordResponse, error)
+	DeleteWord(context.Context, *DeleteWordRequest) (*DeleteWordResponse, error)
+	// GenerateExercises replaces the word's exercises with freshly generated ones.
+	GenerateExercises(context.Context, *GenerateExercisesRequest) (*GenerateExercisesResponse, error)
+	StartPractice(context.Context, *StartPracticeRequest) (*StartPracticeResponse, error)
+	SubmitAnswer(context.Context, *SubmitAnswerRequest) (*SubmitAnswerResponse, error)
+	mustEmbedUnimplementedVocabforgeServiceServer()
+}
+
+// UnimplementedVocabforgeServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedVocabforgeServiceServer struct{}
+
+func (UnimplementedVocabforgeServiceServer) CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (UnimplementedVocabforgeServiceServer) GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedVocabforgeServiceServer) AddWord(context.Context, *AddWordRequest) (*AddWordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddWord not implemented")
+}
+func (UnimplementedVocabforgeServiceServer) GetWord(context.Context, *GetWordRequest) (*GetWordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWord not implemented")
+}
+func (UnimplementedVocabforgeServiceServer) ListWords(context.Context, *ListWordsRequest) (*ListWordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWords not implemented")
+}
+func (UnimplementedVocabforgeServiceServer) UpdateWord(context.Context, *UpdateWordRequest) (*UpdateWordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWord not implemented")
+}
+func (UnimplementedVocabforgeServiceServer) DeleteWord(context.Context, *DeleteWordRequest) (*DeleteWordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWord not implemented")
+}
+func (UnimplementedVocabforgeServiceServer) GenerateExercises(context.Context, *GenerateExercisesRequest) (*GenerateExercisesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateExercises not implemented")
+}
+func (UnimplementedVocabforgeServiceServer) StartPractice(context.Context, *StartPracticeRequest) (*StartPracticeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartPractice not implemented")
+}
+func (UnimplementedVocabforgeServiceServer) SubmitAnswer(context.Context, *SubmitAnswerRequest) (*SubmitAnswerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitAnswer not implemented")
+}
+func (UnimplementedVocabforgeServiceServer) mustEmbedUnimplementedVocabforgeServiceServer() {}
+func (UnimplementedVocabforgeServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeVocabforgeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VocabforgeServiceServer will
+// result in compilation errors.
+type UnsafeVocabforgeServiceServer interface {
+	mustEmbedUnimplementedVocabforgeServiceServer()
+}
+
+func RegisterVocabforgeServiceServer(s grpc.ServiceRegistrar, srv VocabforgeServiceServer) {
+	// If the following call pancis, it indicates UnimplementedVocabforgeServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&VocabforgeService_ServiceDesc, srv)
+}
+
+func _VocabforgeService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VocabforgeServiceServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VocabforgeService_CreateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VocabforgeServiceServer).CreateUser(ctx, req.(*CreateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VocabforgeService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VocabforgeServiceServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VocabforgeService_GetUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VocabforgeServiceServer).GetUser(ctx, req.(*GetUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VocabforgeService_AddWord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddWordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VocabforgeServiceServer).AddWord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VocabforgeService_AddWord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VocabforgeServiceServer).AddWord(ctx, req.(*AddWordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VocabforgeService_GetWord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VocabforgeServiceServer).GetWord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VocabforgeService_GetWord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VocabforgeServiceServer).GetWord(ctx, req.(*GetWordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VocabforgeService_ListWords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VocabforgeServiceServer).ListWords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VocabforgeService_ListWords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VocabforgeServiceServer).ListWords(ctx, req.(*ListWordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VocabforgeService_UpdateWord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VocabforgeServiceServer).UpdateWord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VocabforgeService_UpdateWord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VocabforgeServiceServer).UpdateWord(ctx, req.(*UpdateWordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VocabforgeService_DeleteWord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VocabforgeServiceServer).DeleteWord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VocabforgeService_DeleteWord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VocabforgeServiceServer).DeleteWord(ctx, req.(*DeleteWordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VocabforgeService_GenerateExercises_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateExercisesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VocabforgeServiceServer).GenerateExercises(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VocabforgeService_GenerateExercises_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VocabforgeServiceServer).GenerateExercises(ctx, req.(*GenerateExercisesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VocabforgeService_StartPractice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartPracticeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VocabforgeServiceServer).StartPractice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VocabforgeService_StartPractice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VocabforgeServiceServer).StartPractice(ctx, req.(*StartPracticeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VocabforgeService_SubmitAnswer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitAnswerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VocabforgeServiceServer).SubmitAnswer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VocabforgeService_SubmitAnswer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VocabforgeServiceServer).SubmitAnswer(ctx, req.(*SubmitAnswerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VocabforgeService_ServiceDesc is the grpc.ServiceDesc for VocabforgeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VocabforgeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "vocabforge.v1.VocabforgeService",
+	HandlerType: (*VocabforgeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateUser",
+			Handler:    _VocabforgeService_CreateUser_Handler,
+		},
+		{
+			MethodName: "GetUser",
+			Handler:    _VocabforgeService_GetUser_Handler,
+		},
+		{
+			MethodName: "AddWord",
+			Handler:    _VocabforgeService_AddWord_Handler,
+		},
+		{
+			MethodName: "GetWord",
+			Handler:    _VocabforgeService_GetWord_Handler,
+		},
+		{
+			MethodName: "ListWords",
+			Handler:    _VocabforgeService_ListWords_Handler,
+		},
+		{
+			MethodName: "UpdateWord",
+			Handler:    _VocabforgeService_UpdateWord_Handler,
+		},
+		{
+			MethodName: "DeleteWord",
+			Handler:    _VocabforgeService_DeleteWord_Handler,
+		},
+		{
+			MethodName: "GenerateExercises",
+			Handler:    _VocabforgeService_GenerateExercises_Handler,
+		},
+		{
+			MethodName: "StartPractice",
+			Handler:    _VocabforgeService_StartPractice_Handler,
+		},
+		{
+			MethodName: "SubmitAnswer",
+			Handler:    _VocabforgeService_SubmitAnswer_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "vocabforge/v1/vocabforge.proto",
+}
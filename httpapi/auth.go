@@ -0,0 +1,60 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/users"
+)
+
+var ErrForbidden = errors.New("forbidden")
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (users.Principal, error)
+}
+
+// authenticated resolves the principal by the bearer token and lets the request
+// through only if its scope allows the required one.
+func (h Handler) authenticated(required users.Scope, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="vocabforge"`)
+			h.writeError(w, r, fmt.Errorf("missing bearer token. %w", users.ErrUnauthenticated))
+			return
+		}
+
+		p, err := h.Auth.Authenticate(r.Context(), strings.TrimSpace(token))
+		if err != nil {
+			if errors.Is(err, users.ErrUnauthenticated) {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="vocabforge", error="invalid_token"`)
+			}
+			h.writeError(w, r, err)
+			return
+		}
+		if !p.Scope.Allows(required) {
+			h.writeError(w, r, fmt.Errorf("%w: %s scope is required", ErrForbidden, required.String()))
+			return
+		}
+
+		next(w, r.WithContext(users.ContextWithPrincipal(r.Context(), p)))
+	}
+}
+
+// pathUserID returns the user ID given in the path if the principal may access the user's data.
+func pathUserID(r *http.Request) (models.UserID, error) {
+	userID, err := models.UserIDFromText(r.PathValue("userID"))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	p, ok := users.PrincipalFromContext(r.Context())
+	if !ok || !p.CanAccess(userID) {
+		return "", fmt.Errorf("%w: no access to user %s", ErrForbidden, userID)
+	}
+	return userID, nil
+}
@@ -0,0 +1,187 @@
+package httpapi
+
+import (
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/practice"
+	"github.com/pavelpuchok/vocabforge/usecases/stats"
+)
+
+type sessionTokenResponse struct {
+	Token     string    `json:"token"`
+	Scope     string    `json:"scope"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type createUserRequest struct {
+	DisplayName     string   `json:"displayName"`
+	NativeLanguage  string   `json:"nativeLanguage"`
+	TargetLanguages []string `json:"targetLanguages"`
+	Timezone        string   `json:"timezone"`
+	DailyGoal       int      `json:"dailyGoal"`
+	ExerciseTypes   []string `json:"exerciseTypes"`
+	SessionSize     int      `json:"sessionSize"`
+}
+
+type userResponse struct {
+	ID              string   `json:"id"`
+	DisplayName     string   `json:"displayName"`
+	NativeLanguage  string   `json:"nativeLanguage"`
+	TargetLanguages []string `json:"targetLanguages"`
+	Timezone        string   `json:"timezone"`
+	DailyGoal       int      `json:"dailyGoal"`
+	ExerciseTypes   []string `json:"exerciseTypes"`
+	SessionSize     int      `json:"sessionSize"`
+}
+
+func userToResponse(u models.User) userResponse {
+	res := userResponse{
+		ID:              u.ID.String(),
+		DisplayName:     u.DisplayName,
+		NativeLanguage:  u.NativeLanguage.String(),
+		TargetLanguages: make([]string, len(u.TargetLanguages)),
+		Timezone:        u.Timezone,
+		DailyGoal:       u.DailyGoal,
+		ExerciseTypes:   make([]string, len(u.ExerciseTypes)),
+		SessionSize:     u.SessionSize,
+	}
+	for i, l := range u.TargetLanguages {
+		res.TargetLanguages[i] = l.String()
+	}
+	for i, t := range u.ExerciseTypes {
+		res.ExerciseTypes[i] = t.String()
+	}
+	return res
+}
+
+type addWordRequest struct {
+	Spelling        string `json:"spelling"`
+	Definition      string `json:"definition"`
+	LexicalCategory string `json:"lexicalCategory"`
+	// Language is the user's first target language if empty.
+	Language string `json:"language"`
+}
+
+type exerciseResponse struct {
+	Sentence string `json:"sentence"`
+	Answered bool   `json:"answered"`
+}
+
+type wordResponse struct {
+	ID                 string             `json:"id"`
+	Spelling           string             `json:"spelling"`
+	Definition         string             `json:"definition"`
+	DefinitionLanguage string             `json:"definitionLanguage,omitempty"`
+	LexicalCategory    string             `json:"lexicalCategory"`
+	Language           string             `json:"language"`
+	LearnStatus        string             `json:"learnStatus"`
+	AnsweredCount      uint               `json:"answeredCount"`
+	Exercises          []exerciseResponse `json:"exercises"`
+	Archived           bool               `json:"archived"`
+	Due                *time.Time         `json:"due,omitempty"`
+	Tags               []string           `json:"tags,omitempty"`
+	Source             string             `json:"source,omitempty"`
+}
+
+func wordToResponse(w models.Word) wordResponse {
+	res := wordResponse{
+		ID:                 w.ID.String(),
+		Spelling:           w.Spelling,
+		Definition:         w.Definition,
+		DefinitionLanguage: w.DefinitionLanguage.String(),
+		LexicalCategory:    w.LexicalCategory,
+		Language:           w.Language.String(),
+		LearnStatus:        w.LearnStatus.String(),
+		AnsweredCount:      w.AnsweredCount,
+		Exercises:          make([]exerciseResponse, len(w.Exercises)),
+		Archived:           w.Archived,
+		Tags:               w.Tags,
+		Source:             w.Source,
+	}
+	for i, e := range w.Exercises {
+		res.Exercises[i] = exerciseResponse{Sentence: e.Sentence, Answered: e.Answered}
+	}
+	if !w.Schedule.Due.IsZero() {
+		due := w.Schedule.Due
+		res.Due = &due
+	}
+	return res
+}
+
+type wordsPageResponse struct {
+	Words      []wordResponse `json:"words"`
+	NextCursor string         `json:"nextCursor,omitempty"`
+}
+
+type startSessionRequest struct {
+	Language string `json:"language"`
+	// Size and ExerciseType are taken from the user's preferences if empty.
+	Size         int    `json:"size"`
+	ExerciseType string `json:"exerciseType"`
+}
+
+type sessionItemResponse struct {
+	WordID        string `json:"wordId"`
+	ExerciseType  string `json:"exerciseType"`
+	ExerciseIndex int    `json:"exerciseIndex"`
+	Prompt        string `json:"prompt"`
+}
+
+type sessionResponse struct {
+	Items []sessionItemResponse `json:"items"`
+}
+
+func sessionToResponse(s practice.Session) sessionResponse {
+	res := sessionResponse{Items: make([]sessionItemResponse, len(s.Items))}
+	for i, item := range s.Items {
+		res.Items[i] = sessionItemResponse{
+			WordID:        item.Word.ID.String(),
+			ExerciseType:  item.Type.String(),
+			ExerciseIndex: item.ExerciseIndex,
+			Prompt:        item.Prompt(),
+		}
+	}
+	return res
+}
+
+type answerRequest struct {
+	WordID        string `json:"wordId"`
+	ExerciseType  string `json:"exerciseType"`
+	ExerciseIndex int    `json:"exerciseIndex"`
+	Answer        string `json:"answer"`
+}
+
+type answerResponse struct {
+	Correct     bool   `json:"correct"`
+	Grade       string `json:"grade"`
+	Expected    string `json:"expected"`
+	LearnStatus string `json:"learnStatus"`
+}
+
+func resultToResponse(r practice.Result) answerResponse {
+	return answerResponse{
+		Correct:     r.Correct,
+		Grade:       r.Grade.String(),
+		Expected:    r.Expected,
+		LearnStatus: r.Word.LearnStatus.String(),
+	}
+}
+
+type statsResponse struct {
+	Total      int `json:"total"`
+	Pending    int `json:"pending"`
+	InProgress int `json:"inProgress"`
+	Learned    int `json:"learned"`
+	Due        int `json:"due"`
+}
+
+func statsToResponse(s stats.Stats) statsResponse {
+	return statsResponse{
+		Total:      s.Total,
+		Pending:    s.Pending,
+		InProgress: s.InProgress,
+		Learned:    s.Learned,
+		Due:        s.Due,
+	}
+}
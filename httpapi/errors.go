@@ -0,0 +1,71 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/practice"
+	"github.com/pavelpuchok/vocabforge/usecases/addword"
+	"github.com/pavelpuchok/vocabforge/users"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+	"github.com/pavelpuchok/vocabforge/vocabulary/sentences"
+)
+
+const (
+	CodeInvalidRequest   = "invalid_request"
+	CodeNotFound         = "not_found"
+	CodeConflict         = "conflict"
+	CodeUnauthenticated  = "unauthenticated"
+	CodeForbidden        = "forbidden"
+	CodeGenerationFailed = "generation_failed"
+	CodeUnavailable      = "unavailable"
+	CodeInternal         = "internal"
+)
+
+// ErrInvalidRequest is wrapped by request decoding and validation errors.
+var ErrInvalidRequest = errors.New("invalid request")
+
+type errorBody struct {
+	Error errorDetails `json:"error"`
+}
+
+type errorDetails struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// classifyError maps use case errors to an HTTP status and error code.
+func classifyError(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrInvalidRequest),
+		errors.Is(err, models.ErrInvalidLanguage),
+		errors.Is(err, users.ErrInvalidProfile),
+		errors.Is(err, vocabulary.ErrInvalidCursor),
+		errors.Is(err, vocabulary.ErrInvalidPatch),
+		errors.Is(err, vocabulary.ErrMissingDefinition),
+		errors.Is(err, addword.ErrMissingLanguage):
+		return http.StatusBadRequest, CodeInvalidRequest
+	case errors.Is(err, users.ErrUnauthenticated):
+		return http.StatusUnauthorized, CodeUnauthenticated
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden, CodeForbidden
+	case errors.Is(err, users.ErrUserNotFound),
+		errors.Is(err, users.ErrCredentialNotFound),
+		errors.Is(err, vocabulary.ErrWordNotFound),
+		errors.Is(err, practice.ErrExerciseNotFound):
+		return http.StatusNotFound, CodeNotFound
+	case errors.Is(err, vocabulary.ErrDuplicateWord):
+		return http.StatusConflict, CodeConflict
+	case errors.Is(err, sentences.ErrRateLimited),
+		errors.Is(err, sentences.ErrProviderUnavailable),
+		errors.Is(err, sentences.ErrCircuitOpen):
+		return http.StatusServiceUnavailable, CodeUnavailable
+	case errors.Is(err, sentences.ErrQuotaExhausted),
+		errors.Is(err, sentences.ErrContentRefused),
+		errors.Is(err, sentences.ErrNotEnoughSentences):
+		return http.StatusBadGateway, CodeGenerationFailed
+	default:
+		return http.StatusInternalServerError, CodeInternal
+	}
+}
@@ -0,0 +1,419 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/practice"
+	"github.com/pavelpuchok/vocabforge/usecases/stats"
+	"github.com/pavelpuchok/vocabforge/users"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+)
+
+// maxRequestBodySize limits JSON request bodies.
+const maxRequestBodySize = 1 << 20
+
+// Handler exposes use cases as JSON over HTTP. Every field has to be set.
+type Handler struct {
+	CreateUser    CreateUserUseCase
+	GetUser       GetUserUseCase
+	AddWord       AddWordUseCase
+	ListWords     ListWordsUseCase
+	StartPractice StartPracticeUseCase
+	Answer        AnswerUseCase
+	Stats         StatsUseCase
+	CreateSession CreateSessionUseCase
+	Auth          Authenticator
+	Logger        *slog.Logger
+}
+
+type CreateUserUseCase interface {
+	Run(ctx context.Context, profile models.User) (models.User, error)
+}
+
+type GetUserUseCase interface {
+	Run(ctx context.Context, userID models.UserID) (models.User, error)
+}
+
+type AddWordUseCase interface {
+	Run(ctx context.Context, userID models.UserID, spell, definition, lexicalCategory string, lang models.Language) (models.Word, error)
+}
+
+type ListWordsUseCase interface {
+	Run(ctx context.Context, userID models.UserID, filter vocabulary.ListFilter) (vocabulary.WordsPage, error)
+}
+
+type StartPracticeUseCase interface {
+	Run(ctx context.Context, userID models.UserID, lang models.Language, size int, t *practice.ExerciseType) (practice.Session, error)
+}
+
+type AnswerUseCase interface {
+	Run(ctx context.Context, userID models.UserID, wordID models.WordID, t practice.ExerciseType, exerciseIndex int, answer string) (practice.Result, error)
+}
+
+type CreateSessionUseCase interface {
+	Run(ctx context.Context, p users.Principal) (users.Credential, string, error)
+}
+
+type StatsUseCase interface {
+	Run(ctx context.Context, userID models.UserID, lang models.Language) (stats.Stats, error)
+}
+
+// Routes returns the API routes, all of them are prefixed with /v1 and require a bearer token.
+func (h Handler) Routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/sessions", h.authenticated(users.ScopeReadOnly, h.handleCreateSession))
+	mux.HandleFunc("POST /v1/users", h.authenticated(users.ScopeOperator, h.handleCreateUser))
+	mux.HandleFunc("GET /v1/users/{userID}", h.authenticated(users.ScopeReadOnly, h.handleGetUser))
+	mux.HandleFunc("POST /v1/users/{userID}/words", h.authenticated(users.ScopePractice, h.handleAddWord))
+	mux.HandleFunc("GET /v1/users/{userID}/words", h.authenticated(users.ScopeReadOnly, h.handleListWords))
+	mux.HandleFunc("POST /v1/users/{userID}/practice/sessions", h.authenticated(users.ScopePractice, h.handleStartPractice))
+	mux.HandleFunc("POST /v1/users/{userID}/practice/answers", h.authenticated(users.ScopePractice, h.handleAnswer))
+	mux.HandleFunc("GET /v1/users/{userID}/stats", h.authenticated(users.ScopeReadOnly, h.handleStats))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		h.writeJSON(w, r, http.StatusNotFound, errorBody{errorDetails{Code: CodeNotFound, Message: "no such endpoint"}})
+	})
+	return mux
+}
+
+// handleCreateSession exchanges an API key for a short living session token.
+func (h Handler) handleCreateSession(w http.ResponseWriter, r *http.Request) {
+	p, _ := users.PrincipalFromContext(r.Context())
+	if p.Kind != users.APIKey {
+		h.writeError(w, r, fmt.Errorf("%w: sessions are issued for API keys only", ErrForbidden))
+		return
+	}
+
+	session, token, err := h.CreateSession.Run(r.Context(), p)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	h.writeJSON(w, r, http.StatusCreated, sessionTokenResponse{
+		Token:     token,
+		Scope:     session.Scope.String(),
+		ExpiresAt: session.ExpiresAt,
+	})
+}
+
+func (h Handler) handleCreateUser(w http.ResponseWriter, r *http.Request) {
+	var req createUserRequest
+	if err := decodeJSON(r, &req); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	profile := models.User{
+		DisplayName: req.DisplayName,
+		Timezone:    req.Timezone,
+		DailyGoal:   req.DailyGoal,
+		SessionSize: req.SessionSize,
+	}
+	if req.NativeLanguage == "" {
+		h.writeError(w, r, fmt.Errorf("%w: nativeLanguage is required", ErrInvalidRequest))
+		return
+	}
+	lang, err := models.LanguageFromText(req.NativeLanguage)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	profile.NativeLanguage = lang
+	for _, l := range req.TargetLanguages {
+		lang, err := models.LanguageFromText(l)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+		profile.TargetLanguages = append(profile.TargetLanguages, lang)
+	}
+	for _, et := range req.ExerciseTypes {
+		t, err := models.ExerciseTypeFromText(et)
+		if err != nil {
+			h.writeError(w, r, fmt.Errorf("%w: %w", ErrInvalidRequest, err))
+			return
+		}
+		profile.ExerciseTypes = append(profile.ExerciseTypes, t)
+	}
+
+	usr, err := h.CreateUser.Run(r.Context(), profile)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	h.writeJSON(w, r, http.StatusCreated, userToResponse(usr))
+}
+
+func (h Handler) handleGetUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := pathUserID(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	usr, err := h.GetUser.Run(r.Context(), userID)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	h.writeJSON(w, r, http.StatusOK, userToResponse(usr))
+}
+
+func (h Handler) handleAddWord(w http.ResponseWriter, r *http.Request) {
+	userID, err := pathUserID(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	var req addWordRequest
+	if err := decodeJSON(r, &req); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	if req.Spelling == "" || req.Definition == "" {
+		h.writeError(w, r, fmt.Errorf("%w: spelling and definition are required", ErrInvalidRequest))
+		return
+	}
+
+	var lang models.Language
+	if req.Language != "" {
+		lang, err = models.LanguageFromText(req.Language)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+	}
+
+	word, err := h.AddWord.Run(r.Context(), userID, req.Spelling, req.Definition, req.LexicalCategory, lang)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	h.writeJSON(w, r, http.StatusCreated, wordToResponse(word))
+}
+
+func (h Handler) handleListWords(w http.ResponseWriter, r *http.Request) {
+	userID, err := pathUserID(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	filter, err := listFilterFromQuery(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	page, err := h.ListWords.Run(r.Context(), userID, filter)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	res := wordsPageResponse{
+		Words:      make([]wordResponse, len(page.Words)),
+		NextCursor: page.NextCursor,
+	}
+	for i, word := range page.Words {
+		res.Words[i] = wordToResponse(word)
+	}
+	h.writeJSON(w, r, http.StatusOK, res)
+}
+
+func listFilterFromQuery(r *http.Request) (vocabulary.ListFilter, error) {
+	q := r.URL.Query()
+	filter := vocabulary.ListFilter{
+		LexicalCategory: q.Get("lexicalCategory"),
+		SpellingPrefix:  q.Get("prefix"),
+		Cursor:          q.Get("cursor"),
+	}
+
+	if v := q.Get("language"); v != "" {
+		lang, err := models.LanguageFromText(v)
+		if err != nil {
+			return filter, err
+		}
+		filter.Language = lang
+	}
+	sort, err := vocabulary.SortOrderFromText(q.Get("sort"))
+	if err != nil {
+		return filter, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	filter.Sort = sort
+
+	if v := q.Get("status"); v != "" {
+		status, err := models.LearnStatusFromText(v)
+		if err != nil {
+			return filter, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+		}
+		filter.LearnStatus = &status
+	}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 0 {
+			return filter, fmt.Errorf("%w: invalid limit %s", ErrInvalidRequest, v)
+		}
+		filter.Limit = limit
+	}
+	if v := q.Get("includeArchived"); v != "" {
+		include, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, fmt.Errorf("%w: invalid includeArchived %s", ErrInvalidRequest, v)
+		}
+		filter.IncludeArchived = include
+	}
+	return filter, nil
+}
+
+func (h Handler) handleStartPractice(w http.ResponseWriter, r *http.Request) {
+	userID, err := pathUserID(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	var req startSessionRequest
+	if err := decodeJSON(r, &req); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	if req.Size < 0 {
+		h.writeError(w, r, fmt.Errorf("%w: negative size", ErrInvalidRequest))
+		return
+	}
+
+	var lang models.Language
+	if req.Language != "" {
+		lang, err = models.LanguageFromText(req.Language)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+	}
+	var exerciseType *practice.ExerciseType
+	if req.ExerciseType != "" {
+		t, err := practice.ExerciseTypeFromText(req.ExerciseType)
+		if err != nil {
+			h.writeError(w, r, fmt.Errorf("%w: %w", ErrInvalidRequest, err))
+			return
+		}
+		exerciseType = &t
+	}
+
+	session, err := h.StartPractice.Run(r.Context(), userID, lang, req.Size, exerciseType)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	h.writeJSON(w, r, http.StatusOK, sessionToResponse(session))
+}
+
+func (h Handler) handleAnswer(w http.ResponseWriter, r *http.Request) {
+	userID, err := pathUserID(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	var req answerRequest
+	if err := decodeJSON(r, &req); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	wordID, err := models.WordIDFromText(req.WordID)
+	if err != nil {
+		h.writeError(w, r, fmt.Errorf("%w: %w", ErrInvalidRequest, err))
+		return
+	}
+	exerciseType, err := practice.ExerciseTypeFromText(req.ExerciseType)
+	if err != nil {
+		h.writeError(w, r, fmt.Errorf("%w: %w", ErrInvalidRequest, err))
+		return
+	}
+
+	res, err := h.Answer.Run(r.Context(), userID, wordID, exerciseType, req.ExerciseIndex, req.Answer)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	h.writeJSON(w, r, http.StatusOK, resultToResponse(res))
+}
+
+func (h Handler) handleStats(w http.ResponseWriter, r *http.Request) {
+	userID, err := pathUserID(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	var lang models.Language
+	if v := r.URL.Query().Get("language"); v != "" {
+		lang, err = models.LanguageFromText(v)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+	}
+
+	s, err := h.Stats.Run(r.Context(), userID, lang)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	h.writeJSON(w, r, http.StatusOK, statsToResponse(s))
+}
+
+func decodeJSON(r *http.Request, v any) error {
+	dec := json.NewDecoder(io.LimitReader(r.Body, maxRequestBodySize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("%w: malformed JSON body: %w", ErrInvalidRequest, err)
+	}
+	if dec.More() {
+		return fmt.Errorf("%w: unexpected data after JSON body", ErrInvalidRequest)
+	}
+	return nil
+}
+
+func (h Handler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		// the request ran out of time, it's not a failure of the server
+		h.Logger.WarnContext(r.Context(), "httpapi: request timed out",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+		)
+		h.writeJSON(w, r, http.StatusGatewayTimeout, errorBody{errorDetails{Code: CodeUnavailable, Message: "request timed out"}})
+		return
+	}
+
+	status, code := classifyError(err)
+	msg := err.Error()
+	if status == http.StatusInternalServerError {
+		h.Logger.ErrorContext(r.Context(), "httpapi: request failed",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.String("err", err.Error()),
+		)
+		// internal details are logged, not leaked to the client
+		msg = http.StatusText(status)
+	}
+	h.writeJSON(w, r, status, errorBody{errorDetails{Code: code, Message: msg}})
+}
+
+func (h Handler) writeJSON(w http.ResponseWriter, r *http.Request, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.Logger.WarnContext(r.Context(), "httpapi: unable to write response", slog.String("err", err.Error()))
+	}
+}
@@ -0,0 +1,262 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/users"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+	"github.com/pavelpuchok/vocabforge/vocabulary/sentences"
+)
+
+const testUserID = "000000000000000000000001"
+
+type fakeAddWord struct {
+	lang models.Language
+	err  error
+}
+
+func (f *fakeAddWord) Run(_ context.Context, userID models.UserID, spell, definition, lexicalCategory string, lang models.Language) (models.Word, error) {
+	f.lang = lang
+	if f.err != nil {
+		return models.Word{}, f.err
+	}
+	return models.Word{ID: "000000000000000000000002", UserID: userID, Spelling: spell, Definition: definition, LexicalCategory: lexicalCategory, Language: lang}, nil
+}
+
+type fakeListWords struct {
+	filter vocabulary.ListFilter
+}
+
+func (f *fakeListWords) Run(_ context.Context, _ models.UserID, filter vocabulary.ListFilter) (vocabulary.WordsPage, error) {
+	f.filter = filter
+	return vocabulary.WordsPage{Words: []models.Word{{ID: "000000000000000000000002", Spelling: "run", Language: "en"}}, NextCursor: "next"}, nil
+}
+
+const (
+	readOnlyToken = "vfk_read"
+	practiceToken = "vfk_practice"
+	adminToken    = "vfk_admin"
+	operatorToken = "vfk_operator"
+	sessionToken  = "vfs_session"
+)
+
+type fakeAuth map[string]users.Principal
+
+func (a fakeAuth) Authenticate(_ context.Context, token string) (users.Principal, error) {
+	p, ok := a[token]
+	if !ok {
+		return users.Principal{}, users.ErrUnauthenticated
+	}
+	return p, nil
+}
+
+type fakeCreateSession struct{}
+
+func (fakeCreateSession) Run(_ context.Context, p users.Principal) (users.Credential, string, error) {
+	return users.Credential{Kind: users.Session, Scope: p.Scope}, "vfs_new", nil
+}
+
+func newTestHandler(addWord *fakeAddWord, listWords *fakeListWords) http.Handler {
+	return Handler{
+		AddWord:       addWord,
+		ListWords:     listWords,
+		CreateSession: fakeCreateSession{},
+		Auth: fakeAuth{
+			readOnlyToken: {UserID: testUserID, Scope: users.ScopeReadOnly},
+			practiceToken: {UserID: testUserID, Scope: users.ScopePractice},
+			adminToken:    {UserID: "000000000000000000000009", Scope: users.ScopeAdmin},
+			operatorToken: {UserID: "000000000000000000000009", Scope: users.ScopeOperator},
+			sessionToken:  {UserID: testUserID, Scope: users.ScopePractice, Kind: users.Session},
+		},
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}.Routes()
+}
+
+func serve(h http.Handler, method, path, token, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeError(t *testing.T, rec *httptest.ResponseRecorder) errorDetails {
+	t.Helper()
+	var body errorBody
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("unable to decode error body %q. %s", rec.Body.String(), err)
+	}
+	return body.Error
+}
+
+func TestHandler_AddWord(t *testing.T) {
+	t.Parallel()
+
+	addWord := &fakeAddWord{}
+	h := newTestHandler(addWord, &fakeListWords{})
+
+	rec := serve(h, http.MethodPost, "/v1/users/"+testUserID+"/words", practiceToken, `{"spelling":"run","definition":"move fast","language":"en_GB"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("unexpected status %d: %s", rec.Code, rec.Body)
+	}
+	var word wordResponse
+	if err := json.NewDecoder(rec.Body).Decode(&word); err != nil {
+		t.Fatal(err)
+	}
+	if word.Spelling != "run" || word.Language != "en-GB" || addWord.lang != "en-GB" {
+		t.Errorf("unexpected word %+v", word)
+	}
+
+	cases := []struct {
+		name   string
+		path   string
+		body   string
+		status int
+		code   string
+	}{
+		{"invalid user id", "/v1/users/abc/words", `{"spelling":"run","definition":"d"}`, http.StatusBadRequest, CodeInvalidRequest},
+		{"other user", "/v1/users/000000000000000000000002/words", `{"spelling":"run","definition":"d"}`, http.StatusForbidden, CodeForbidden},
+		{"missing definition", "/v1/users/" + testUserID + "/words", `{"spelling":"run"}`, http.StatusBadRequest, CodeInvalidRequest},
+		{"unknown field", "/v1/users/" + testUserID + "/words", `{"spelling":"run","definition":"d","foo":1}`, http.StatusBadRequest, CodeInvalidRequest},
+		{"invalid language", "/v1/users/" + testUserID + "/words", `{"spelling":"run","definition":"d","language":"!"}`, http.StatusBadRequest, CodeInvalidRequest},
+	}
+	for _, c := range cases {
+		rec := serve(h, http.MethodPost, c.path, practiceToken, c.body)
+		if rec.Code != c.status {
+			t.Errorf("%s: expected status %d, got %d", c.name, c.status, rec.Code)
+		}
+		if details := decodeError(t, rec); details.Code != c.code || details.Message == "" {
+			t.Errorf("%s: unexpected error body %+v", c.name, details)
+		}
+	}
+}
+
+func TestHandler_Errors(t *testing.T) {
+	t.Parallel()
+
+	cases := map[error]int{
+		fmt.Errorf("wrapped. %w", &sentences.ProviderError{Kind: sentences.ErrRateLimited}): http.StatusServiceUnavailable,
+		fmt.Errorf("wrapped. %w", sentences.ErrNotEnoughSentences):                          http.StatusBadGateway,
+		fmt.Errorf("wrapped. %w", vocabulary.ErrWordNotFound):                               http.StatusNotFound,
+		fmt.Errorf("wrapped. %w", vocabulary.ErrDuplicateWord):                              http.StatusConflict,
+		fmt.Errorf("wrapped. %w", context.DeadlineExceeded):                                 http.StatusGatewayTimeout,
+		fmt.Errorf("connection refused"):                                                    http.StatusInternalServerError,
+	}
+	for err, status := range cases {
+		h := newTestHandler(&fakeAddWord{err: err}, &fakeListWords{})
+		rec := serve(h, http.MethodPost, "/v1/users/"+testUserID+"/words", practiceToken, `{"spelling":"run","definition":"d"}`)
+		if rec.Code != status {
+			t.Errorf("expected status %d for %s, got %d", status, err, rec.Code)
+		}
+		details := decodeError(t, rec)
+		if status == http.StatusInternalServerError && details.Message != http.StatusText(status) {
+			t.Errorf("expected internal error details to be hidden, got %q", details.Message)
+		}
+	}
+
+	// a timed out request isn't logged as a failed one
+	var logs bytes.Buffer
+	h := Handler{
+		AddWord:   &fakeAddWord{err: fmt.Errorf("wrapped. %w", context.DeadlineExceeded)},
+		ListWords: &fakeListWords{},
+		Auth:      fakeAuth{practiceToken: {UserID: testUserID, Scope: users.ScopePractice}},
+		Logger:    slog.New(slog.NewTextHandler(&logs, nil)),
+	}.Routes()
+	serve(h, http.MethodPost, "/v1/users/"+testUserID+"/words", practiceToken, `{"spelling":"run","definition":"d"}`)
+	if strings.Contains(logs.String(), "request failed") || !strings.Contains(logs.String(), "request timed out") {
+		t.Errorf("unexpected logs of timed out request:\n%s", logs.String())
+	}
+
+	rec := serve(newTestHandler(&fakeAddWord{}, &fakeListWords{}), http.MethodGet, "/v1/nowhere", "", "")
+	if rec.Code != http.StatusNotFound || decodeError(t, rec).Code != CodeNotFound {
+		t.Errorf("unexpected response for unknown endpoint %d: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestHandler_ListWords(t *testing.T) {
+	t.Parallel()
+
+	listWords := &fakeListWords{}
+	h := newTestHandler(&fakeAddWord{}, listWords)
+
+	rec := serve(h, http.MethodGet, "/v1/users/"+testUserID+"/words?language=de&status=learned&sort=-spelling&limit=20&includeArchived=true&cursor=abc", readOnlyToken, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d: %s", rec.Code, rec.Body)
+	}
+
+	learned := models.Learned
+	expectedFilter := vocabulary.ListFilter{
+		Language:        "de",
+		LearnStatus:     &learned,
+		IncludeArchived: true,
+		Sort:            vocabulary.SortBySpellingDesc,
+		Cursor:          "abc",
+		Limit:           20,
+	}
+	if diff := cmp.Diff(expectedFilter, listWords.filter); diff != "" {
+		t.Errorf("unexpected filter (-want +got):\n%s", diff)
+	}
+
+	var page wordsPageResponse
+	if err := json.NewDecoder(rec.Body).Decode(&page); err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Words) != 1 || page.NextCursor != "next" {
+		t.Errorf("unexpected page %+v", page)
+	}
+
+	rec = serve(h, http.MethodGet, "/v1/users/"+testUserID+"/words?limit=-1", readOnlyToken, "")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected bad request for negative limit, got %d", rec.Code)
+	}
+}
+
+func TestHandler_Auth(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHandler(&fakeAddWord{}, &fakeListWords{})
+	wordsPath := "/v1/users/" + testUserID + "/words"
+	body := `{"spelling":"run","definition":"d"}`
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		status int
+	}{
+		{"missing token", http.MethodGet, wordsPath, "", http.StatusUnauthorized},
+		{"unknown token", http.MethodGet, wordsPath, "vfk_unknown", http.StatusUnauthorized},
+		{"read-only reads", http.MethodGet, wordsPath, readOnlyToken, http.StatusOK},
+		{"read-only can't add words", http.MethodPost, wordsPath, readOnlyToken, http.StatusForbidden},
+		{"practice adds words", http.MethodPost, wordsPath, practiceToken, http.StatusCreated},
+		{"session adds words", http.MethodPost, wordsPath, sessionToken, http.StatusCreated},
+		{"admin can't access other users", http.MethodPost, wordsPath, adminToken, http.StatusForbidden},
+		{"operator accesses other users", http.MethodPost, wordsPath, operatorToken, http.StatusCreated},
+		{"non-operator can't create users", http.MethodPost, "/v1/users", adminToken, http.StatusForbidden},
+		{"API key creates session", http.MethodPost, "/v1/sessions", readOnlyToken, http.StatusCreated},
+		{"session can't create session", http.MethodPost, "/v1/sessions", sessionToken, http.StatusForbidden},
+	}
+	for _, c := range cases {
+		rec := serve(h, c.method, c.path, c.token, body)
+		if rec.Code != c.status {
+			t.Errorf("%s: expected status %d, got %d: %s", c.name, c.status, rec.Code, rec.Body)
+		}
+		if c.status == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+			t.Errorf("%s: expected WWW-Authenticate header", c.name)
+		}
+	}
+}
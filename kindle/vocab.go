@@ -0,0 +1,113 @@
+// Package kindle reads words looked up on a Kindle from its Vocabulary Builder database,
+// found at system/vocabulary/vocab.db on the device.
+package kindle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/practice/cloze"
+
+	// registers "sqlite" database/sql driver
+	_ "modernc.org/sqlite"
+)
+
+// masteredCategory is the category of words marked as mastered in Vocabulary Builder.
+const masteredCategory = 100
+
+var ErrWordNotInSentence = errors.New("looked up word isn't found in the sentence")
+
+// Lookup is a word looked up while reading a book.
+type Lookup struct {
+	// Word is the form looked up, as it's in the sentence.
+	Word string
+	// Stem is the dictionary form of the word, it may be empty.
+	Stem string
+	// Language is the language of the word as Kindle gives it, for ex: en.
+	Language string
+	// Usage is the sentence the word was looked up in.
+	Usage      string
+	BookTitle  string
+	BookAuthor string
+	Mastered   bool
+	LookedUpAt time.Time
+}
+
+// Spelling is the stem of the word, or the looked up form when Kindle doesn't know the stem.
+func (l Lookup) Spelling() string {
+	if l.Stem != "" {
+		return l.Stem
+	}
+	return l.Word
+}
+
+// Sentence returns the usage with the looked up word marked with <% %>.
+func (l Lookup) Sentence() (string, error) {
+	re, err := regexp.Compile(`(?i)(?:^|[^\pL\pN])(` + regexp.QuoteMeta(l.Word) + `)(?:$|[^\pL\pN])`)
+	if err != nil {
+		return "", fmt.Errorf("kindle.Lookup.Sentence. %w", err)
+	}
+	m := re.FindStringSubmatchIndex(l.Usage)
+	if m == nil {
+		return "", fmt.Errorf("kindle.Lookup.Sentence %q in %q. %w", l.Word, l.Usage, ErrWordNotInSentence)
+	}
+
+	sentence := l.Usage[:m[2]] + cloze.OpenTag + l.Usage[m[2]:m[3]] + cloze.CloseTag + l.Usage[m[3]:]
+	if _, err := cloze.Parse(sentence); err != nil {
+		return "", fmt.Errorf("kindle.Lookup.Sentence. %w", err)
+	}
+	return sentence, nil
+}
+
+// Read reads the lookups from the vocab.db at path, oldest first.
+func Read(ctx context.Context, path string) ([]Lookup, error) {
+	// the driver creates an empty database if there is no file
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("kindle.Read. %w", err)
+	}
+
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("kindle.Read unable to open %s. %w", path, err)
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT w.word, w.stem, w.lang, w.category, l.usage, l.timestamp, b.title, b.authors
+		FROM LOOKUPS l
+		JOIN WORDS w ON w.id = l.word_key
+		LEFT JOIN BOOK_INFO b ON b.id = l.book_key
+		ORDER BY l.timestamp`)
+	if err != nil {
+		return nil, fmt.Errorf("kindle.Read unable to query lookups. %w", err)
+	}
+	defer rows.Close()
+
+	var lookups []Lookup
+	for rows.Next() {
+		var word, stem, lang, usage, title, authors sql.NullString
+		var category, timestamp sql.NullInt64
+		if err := rows.Scan(&word, &stem, &lang, &category, &usage, &timestamp, &title, &authors); err != nil {
+			return nil, fmt.Errorf("kindle.Read unable to scan lookup. %w", err)
+		}
+		lookups = append(lookups, Lookup{
+			Word:       word.String,
+			Stem:       stem.String,
+			Language:   lang.String,
+			Usage:      usage.String,
+			BookTitle:  title.String,
+			BookAuthor: authors.String,
+			Mastered:   category.Int64 == masteredCategory,
+			LookedUpAt: time.UnixMilli(timestamp.Int64).UTC(),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("kindle.Read unable to read lookups. %w", err)
+	}
+	return lookups, nil
+}
@@ -0,0 +1,95 @@
+package kindle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// vocabSchema is the part of the Vocabulary Builder schema the reader uses.
+const vocabSchema = `
+CREATE TABLE WORDS (id TEXT PRIMARY KEY NOT NULL, word TEXT, stem TEXT, lang TEXT, category INTEGER DEFAULT 0, timestamp INTEGER DEFAULT 0, profileid TEXT);
+CREATE TABLE LOOKUPS (id TEXT PRIMARY KEY NOT NULL, word_key TEXT, book_key TEXT, dict_key TEXT, pos TEXT, usage TEXT, timestamp INTEGER DEFAULT 0);
+CREATE TABLE BOOK_INFO (id TEXT PRIMARY KEY NOT NULL, asin TEXT, guid TEXT, lang TEXT, title TEXT, authors TEXT);
+INSERT INTO WORDS VALUES ('de:Häuser', 'Häuser', 'Haus', 'de', 0, 1725184800000, '');
+INSERT INTO WORDS VALUES ('en:ran', 'ran', 'run', 'en', 100, 1725181200000, '');
+INSERT INTO BOOK_INFO VALUES ('b1', 'B001', 'g1', 'de', 'Der Process', 'Franz Kafka');
+INSERT INTO LOOKUPS VALUES ('l1', 'de:Häuser', 'b1', '', '', 'Die Häuser waren alt.', 1725184800000);
+INSERT INTO LOOKUPS VALUES ('l2', 'en:ran', 'b2', '', '', 'She ran home.', 1725181200000);
+`
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "vocab.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(vocabSchema); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	lookups, err := Read(context.Background(), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Lookup{
+		{
+			Word:       "ran",
+			Stem:       "run",
+			Language:   "en",
+			Usage:      "She ran home.",
+			Mastered:   true,
+			LookedUpAt: time.Date(2024, 9, 1, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			Word:       "Häuser",
+			Stem:       "Haus",
+			Language:   "de",
+			Usage:      "Die Häuser waren alt.",
+			BookTitle:  "Der Process",
+			BookAuthor: "Franz Kafka",
+			LookedUpAt: time.Date(2024, 9, 1, 10, 0, 0, 0, time.UTC),
+		},
+	}
+	if diff := cmp.Diff(expected, lookups); diff != "" {
+		t.Errorf("unexpected lookups (-want +got):\n%s", diff)
+	}
+
+	if _, err := Read(context.Background(), filepath.Join(t.TempDir(), "missing.db")); err == nil {
+		t.Error("expected error for missing database")
+	}
+}
+
+func TestLookupSentence(t *testing.T) {
+	t.Parallel()
+
+	valid := map[Lookup]string{
+		{Word: "Häuser", Usage: "Die Häuser waren alt."}: "Die <%Häuser%> waren alt.",
+		{Word: "ran", Usage: "Ran, she ran."}:            "<%Ran%>, she ran.",
+		{Word: "ran", Usage: "He ranted, then ran."}:     "He ranted, then <%ran%>.",
+	}
+	for l, expected := range valid {
+		s, err := l.Sentence()
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", l.Usage, err)
+			continue
+		}
+		if s != expected {
+			t.Errorf("expected %q, got %q", expected, s)
+		}
+	}
+
+	l := Lookup{Word: "run", Usage: "She ran home."}
+	if _, err := l.Sentence(); !errors.Is(err, ErrWordNotInSentence) {
+		t.Errorf("expected ErrWordNotInSentence, got %v", err)
+	}
+}
@@ -0,0 +1,25 @@
+package levenshtein
+
+import "testing"
+
+func TestDistance(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"run", "", 3},
+		{"", "run", 3},
+		{"run", "run", 0},
+		{"run", "ran", 1},
+		{"kitten", "sitting", 3},
+		{"straße", "strasse", 2},
+	}
+	for _, tt := range tests {
+		if actual := Distance(tt.a, tt.b); actual != tt.expected {
+			t.Errorf("expected distance %d between %q and %q, got %d", tt.expected, tt.a, tt.b, actual)
+		}
+	}
+}
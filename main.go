@@ -1,11 +1,21 @@
 package main
 
 import (
+	"errors"
 	"log/slog"
 	"os"
+
+	"github.com/pavelpuchok/vocabforge/vocabulary/sentences"
 )
 
-const exitErrCode = 2
+const (
+	exitErrCode = 2
+	// AI provider failures get distinct codes so scripts can tell when retrying later makes sense.
+	exitRateLimitedCode    = 3
+	exitQuotaExhaustedCode = 4
+	exitContentRefusedCode = 5
+	exitAIUnavailableCode  = 6
+)
 
 func main() {
 	cfg, err := ParseConfig(os.Args)
@@ -22,6 +32,21 @@ func main() {
 	err = run(cfg, logger)
 	if err != nil {
 		logger.Error("failed", slog.String("err", err.Error()))
-		os.Exit(exitErrCode)
+		os.Exit(exitCode(err))
+	}
+}
+
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, sentences.ErrRateLimited):
+		return exitRateLimitedCode
+	case errors.Is(err, sentences.ErrQuotaExhausted):
+		return exitQuotaExhaustedCode
+	case errors.Is(err, sentences.ErrContentRefused):
+		return exitContentRefusedCode
+	case errors.Is(err, sentences.ErrProviderUnavailable), errors.Is(err, sentences.ErrCircuitOpen):
+		return exitAIUnavailableCode
+	default:
+		return exitErrCode
 	}
 }
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ExerciseType int
+
+const (
+	// ClozeExercise asks to fill the marked word in a generated sentence.
+	ClozeExercise ExerciseType = iota
+	// DefinitionExercise asks to spell the word by its definition.
+	DefinitionExercise
+)
+
+func (t *ExerciseType) String() string {
+	txt, err := t.MarshalText()
+	if err != nil {
+		return "unknown"
+	}
+	return txt
+}
+
+func (t *ExerciseType) MarshalText() (string, error) {
+	switch *t {
+	case ClozeExercise:
+		return "cloze", nil
+	case DefinitionExercise:
+		return "definition", nil
+	default:
+		return "", fmt.Errorf("%d is unknown ExerciseType", *t)
+	}
+}
+
+func (t *ExerciseType) UnmarshalText(text string) error {
+	switch text {
+	case "cloze":
+		*t = ClozeExercise
+	case "definition":
+		*t = DefinitionExercise
+	default:
+		return fmt.Errorf("%s is unknown ExerciseType representation", text)
+	}
+	return nil
+}
+
+func ExerciseTypeFromText(s string) (ExerciseType, error) {
+	var t ExerciseType
+	err := t.UnmarshalText(s)
+	if err != nil {
+		return 0, fmt.Errorf("models.ExerciseTypeFromText invalid exercise type string %s. %w", s, err)
+	}
+	return t, nil
+}
+
+// ExerciseTypesFromText parses comma separated list of exercise types, for ex: cloze,definition.
+func ExerciseTypesFromText(s string) ([]ExerciseType, error) {
+	var types []ExerciseType
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		t, err := ExerciseTypeFromText(part)
+		if err != nil {
+			return nil, fmt.Errorf("models.ExerciseTypesFromText. %w", err)
+		}
+		types = append(types, t)
+	}
+	return types, nil
+}
@@ -1,7 +1,17 @@
 package models
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strings"
 
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+var ErrInvalidLanguage = errors.New("invalid language tag")
+
+// Language is a BCP 47 language tag in its canonical form, for ex: en-US.
 type Language string
 
 func (l *Language) String() string {
@@ -16,11 +26,31 @@ func (l *Language) MarshalText() (string, error) {
 	return string(*l), nil
 }
 
+// UnmarshalText parses and canonicalises BCP 47 tag. POSIX style tags, like en_US, are accepted too.
 func (l *Language) UnmarshalText(s string) error {
-	*l = Language(s)
+	tag, err := language.Parse(strings.ReplaceAll(strings.TrimSpace(s), "_", "-"))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidLanguage, err)
+	}
+	if tag == language.Und {
+		return fmt.Errorf("%w: undefined language", ErrInvalidLanguage)
+	}
+	*l = Language(tag.String())
 	return nil
 }
 
+// Name returns English name of the language, for ex: American English for en-US.
+func (l *Language) Name() string {
+	tag, err := language.Parse(string(*l))
+	if err != nil {
+		return string(*l)
+	}
+	if name := display.English.Tags().Name(tag); name != "" {
+		return name
+	}
+	return string(*l)
+}
+
 func LanguageFromText(s string) (Language, error) {
 	var lang Language
 	err := lang.UnmarshalText(s)
@@ -29,3 +59,19 @@ func LanguageFromText(s string) (Language, error) {
 	}
 	return lang, nil
 }
+
+// LanguagesFromText parses comma separated list of languages, for ex: en_US,de.
+func LanguagesFromText(s string) ([]Language, error) {
+	var langs []Language
+	for _, part := range strings.Split(s, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		lang, err := LanguageFromText(part)
+		if err != nil {
+			return nil, fmt.Errorf("models.LanguagesFromText. %w", err)
+		}
+		langs = append(langs, lang)
+	}
+	return langs, nil
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLanguageFromText(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]Language{
+		"en_US":   "en-US",
+		"en-us":   "en-US",
+		" de ":    "de",
+		"zh_hant": "zh-Hant",
+		"pt-BR":   "pt-BR",
+	}
+	for in, expected := range cases {
+		actual, err := LanguageFromText(in)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %s", in, err)
+			continue
+		}
+		if actual != expected {
+			t.Errorf("expected %q for %q, got %q", expected, in, actual)
+		}
+	}
+
+	for _, in := range []string{"", "und", "english", "en_US_foo!"} {
+		if _, err := LanguageFromText(in); !errors.Is(err, ErrInvalidLanguage) {
+			t.Errorf("expected invalid language error for %q, got %v", in, err)
+		}
+	}
+}
+
+func TestLanguage_Name(t *testing.T) {
+	t.Parallel()
+
+	lang := Language("en-US")
+	if name := lang.Name(); name != "American English" {
+		t.Errorf("unexpected name %q", name)
+	}
+}
+
+func TestLanguagesFromText(t *testing.T) {
+	t.Parallel()
+
+	actual, err := LanguagesFromText("en_GB, de,,fr-CA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]Language{"en-GB", "de", "fr-CA"}, actual); diff != "" {
+		t.Errorf("unexpected languages (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Schedule is a spaced-repetition state of a word. Ease is used by SM-2,
+// Stability and Difficulty by FSRS, the rest is shared by both.
+type Schedule struct {
+	Ease        float64
+	Stability   float64
+	Difficulty  float64
+	Interval    time.Duration
+	Due         time.Time
+	LastReview  time.Time
+	Repetitions uint
+	Lapses      uint
+}
@@ -1,6 +1,9 @@
 package models
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type UserID string
 
@@ -19,5 +22,30 @@ func UserIDFromText(s string) (UserID, error) {
 }
 
 type User struct {
-	ID UserID
+	ID          UserID
+	DisplayName string
+	// NativeLanguage is the language definitions and prompts are given in.
+	NativeLanguage Language
+	// TargetLanguages are the languages the user learns.
+	TargetLanguages []Language
+	// Timezone is an IANA time zone name, for ex: Europe/Berlin. Empty means UTC.
+	Timezone string
+	// DailyGoal is the number of reviews a day the user aims for, zero if not set.
+	DailyGoal int
+	// ExerciseTypes are the preferred exercise types, the first one is used by default.
+	ExerciseTypes []ExerciseType
+	// SessionSize is the preferred number of exercises in a practice session, zero if not set.
+	SessionSize int
+}
+
+// Location returns the user's time zone, UTC if it is not set or unknown.
+func (u User) Location() *time.Location {
+	if u.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
 }
@@ -8,6 +8,14 @@ func (id WordID) String() string {
 	return string(id)
 }
 
+func WordIDFromText(s string) (WordID, error) {
+	if len(s) != objectIdHexLen {
+		return "", fmt.Errorf("models.WordIDFromText invalid word ID string %s", s)
+	}
+
+	return WordID(s), nil
+}
+
 type LearnStatus int
 
 func (s *LearnStatus) String() string {
@@ -46,6 +54,15 @@ func (s *LearnStatus) UnmarshalText(text string) error {
 	return nil
 }
 
+func LearnStatusFromText(s string) (LearnStatus, error) {
+	var status LearnStatus
+	err := status.UnmarshalText(s)
+	if err != nil {
+		return 0, fmt.Errorf("models.LearnStatusFromText invalid learn status string %s. %w", s, err)
+	}
+	return status, nil
+}
+
 const (
 	Pending LearnStatus = iota
 	InProgress
@@ -53,12 +70,23 @@ const (
 )
 
 type Word struct {
-	ID              WordID
-	UserID          UserID
-	Spelling        string
+	ID       WordID
+	UserID   UserID
+	Spelling string
+	// Definition is empty for words imported without one, for ex: from a Kindle. Such words
+	// get no generated exercises and aren't practiced by definition until it's set.
 	Definition      string
 	LexicalCategory string
 	Language        Language
-	LearnStatus     LearnStatus
-	AnsweredCount   uint
+	// DefinitionLanguage is the language the definition is given in, the user's native one.
+	DefinitionLanguage Language
+	LearnStatus        LearnStatus
+	AnsweredCount      uint
+	Exercises          []SentenceExercise
+	Archived           bool
+	Schedule           Schedule
+	// Tags are free-form labels given by the user, for ex: a textbook chapter.
+	Tags []string
+	// Source tells where the word comes from, for ex: the title of the book it was looked up in.
+	Source string
 }
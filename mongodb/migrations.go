@@ -0,0 +1,124 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// migrations are ordered by version, which starts from 1 and has no gaps. The latest version
+// is the one vocabulary.MongoRepository and users.MongoRepository write new documents with.
+// Migrations name collections and fields literally, so they keep working whatever the
+// repositories change later.
+var migrations = []Migration{
+	{
+		Version: 1,
+		Name:    "canonical_languages",
+		Up:      canonicalLanguagesUp,
+		Down:    canonicalLanguagesDown,
+	},
+	{
+		Version: 2,
+		Name:    "users_schema_version",
+		Up:      usersSchemaVersionUp,
+		Down:    usersSchemaVersionDown,
+	},
+}
+
+// canonicalLanguagesUp rewrites the languages of words added before they were validated, for
+// ex: en_US to en-US, so the repository reads them and filtering words by language finds them.
+// Languages which can't be parsed, for ex: english, fail the migration with the words named,
+// the other words are migrated and aren't gone through again.
+func canonicalLanguagesUp(ctx context.Context, db *mongo.Database) error {
+	const version = 1
+	col := db.Collection("vocabulary")
+
+	cur, err := col.Find(ctx, notMigrated(version), options.Find().SetProjection(bson.D{
+		{Key: "spelling", Value: 1},
+		{Key: "language", Value: 1},
+		{Key: "definitionlanguage", Value: 1},
+	}))
+	if err != nil {
+		return fmt.Errorf("unable to find words. %w", err)
+	}
+	defer cur.Close(ctx)
+
+	var invalid []string
+	for cur.Next(ctx) {
+		var doc struct {
+			ID                 primitive.ObjectID `bson:"_id"`
+			Spelling           string             `bson:"spelling"`
+			Language           string             `bson:"language"`
+			DefinitionLanguage string             `bson:"definitionlanguage"`
+		}
+		if err := cur.Decode(&doc); err != nil {
+			return fmt.Errorf("unable to decode word. %w", err)
+		}
+
+		lang, err := models.LanguageFromText(doc.Language)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s %q in %q", doc.ID.Hex(), doc.Spelling, doc.Language))
+			continue
+		}
+		set := bson.D{{Key: FieldSchemaVersion, Value: version}, {Key: "language", Value: lang}}
+		if doc.DefinitionLanguage != "" {
+			definitionLang, err := models.LanguageFromText(doc.DefinitionLanguage)
+			if err != nil {
+				invalid = append(invalid, fmt.Sprintf("%s %q defined in %q", doc.ID.Hex(), doc.Spelling, doc.DefinitionLanguage))
+				continue
+			}
+			set = append(set, bson.E{Key: "definitionlanguage", Value: definitionLang})
+		}
+
+		_, err = col.UpdateByID(ctx, doc.ID, bson.D{{Key: "$set", Value: set}})
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("word %s is in %s already, delete one of them and migrate again. %w", doc.ID.Hex(), lang, err)
+		}
+		if err != nil {
+			return fmt.Errorf("unable to update word %s. %w", doc.ID.Hex(), err)
+		}
+	}
+	if err := cur.Err(); err != nil {
+		return fmt.Errorf("unable to read words. %w", err)
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("words %s, fix or delete them and migrate again. %w", strings.Join(invalid, ", "), ErrInvalidLanguage)
+	}
+	return nil
+}
+
+// canonicalLanguagesDown only unmarks the words, their languages stay canonical: the original
+// ones aren't kept, and the code before the migration reads canonical languages as well.
+func canonicalLanguagesDown(ctx context.Context, db *mongo.Database) error {
+	return unmarkMigrated(ctx, db.Collection("vocabulary"), 1)
+}
+
+// usersSchemaVersionUp marks users with the schema version, which only words had before, so
+// later migrations of users tell the migrated ones apart. Words go to the version as well, there
+// is one version for the whole database.
+func usersSchemaVersionUp(ctx context.Context, db *mongo.Database) error {
+	const version = 2
+	for _, name := range []string{"vocabulary", "users"} {
+		_, err := db.Collection(name).UpdateMany(ctx, notMigrated(version),
+			bson.D{{Key: "$set", Value: bson.D{{Key: FieldSchemaVersion, Value: version}}}})
+		if err != nil {
+			return fmt.Errorf("unable to mark %s documents. %w", name, err)
+		}
+	}
+	return nil
+}
+
+func usersSchemaVersionDown(ctx context.Context, db *mongo.Database) error {
+	for _, name := range []string{"vocabulary", "users"} {
+		if err := unmarkMigrated(ctx, db.Collection(name), 2); err != nil {
+			return err
+		}
+	}
+	return nil
+}
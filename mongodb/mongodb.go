@@ -0,0 +1,266 @@
+// Package mongodb migrates the documents vocabforge keeps in MongoDB. Applied migrations are
+// recorded in the migrations collection, and every migrated document carries the version of the
+// last migration it went through in its schemaVersion field.
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	ErrUnknownVersion = errors.New("database schema is newer than the known migrations")
+	ErrLocked         = errors.New("database is migrated by another process")
+	// ErrInvalidLanguage is returned for words whose language isn't a language tag, for ex:
+	// english. Such words are to be fixed or deleted by hand before migrating.
+	ErrInvalidLanguage = errors.New("word has invalid language")
+)
+
+const (
+	migrationsCollection = "migrations"
+	// lockCollection holds the single lock document while migrations are applied or reverted.
+	lockCollection = "migrationsLock"
+	lockID         = "lock"
+	unlockTimeout  = 10 * time.Second
+	// FieldSchemaVersion is the field documents keep their schema version in, documents without
+	// it predate the migrations.
+	FieldSchemaVersion = "schemaVersion"
+)
+
+// Migration changes the documents from Version-1 to Version. Both Up and Down have to be
+// idempotent, they are run again if a previous run was interrupted halfway.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context, db *mongo.Database) error
+	Down    func(ctx context.Context, db *mongo.Database) error
+}
+
+// MigrationStatus tells if the migration is applied, AppliedAt is zero for pending ones.
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt time.Time
+}
+
+type lockEntity struct {
+	ID       string    `bson:"_id"`
+	LockedAt time.Time `bson:"lockedAt"`
+}
+
+type migrationEntity struct {
+	Version   int       `bson:"_id"`
+	Name      string    `bson:"name"`
+	AppliedAt time.Time `bson:"appliedAt"`
+}
+
+// Up applies the migrations the database doesn't have yet in order, and returns the version
+// the database is at. It fails with ErrLocked while another process migrates the database.
+func Up(ctx context.Context, db *mongo.Database) (int, error) {
+	unlock, err := lock(ctx, db)
+	if err != nil {
+		return 0, fmt.Errorf("mongodb.Up. %w", err)
+	}
+	version, err := up(ctx, db)
+	if unlockErr := unlock(); unlockErr != nil {
+		err = errors.Join(err, fmt.Errorf("mongodb.Up. %w", unlockErr))
+	}
+	return version, err
+}
+
+func up(ctx context.Context, db *mongo.Database) (int, error) {
+	applied, err := appliedMigrations(ctx, db)
+	if err != nil {
+		return 0, fmt.Errorf("mongodb.Up. %w", err)
+	}
+	version, err := checkVersion(applied)
+	if err != nil {
+		return version, fmt.Errorf("mongodb.Up. %w", err)
+	}
+
+	col := db.Collection(migrationsCollection)
+	for _, m := range migrations {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+		if err := m.Up(ctx, db); err != nil {
+			return version, fmt.Errorf("mongodb.Up unable to apply %d_%s. %w", m.Version, m.Name, err)
+		}
+		_, err := col.InsertOne(ctx, migrationEntity{Version: m.Version, Name: m.Name, AppliedAt: time.Now().UTC()})
+		if err != nil {
+			return version, fmt.Errorf("mongodb.Up unable to record %d_%s. %w", m.Version, m.Name, err)
+		}
+		version = m.Version
+	}
+	return version, nil
+}
+
+// Down reverts the latest applied migration, and returns the version the database is at.
+// Nothing is reverted in a database without migrations. It fails with ErrLocked while another
+// process migrates the database.
+func Down(ctx context.Context, db *mongo.Database) (int, error) {
+	unlock, err := lock(ctx, db)
+	if err != nil {
+		return 0, fmt.Errorf("mongodb.Down. %w", err)
+	}
+	version, err := down(ctx, db)
+	if unlockErr := unlock(); unlockErr != nil {
+		err = errors.Join(err, fmt.Errorf("mongodb.Down. %w", unlockErr))
+	}
+	return version, err
+}
+
+func down(ctx context.Context, db *mongo.Database) (int, error) {
+	applied, err := appliedMigrations(ctx, db)
+	if err != nil {
+		return 0, fmt.Errorf("mongodb.Down. %w", err)
+	}
+	version, err := checkVersion(applied)
+	if err != nil {
+		return version, fmt.Errorf("mongodb.Down. %w", err)
+	}
+	if version == 0 {
+		return 0, nil
+	}
+
+	m := migrations[version-1]
+	if err := m.Down(ctx, db); err != nil {
+		return version, fmt.Errorf("mongodb.Down unable to revert %d_%s. %w", m.Version, m.Name, err)
+	}
+	_, err = db.Collection(migrationsCollection).DeleteOne(ctx, bson.D{{Key: "_id", Value: m.Version}})
+	if err != nil {
+		return version, fmt.Errorf("mongodb.Down unable to unrecord %d_%s. %w", m.Version, m.Name, err)
+	}
+	return m.Version - 1, nil
+}
+
+// Status returns all the migrations ordered by version.
+func Status(ctx context.Context, db *mongo.Database) ([]MigrationStatus, error) {
+	applied, err := appliedMigrations(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("mongodb.Status. %w", err)
+	}
+	if _, err := checkVersion(applied); err != nil {
+		return nil, fmt.Errorf("mongodb.Status. %w", err)
+	}
+
+	statuses := make([]MigrationStatus, len(migrations))
+	for i, m := range migrations {
+		statuses[i] = MigrationStatus{Version: m.Version, Name: m.Name, AppliedAt: applied[m.Version].AppliedAt}
+	}
+	return statuses, nil
+}
+
+// Version returns the version of the database's schema, zero if no migration is applied.
+func Version(ctx context.Context, db *mongo.Database) (int, error) {
+	applied, err := appliedMigrations(ctx, db)
+	if err != nil {
+		return 0, fmt.Errorf("mongodb.Version. %w", err)
+	}
+	version, err := checkVersion(applied)
+	if err != nil {
+		return version, fmt.Errorf("mongodb.Version. %w", err)
+	}
+	return version, nil
+}
+
+// Empty reports whether the database has no documents for migrations to go through, for ex:
+// when it's new.
+func Empty(ctx context.Context, db *mongo.Database) (bool, error) {
+	for _, name := range []string{"vocabulary", "users"} {
+		n, err := db.Collection(name).CountDocuments(ctx, bson.D{}, options.Count().SetLimit(1))
+		if err != nil {
+			return false, fmt.Errorf("mongodb.Empty unable to count %s documents. %w", name, err)
+		}
+		if n > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// lock keeps other processes from migrating the database until the returned unlock is called.
+// The lock of a process which didn't unlock, for ex: crashed or failed to unlock, is to be removed by hand.
+func lock(ctx context.Context, db *mongo.Database) (func() error, error) {
+	col := db.Collection(lockCollection)
+	_, err := col.InsertOne(ctx, lockEntity{ID: lockID, LockedAt: time.Now().UTC()})
+	if mongo.IsDuplicateKeyError(err) {
+		var held lockEntity
+		if err := col.FindOne(ctx, bson.D{{Key: "_id", Value: lockID}}).Decode(&held); err != nil {
+			return nil, fmt.Errorf("unable to get the lock. %w", err)
+		}
+		return nil, fmt.Errorf("locked at %s, if no process migrates the database delete the %s document. %w",
+			held.LockedAt.Format(time.RFC3339), lockCollection, ErrLocked)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to lock. %w", err)
+	}
+
+	return func() error {
+		// the lock is released even if migrating was canceled
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), unlockTimeout)
+		defer cancel()
+		if _, err := col.DeleteOne(ctx, bson.D{{Key: "_id", Value: lockID}}); err != nil {
+			return fmt.Errorf("unable to unlock, delete the %s document. %w", lockCollection, err)
+		}
+		return nil
+	}, nil
+}
+
+func appliedMigrations(ctx context.Context, db *mongo.Database) (map[int]migrationEntity, error) {
+	cur, err := db.Collection(migrationsCollection).Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("unable to find applied migrations. %w", err)
+	}
+	var entities []migrationEntity
+	if err := cur.All(ctx, &entities); err != nil {
+		return nil, fmt.Errorf("unable to decode applied migrations. %w", err)
+	}
+
+	applied := make(map[int]migrationEntity, len(entities))
+	for _, e := range entities {
+		applied[e.Version] = e
+	}
+	return applied, nil
+}
+
+// checkVersion returns the highest version applied without gaps before it, and
+// ErrUnknownVersion if some applied migration isn't known.
+func checkVersion(applied map[int]migrationEntity) (int, error) {
+	version := 0
+	for _, m := range migrations {
+		if _, ok := applied[m.Version]; !ok {
+			break
+		}
+		version = m.Version
+	}
+	for v := range applied {
+		if v > len(migrations) || v < 1 {
+			return version, fmt.Errorf("version %d. %w", v, ErrUnknownVersion)
+		}
+	}
+	return version, nil
+}
+
+// notMigrated matches documents which didn't go through the migration of the version yet.
+func notMigrated(version int) bson.D {
+	return bson.D{{Key: FieldSchemaVersion, Value: bson.D{{Key: "$not", Value: bson.D{{Key: "$gte", Value: version}}}}}}
+}
+
+// unmarkMigrated moves documents which went through the migration of the version back to the
+// version before it.
+func unmarkMigrated(ctx context.Context, col *mongo.Collection, version int) error {
+	_, err := col.UpdateMany(ctx,
+		bson.D{{Key: FieldSchemaVersion, Value: bson.D{{Key: "$gte", Value: version}}}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: FieldSchemaVersion, Value: version - 1}}}})
+	if err != nil {
+		return fmt.Errorf("unable to unmark %s documents. %w", col.Name(), err)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pavelpuchok/vocabforge/users"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestMigrations(t *testing.T) {
+	t.Parallel()
+
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			t.Errorf("migration %d_%s is out of sequence", m.Version, m.Name)
+		}
+		if m.Up == nil || m.Down == nil {
+			t.Errorf("migration %d_%s misses up or down", m.Version, m.Name)
+		}
+	}
+	if latest := migrations[len(migrations)-1].Version; latest != vocabulary.MongoSchemaVersion {
+		t.Errorf("expected vocabulary.MongoSchemaVersion %d, got %d", latest, vocabulary.MongoSchemaVersion)
+	}
+	if latest := migrations[len(migrations)-1].Version; latest != users.MongoSchemaVersion {
+		t.Errorf("expected users.MongoSchemaVersion %d, got %d", latest, users.MongoSchemaVersion)
+	}
+}
+
+// TestUpDown runs against the MongoDB at VOCABFORGE_TEST_MONGO_URI in a database of its own.
+// It's skipped when the variable isn't set.
+func TestUpDown(t *testing.T) {
+	t.Parallel()
+
+	uri := os.Getenv("VOCABFORGE_TEST_MONGO_URI")
+	if uri == "" {
+		t.Skip("VOCABFORGE_TEST_MONGO_URI is not set")
+	}
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = client.Disconnect(context.Background())
+	})
+	db := client.Database("vocabforge_test_" + primitive.NewObjectID().Hex())
+	t.Cleanup(func() {
+		if err := db.Drop(context.Background()); err != nil {
+			t.Errorf("unable to drop test database. %s", err)
+		}
+	})
+
+	words := db.Collection("vocabulary")
+	_, err = words.InsertMany(ctx, []any{
+		bson.D{{Key: "spelling", Value: "run"}, {Key: "language", Value: "en_US"}, {Key: "definitionlanguage", Value: "ru"}},
+		bson.D{{Key: "spelling", Value: "Haus"}, {Key: "language", Value: "de"}},
+		bson.D{{Key: "spelling", Value: "???"}, {Key: "language", Value: "not a language"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Collection("users").InsertOne(ctx, bson.D{{Key: "displayName", Value: "Jane"}}); err != nil {
+		t.Fatal(err)
+	}
+	if empty, err := Empty(ctx, db); err != nil || empty {
+		t.Errorf("expected database not to be empty, got %v and %v", empty, err)
+	}
+	languages := func() map[string]string {
+		t.Helper()
+		cur, err := words.Find(ctx, bson.D{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var docs []struct {
+			Spelling      string `bson:"spelling"`
+			Language      string `bson:"language"`
+			SchemaVersion int    `bson:"schemaVersion"`
+		}
+		if err := cur.All(ctx, &docs); err != nil {
+			t.Fatal(err)
+		}
+		res := map[string]string{}
+		for _, d := range docs {
+			res[d.Spelling] = d.Language
+			if d.SchemaVersion != len(migrations) {
+				t.Errorf("expected %s to have schema version %d, got %d", d.Spelling, len(migrations), d.SchemaVersion)
+			}
+		}
+		var usr struct {
+			SchemaVersion int `bson:"schemaVersion"`
+		}
+		if err := db.Collection("users").FindOne(ctx, bson.D{}).Decode(&usr); err != nil {
+			t.Fatal(err)
+		}
+		if usr.SchemaVersion != len(migrations) {
+			t.Errorf("expected user to have schema version %d, got %d", len(migrations), usr.SchemaVersion)
+		}
+		return res
+	}
+
+	// words in languages which can't be parsed are named, and the migration goes on once they are fixed
+	_, err = Up(ctx, db)
+	if !errors.Is(err, ErrInvalidLanguage) || !strings.Contains(err.Error(), `"???" in "not a language"`) {
+		t.Fatalf("expected ErrInvalidLanguage naming the word, got %v", err)
+	}
+	_, err = words.UpdateOne(ctx, bson.D{{Key: "spelling", Value: "???"}}, bson.D{{Key: "$set", Value: bson.D{{Key: "language", Value: "fr"}}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the second time there is nothing to migrate
+	for range 2 {
+		version, err := Up(ctx, db)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if version != len(migrations) {
+			t.Errorf("expected version %d, got %d", len(migrations), version)
+		}
+		expected := map[string]string{"run": "en-US", "Haus": "de", "???": "fr"}
+		if diff := cmp.Diff(expected, languages()); diff != "" {
+			t.Errorf("unexpected languages (-want +got):\n%s", diff)
+		}
+	}
+
+	if version, err := Version(ctx, db); err != nil || version != len(migrations) {
+		t.Errorf("expected version %d, got %d and %v", len(migrations), version, err)
+	}
+
+	unlock, err := lock(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Down(ctx, db); !errors.Is(err, ErrLocked) {
+		t.Errorf("expected ErrLocked, got %v", err)
+	}
+	if err := unlock(); err != nil {
+		t.Fatal(err)
+	}
+
+	statuses, err := Status(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range statuses {
+		if s.AppliedAt.IsZero() {
+			t.Errorf("expected %d_%s to be applied", s.Version, s.Name)
+		}
+	}
+
+	for v := len(migrations); v > 0; v-- {
+		version, err := Down(ctx, db)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if version != v-1 {
+			t.Errorf("expected version %d after down, got %d", v-1, version)
+		}
+	}
+	if version, err := Down(ctx, db); err != nil || version != 0 {
+		t.Errorf("expected nothing to revert, got version %d and %v", version, err)
+	}
+	statuses, err = Status(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range statuses {
+		if !s.AppliedAt.IsZero() {
+			t.Errorf("expected %d_%s to be pending", s.Version, s.Name)
+		}
+	}
+
+	if _, err := db.Collection(migrationsCollection).InsertOne(ctx, migrationEntity{Version: 1000, Name: "future"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Up(ctx, db); !errors.Is(err, ErrUnknownVersion) {
+		t.Errorf("expected ErrUnknownVersion, got %v", err)
+	}
+}
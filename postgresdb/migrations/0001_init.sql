@@ -0,0 +1,66 @@
+-- ids and spellings are compared byte by byte, as in the other storages, whatever the database collation is
+CREATE TABLE users (
+	id TEXT COLLATE "C" PRIMARY KEY,
+	display_name TEXT NOT NULL DEFAULT '',
+	native_language TEXT NOT NULL DEFAULT '',
+	target_languages TEXT[] NOT NULL DEFAULT '{}',
+	timezone TEXT NOT NULL DEFAULT '',
+	daily_goal INTEGER NOT NULL DEFAULT 0,
+	exercise_types TEXT[] NOT NULL DEFAULT '{}',
+	session_size INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE credentials (
+	user_id TEXT COLLATE "C" NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	id TEXT NOT NULL,
+	kind TEXT NOT NULL,
+	name TEXT NOT NULL DEFAULT '',
+	scope TEXT NOT NULL,
+	hash TEXT NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL,
+	expires_at TIMESTAMPTZ,
+	-- parent_id is the API key a session was issued for, sessions are removed together with it
+	parent_id TEXT NOT NULL DEFAULT '',
+	PRIMARY KEY (user_id, id)
+);
+
+CREATE INDEX credentials_parent ON credentials (user_id, parent_id);
+
+CREATE TABLE words (
+	id TEXT COLLATE "C" PRIMARY KEY,
+	user_id TEXT COLLATE "C" NOT NULL,
+	spelling TEXT COLLATE "C" NOT NULL,
+	-- spelling_key is the lower cased spelling, lower() depends on the database locale
+	spelling_key TEXT NOT NULL,
+	definition TEXT NOT NULL DEFAULT '',
+	language TEXT NOT NULL,
+	definition_language TEXT NOT NULL DEFAULT '',
+	learn_status TEXT NOT NULL,
+	lexical_category TEXT NOT NULL DEFAULT '',
+	answered_count INTEGER NOT NULL DEFAULT 0,
+	exercises JSONB NOT NULL DEFAULT '[]',
+	archived BOOLEAN NOT NULL DEFAULT FALSE,
+	tags TEXT[] NOT NULL DEFAULT '{}',
+	source TEXT NOT NULL DEFAULT '',
+	ease DOUBLE PRECISION NOT NULL DEFAULT 0,
+	stability DOUBLE PRECISION NOT NULL DEFAULT 0,
+	difficulty DOUBLE PRECISION NOT NULL DEFAULT 0,
+	-- interval is in nanoseconds
+	interval BIGINT NOT NULL DEFAULT 0,
+	due TIMESTAMPTZ NOT NULL,
+	last_review TIMESTAMPTZ NOT NULL,
+	repetitions INTEGER NOT NULL DEFAULT 0,
+	lapses INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX words_user_spelling ON words (user_id, spelling_key, language);
+CREATE INDEX words_user_due ON words (user_id, due);
+
+CREATE TABLE telegram_chats (
+	chat_id BIGINT PRIMARY KEY,
+	user_id TEXT NOT NULL,
+	-- credential_id is the API key the chat was linked with, the chat is unlinked once it's revoked
+	credential_id TEXT NOT NULL DEFAULT '',
+	linked_at TIMESTAMPTZ NOT NULL,
+	reminded_at TIMESTAMPTZ
+);
@@ -0,0 +1,215 @@
+// Package postgresdb opens the PostgreSQL database vocabforge keeps all its data in when
+// several instances share it, and migrates its schema. Applied migrations are recorded in
+// the schema_migrations table.
+package postgresdb
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pavelpuchok/vocabforge/sqlmigrations"
+)
+
+var (
+	ErrUnknownVersion = errors.New("database schema is newer than the known migrations")
+	ErrDuplicateWords = errors.New("words are in the vocabulary more than once")
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLock is the advisory lock key instances take while migrating, so only one of
+// them applies a migration when they start at the same time.
+const migrationLock = 0x766f6361 // "voca"
+
+// checks run before the migrations of their versions, in the same transaction, and fail on
+// data the migration can't be applied to with an error telling what to fix.
+var checks = map[int]func(ctx context.Context, tx pgx.Tx) error{
+	2: checkUniqueSpelling,
+}
+
+// Migration changes the schema from Version-1 to Version.
+type Migration = sqlmigrations.Migration
+
+// PoolConfig sets up the connection pool, zero values keep the pgxpool defaults.
+type PoolConfig struct {
+	MaxConns        int32
+	MinConns        int32
+	MaxConnLifetime time.Duration
+	MaxConnIdleTime time.Duration
+}
+
+// Open connects to the database at url and migrates it to the latest schema.
+func Open(ctx context.Context, url string, cfg PoolConfig) (*pgxpool.Pool, error) {
+	poolCfg, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		return nil, fmt.Errorf("postgresdb.Open unable to parse url. %w", err)
+	}
+	if cfg.MaxConns > 0 {
+		poolCfg.MaxConns = cfg.MaxConns
+	}
+	if cfg.MinConns > 0 {
+		poolCfg.MinConns = cfg.MinConns
+	}
+	if cfg.MaxConnLifetime > 0 {
+		poolCfg.MaxConnLifetime = cfg.MaxConnLifetime
+	}
+	if cfg.MaxConnIdleTime > 0 {
+		poolCfg.MaxConnIdleTime = cfg.MaxConnIdleTime
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		return nil, fmt.Errorf("postgresdb.Open unable to create pool. %w", err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("postgresdb.Open unable to connect. %w", err)
+	}
+
+	if _, err := Migrate(ctx, pool); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("postgresdb.Open. %w", err)
+	}
+	return pool, nil
+}
+
+// Migrations returns all the migrations ordered by version.
+func Migrations() ([]Migration, error) {
+	dir, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("postgresdb.Migrations. %w", err)
+	}
+	migrations, err := sqlmigrations.Load(dir)
+	if err != nil {
+		return nil, fmt.Errorf("postgresdb.Migrations. %w", err)
+	}
+	return migrations, nil
+}
+
+// Migrate applies the migrations the database doesn't have yet, each in a transaction
+// of its own. It returns the version the database is at.
+func Migrate(ctx context.Context, pool *pgxpool.Pool) (int, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return 0, fmt.Errorf("postgresdb.Migrate. %w", err)
+	}
+
+	version := 0
+	for _, m := range migrations {
+		applied, err := apply(ctx, pool, m)
+		if err != nil {
+			return version, fmt.Errorf("postgresdb.Migrate unable to apply %d_%s. %w", m.Version, m.Name, err)
+		}
+		version = applied
+	}
+	if version > len(migrations) {
+		return version, fmt.Errorf("postgresdb.Migrate version %d. %w", version, ErrUnknownVersion)
+	}
+	return version, nil
+}
+
+// Version returns the version of the database's schema, zero for an empty database.
+func Version(ctx context.Context, pool *pgxpool.Pool) (int, error) {
+	var exists bool
+	err := pool.QueryRow(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists)
+	if err != nil {
+		return 0, fmt.Errorf("postgresdb.Version unable to look up schema_migrations. %w", err)
+	}
+	if !exists {
+		return 0, nil
+	}
+
+	version, err := currentVersion(ctx, pool)
+	if err != nil {
+		return 0, fmt.Errorf("postgresdb.Version. %w", err)
+	}
+	return version, nil
+}
+
+func currentVersion(ctx context.Context, q interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}) (int, error) {
+	var version int
+	if err := q.QueryRow(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version); err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// apply applies m unless another instance already did, and returns the version the
+// database is at afterwards.
+func apply(ctx context.Context, pool *pgxpool.Pool, m Migration) (int, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, migrationLock); err != nil {
+		return 0, err
+	}
+	_, err = tx.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`)
+	if err != nil {
+		return 0, err
+	}
+
+	version, err := currentVersion(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	if version >= m.Version {
+		return version, nil
+	}
+
+	if check, ok := checks[m.Version]; ok {
+		if err := check(ctx, tx); err != nil {
+			return 0, err
+		}
+	}
+	if _, err := tx.Exec(ctx, m.SQL); err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, m.Version, m.Name); err != nil {
+		return 0, err
+	}
+	return m.Version, tx.Commit(ctx)
+}
+
+// checkUniqueSpelling fails naming the words a user has more than once in a language, the
+// unique index of 0002_unique_spelling can't be created while they are there.
+func checkUniqueSpelling(ctx context.Context, tx pgx.Tx) error {
+	rows, err := tx.Query(ctx, `SELECT user_id, language, string_agg(spelling, ', ')
+		FROM words GROUP BY user_id, spelling_key, language HAVING COUNT(*) > 1`)
+	if err != nil {
+		return fmt.Errorf("unable to look for duplicate words. %w", err)
+	}
+	defer rows.Close()
+
+	var duplicates []string
+	for rows.Next() {
+		var userID, language, spellings string
+		if err := rows.Scan(&userID, &language, &spellings); err != nil {
+			return fmt.Errorf("unable to scan duplicate words. %w", err)
+		}
+		duplicates = append(duplicates, fmt.Sprintf("%s in %s of user %s", spellings, language, userID))
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("unable to read duplicate words. %w", err)
+	}
+	if len(duplicates) > 0 {
+		return fmt.Errorf("%w, delete all but one of each: %s", ErrDuplicateWords, strings.Join(duplicates, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package postgresdb
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pavelpuchok/vocabforge/postgresdb/postgresdbtest"
+)
+
+func TestOpen(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	url := postgresdbtest.URL(t)
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the second time there is nothing to migrate
+	for range 2 {
+		pool, err := Open(ctx, url, PoolConfig{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		version, err := Version(ctx, pool)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if version != len(migrations) {
+			t.Errorf("expected version %d, got %d", len(migrations), version)
+		}
+		pool.Close()
+	}
+
+	pool, err := Open(ctx, url, PoolConfig{MaxConns: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+	if _, err := pool.Exec(ctx, "INSERT INTO schema_migrations (version, name) VALUES (1000, 'future')"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Migrate(ctx, pool); !errors.Is(err, ErrUnknownVersion) {
+		t.Errorf("expected ErrUnknownVersion, got %v", err)
+	}
+}
+
+func TestMigrate_DuplicateWords(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, postgresdbtest.URL(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := apply(ctx, pool, migrations[0]); err != nil {
+		t.Fatal(err)
+	}
+	_, err = pool.Exec(ctx, `INSERT INTO words (id, user_id, spelling, spelling_key, language, learn_status, due, last_review)
+		VALUES ('1', 'u1', 'run', 'run', 'en', 'pending', now(), now()), ('2', 'u1', 'Run', 'run', 'en', 'pending', now(), now()),
+			('3', 'u1', 'run', 'run', 'de', 'pending', now(), now()), ('4', 'u2', 'run', 'run', 'en', 'pending', now(), now())`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Migrate(ctx, pool)
+	if !errors.Is(err, ErrDuplicateWords) {
+		t.Fatalf("expected ErrDuplicateWords, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "run, Run in en of user u1") && !strings.Contains(err.Error(), "Run, run in en of user u1") {
+		t.Errorf("expected the duplicates to be named, got %v", err)
+	}
+	if version, err := Version(ctx, pool); err != nil || version != 1 {
+		t.Errorf("expected version 1, got %d and %v", version, err)
+	}
+}
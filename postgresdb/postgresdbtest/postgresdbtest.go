@@ -0,0 +1,56 @@
+// Package postgresdbtest gives tests a PostgreSQL database of their own.
+package postgresdbtest
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// URL returns the URL of the PostgreSQL at VOCABFORGE_TEST_POSTGRES_URL with an empty schema
+// of the test's own on the search path, the schema is dropped when the test ends. The test is
+// skipped when the variable isn't set.
+func URL(t *testing.T) string {
+	t.Helper()
+
+	base := os.Getenv("VOCABFORGE_TEST_POSTGRES_URL")
+	if base == "" {
+		t.Skip("VOCABFORGE_TEST_POSTGRES_URL is not set")
+	}
+	u, err := url.Parse(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close(ctx)
+
+	schema := pgx.Identifier{"vocabforge_test_" + primitive.NewObjectID().Hex()}
+	if _, err := conn.Exec(ctx, "CREATE SCHEMA "+schema.Sanitize()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		conn, err := pgx.Connect(ctx, base)
+		if err != nil {
+			t.Errorf("unable to connect to drop test schema. %s", err)
+			return
+		}
+		defer conn.Close(ctx)
+		if _, err := conn.Exec(ctx, "DROP SCHEMA "+schema.Sanitize()+" CASCADE"); err != nil {
+			t.Errorf("unable to drop test schema. %s", err)
+		}
+	})
+
+	q := u.Query()
+	q.Set("search_path", schema[0])
+	u.RawQuery = q.Encode()
+	return u.String()
+}
@@ -0,0 +1,39 @@
+package practice
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/pavelpuchok/vocabforge/levenshtein"
+	"github.com/pavelpuchok/vocabforge/scheduling"
+)
+
+// minTypoLen is the shortest answer for which a single typo is still accepted.
+const minTypoLen = 5
+
+var answerReplacer = strings.NewReplacer("’", "'", "‘", "'", "`", "'")
+
+// Check grades the given answer against the expected one. Case, surrounding
+// punctuation and extra whitespace are ignored, a single typo in a long word
+// is accepted as a hard recall.
+func Check(expected, given string) scheduling.Grade {
+	expected, given = normalize(expected), normalize(given)
+	switch {
+	case given == "":
+		return scheduling.Again
+	case given == expected:
+		return scheduling.Good
+	case len([]rune(expected)) >= minTypoLen && levenshtein.Distance(expected, given) == 1:
+		return scheduling.Hard
+	default:
+		return scheduling.Again
+	}
+}
+
+func normalize(s string) string {
+	s = answerReplacer.Replace(strings.ToLower(s))
+	s = strings.TrimFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r) && r != '\''
+	})
+	return strings.Join(strings.Fields(s), " ")
+}
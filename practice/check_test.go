@@ -0,0 +1,32 @@
+package practice
+
+import (
+	"testing"
+
+	"github.com/pavelpuchok/vocabforge/scheduling"
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		expected string
+		given    string
+		grade    scheduling.Grade
+	}{
+		{"ran", "ran", scheduling.Good},
+		{"ran", "  Ran. ", scheduling.Good},
+		{"don't", "don’t", scheduling.Good},
+		{"give up", "give   up", scheduling.Good},
+		{"ran", "run", scheduling.Again},
+		{"ran", "", scheduling.Again},
+		{"thought", "thougt", scheduling.Hard},
+		{"thought", "taught", scheduling.Again},
+	}
+
+	for _, c := range cases {
+		if actual := Check(c.expected, c.given); actual != c.grade {
+			t.Errorf("Check(%q, %q): want %s, got %s", c.expected, c.given, c.grade.String(), actual.String())
+		}
+	}
+}
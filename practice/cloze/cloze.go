@@ -0,0 +1,71 @@
+package cloze
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	OpenTag  = "<%"
+	CloseTag = "%>"
+	// Blank replaces the marked word in the prompt shown to a learner.
+	Blank = "_____"
+)
+
+var (
+	ErrNoMarkup        = errors.New("sentence has no marked word")
+	ErrMultipleMarkup  = errors.New("sentence has more than one marked word")
+	ErrMalformedMarkup = errors.New("sentence has malformed markup")
+)
+
+// Cloze is a sentence with a single word hidden. Before + Answer + After gives the original sentence.
+type Cloze struct {
+	Before string
+	Answer string
+	After  string
+}
+
+// Prompt returns the sentence with the answer replaced by Blank.
+func (c Cloze) Prompt() string {
+	return c.Before + Blank + c.After
+}
+
+func (c Cloze) Text() string {
+	return c.Before + c.Answer + c.After
+}
+
+// Parse parses a sentence with exactly one word wrapped in <% and %>.
+func Parse(sentence string) (Cloze, error) {
+	start := strings.Index(sentence, OpenTag)
+	if start == -1 {
+		if strings.Contains(sentence, CloseTag) {
+			return Cloze{}, fmt.Errorf("cloze.Parse %q. %w", sentence, ErrMalformedMarkup)
+		}
+		return Cloze{}, fmt.Errorf("cloze.Parse %q. %w", sentence, ErrNoMarkup)
+	}
+
+	rest := sentence[start+len(OpenTag):]
+	end := strings.Index(rest, CloseTag)
+	if end == -1 {
+		return Cloze{}, fmt.Errorf("cloze.Parse %q. %w", sentence, ErrMalformedMarkup)
+	}
+
+	answer := strings.TrimSpace(rest[:end])
+	after := rest[end+len(CloseTag):]
+	if answer == "" || strings.Contains(answer, OpenTag) {
+		return Cloze{}, fmt.Errorf("cloze.Parse %q. %w", sentence, ErrMalformedMarkup)
+	}
+	if strings.Contains(after, OpenTag) {
+		return Cloze{}, fmt.Errorf("cloze.Parse %q. %w", sentence, ErrMultipleMarkup)
+	}
+	if strings.Contains(after, CloseTag) {
+		return Cloze{}, fmt.Errorf("cloze.Parse %q. %w", sentence, ErrMalformedMarkup)
+	}
+
+	return Cloze{
+		Before: sentence[:start],
+		Answer: answer,
+		After:  after,
+	}, nil
+}
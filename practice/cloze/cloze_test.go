@@ -0,0 +1,43 @@
+package cloze
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	c, err := Parse("She <% ran %> to the store.")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Cloze{Before: "She ", Answer: "ran", After: " to the store."}
+	if diff := cmp.Diff(expected, c); diff != "" {
+		t.Errorf("unexpected cloze (-want +got):\n%s", diff)
+	}
+	if c.Prompt() != "She _____ to the store." {
+		t.Errorf("unexpected prompt %q", c.Prompt())
+	}
+	if c.Text() != "She ran to the store." {
+		t.Errorf("unexpected text %q", c.Text())
+	}
+
+	invalid := map[string]error{
+		"She ran to the store.":              ErrNoMarkup,
+		"She <%ran to the store.":            ErrMalformedMarkup,
+		"She ran%> to the store.":            ErrMalformedMarkup,
+		"She <%%> to the store.":             ErrMalformedMarkup,
+		"She <%ran%> to the <%store%>.":      ErrMultipleMarkup,
+		"She <%ran%> to the store%>.":        ErrMalformedMarkup,
+		"She <% ran <% fast %> to the store": ErrMalformedMarkup,
+	}
+	for s, expectedErr := range invalid {
+		if _, err := Parse(s); !errors.Is(err, expectedErr) {
+			t.Errorf("expected %v for %q, got %v", expectedErr, s, err)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package practice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/practice/cloze"
+	"github.com/pavelpuchok/vocabforge/scheduling"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+)
+
+const DefaultSessionSize = 10
+
+var ErrExerciseNotFound = errors.New("exercise not found")
+
+type ExerciseType = models.ExerciseType
+
+const (
+	// Cloze asks to fill the marked word in a generated sentence.
+	Cloze = models.ClozeExercise
+	// Definition asks to spell the word by its definition.
+	Definition = models.DefinitionExercise
+)
+
+func ExerciseTypeFromText(s string) (ExerciseType, error) {
+	t, err := models.ExerciseTypeFromText(s)
+	if err != nil {
+		return 0, fmt.Errorf("practice.ExerciseTypeFromText. %w", err)
+	}
+	return t, nil
+}
+
+type Service struct {
+	vocabulary VocabularyService
+	scheduler  scheduling.Scheduler
+	now        func() time.Time
+}
+
+type VocabularyService interface {
+	GetWord(ctx context.Context, userID models.UserID, wordID models.WordID) (models.Word, error)
+	ListWords(ctx context.Context, userID models.UserID, filter vocabulary.ListFilter) (vocabulary.WordsPage, error)
+	RecordAnswer(ctx context.Context, userID models.UserID, wordID models.WordID, exerciseIndex int, correct bool, status models.LearnStatus, schedule models.Schedule) (models.Word, error)
+}
+
+func NewService(vocabulary VocabularyService, scheduler scheduling.Scheduler) Service {
+	return Service{
+		vocabulary,
+		scheduler,
+		time.Now,
+	}
+}
+
+type Session struct {
+	UserID models.UserID
+	Items  []Item
+}
+
+// Item is a single exercise of a session. ExerciseIndex and Cloze are set for Cloze items only.
+type Item struct {
+	Type          ExerciseType
+	Word          models.Word
+	ExerciseIndex int
+	Cloze         cloze.Cloze
+}
+
+// Prompt returns the text shown to a learner.
+func (i Item) Prompt() string {
+	if i.Type == Definition {
+		return i.Word.Definition
+	}
+	return i.Cloze.Prompt()
+}
+
+type Result struct {
+	Correct bool
+	Grade   scheduling.Grade
+	// Expected is the answer hidden in the sentence.
+	Expected string
+	Word     models.Word
+}
+
+// StartSession picks up to size words due for a review and one exercise of type t for each of them.
+// Empty lang means words of any language.
+func (s Service) StartSession(ctx context.Context, userID models.UserID, lang models.Language, size int, t ExerciseType) (Session, error) {
+	if size <= 0 {
+		size = DefaultSessionSize
+	}
+
+	session := Session{UserID: userID}
+	filter := vocabulary.ListFilter{
+		Language:  lang,
+		DueBefore: s.now(),
+		Sort:      vocabulary.SortByDueAsc,
+		Limit:     size,
+	}
+
+	for len(session.Items) < size {
+		page, err := s.vocabulary.ListWords(ctx, userID, filter)
+		if err != nil {
+			return session, fmt.Errorf("practice.Service.StartSession unable to list due words. %w", err)
+		}
+
+		for _, w := range page.Words {
+			item, ok := pickItem(w, t)
+			if !ok {
+				continue
+			}
+			session.Items = append(session.Items, item)
+			if len(session.Items) == size {
+				break
+			}
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+		filter.Cursor = page.NextCursor
+	}
+
+	return session, nil
+}
+
+// Answer checks the answer on the word's exercise, reschedules the word and persists the outcome.
+func (s Service) Answer(ctx context.Context, userID models.UserID, wordID models.WordID, exerciseIndex int, answer string) (Result, error) {
+	word, err := s.vocabulary.GetWord(ctx, userID, wordID)
+	if err != nil {
+		return Result{}, fmt.Errorf("practice.Service.Answer unable to get word. %w", err)
+	}
+
+	if exerciseIndex < 0 || exerciseIndex >= len(word.Exercises) {
+		return Result{}, fmt.Errorf("practice.Service.Answer exercise %d of word %s. %w", exerciseIndex, wordID, ErrExerciseNotFound)
+	}
+
+	c, err := cloze.Parse(word.Exercises[exerciseIndex].Sentence)
+	if err != nil {
+		return Result{}, fmt.Errorf("practice.Service.Answer unable to parse exercise. %w", err)
+	}
+
+	res, err := s.record(ctx, word, exerciseIndex, c.Answer, answer)
+	if err != nil {
+		return res, fmt.Errorf("practice.Service.Answer unable to record answer. %w", err)
+	}
+	return res, nil
+}
+
+// AnswerDefinition checks the spelling given for the word's definition, reschedules the word and persists the outcome.
+func (s Service) AnswerDefinition(ctx context.Context, userID models.UserID, wordID models.WordID, answer string) (Result, error) {
+	word, err := s.vocabulary.GetWord(ctx, userID, wordID)
+	if err != nil {
+		return Result{}, fmt.Errorf("practice.Service.AnswerDefinition unable to get word. %w", err)
+	}
+
+	res, err := s.record(ctx, word, -1, word.Spelling, answer)
+	if err != nil {
+		return res, fmt.Errorf("practice.Service.AnswerDefinition unable to record answer. %w", err)
+	}
+	return res, nil
+}
+
+func (s Service) record(ctx context.Context, word models.Word, exerciseIndex int, expected, answer string) (Result, error) {
+	grade := Check(expected, answer)
+	correct := grade != scheduling.Again
+	reviewed := scheduling.Review(s.scheduler, word, grade, s.now())
+
+	word, err := s.vocabulary.RecordAnswer(ctx, word.UserID, word.ID, exerciseIndex, correct, reviewed.LearnStatus, reviewed.Schedule)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		Correct:  correct,
+		Grade:    grade,
+		Expected: expected,
+		Word:     word,
+	}, nil
+}
+
+// pickItem selects the first unanswered exercise of the word with a valid markup,
+// rotating through the answered ones once all of them were practiced.
+func pickItem(w models.Word, t ExerciseType) (Item, bool) {
+	if t == Definition {
+		return Item{Type: Definition, Word: w, ExerciseIndex: -1}, w.Definition != ""
+	}
+
+	var answered []Item
+	for i, e := range w.Exercises {
+		c, err := cloze.Parse(e.Sentence)
+		if err != nil {
+			continue
+		}
+		item := Item{Word: w, ExerciseIndex: i, Cloze: c}
+		if !e.Answered {
+			return item, true
+		}
+		answered = append(answered, item)
+	}
+
+	if len(answered) == 0 {
+		return Item{}, false
+	}
+	return answered[w.AnsweredCount%uint(len(answered))], true
+}
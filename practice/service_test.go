@@ -0,0 +1,105 @@
+package practice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/scheduling"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+)
+
+type fakeVocabulary struct {
+	words map[models.WordID]models.Word
+}
+
+func (f *fakeVocabulary) GetWord(_ context.Context, _ models.UserID, wordID models.WordID) (models.Word, error) {
+	w, ok := f.words[wordID]
+	if !ok {
+		return w, vocabulary.ErrWordNotFound
+	}
+	return w, nil
+}
+
+func (f *fakeVocabulary) ListWords(_ context.Context, _ models.UserID, filter vocabulary.ListFilter) (vocabulary.WordsPage, error) {
+	var page vocabulary.WordsPage
+	for _, id := range []models.WordID{"000000000000000000000001", "000000000000000000000002", "000000000000000000000003"} {
+		if w, ok := f.words[id]; ok && !w.Schedule.Due.After(filter.DueBefore) {
+			page.Words = append(page.Words, w)
+		}
+	}
+	return page, nil
+}
+
+func (f *fakeVocabulary) RecordAnswer(_ context.Context, _ models.UserID, wordID models.WordID, exerciseIndex int, correct bool, status models.LearnStatus, schedule models.Schedule) (models.Word, error) {
+	w := f.words[wordID]
+	if correct {
+		w.Exercises[exerciseIndex].Answered = true
+		w.AnsweredCount++
+	}
+	w.LearnStatus = status
+	w.Schedule = schedule
+	f.words[wordID] = w
+	return w, nil
+}
+
+func TestService(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	vocab := &fakeVocabulary{words: map[models.WordID]models.Word{
+		"000000000000000000000001": {
+			ID: "000000000000000000000001",
+			Exercises: []models.SentenceExercise{
+				{Sentence: "I <%ran%> home.", Answered: true},
+				{Sentence: "broken sentence"},
+				{Sentence: "They <% run %> daily."},
+			},
+		},
+		"000000000000000000000002": {
+			ID:        "000000000000000000000002",
+			Exercises: []models.SentenceExercise{{Sentence: "no markup"}},
+		},
+		"000000000000000000000003": {
+			ID:        "000000000000000000000003",
+			Exercises: []models.SentenceExercise{{Sentence: "<%Later%> word."}},
+			Schedule:  models.Schedule{Due: now.Add(time.Hour)},
+		},
+	}}
+
+	s := NewService(vocab, scheduling.NewSM2())
+	s.now = func() time.Time { return now }
+
+	session, err := s.StartSession(context.Background(), "000000000000000000000000", "", 5, Cloze)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(session.Items) != 1 || session.Items[0].ExerciseIndex != 2 || session.Items[0].Cloze.Answer != "run" {
+		t.Fatalf("unexpected session %+v", session)
+	}
+
+	item := session.Items[0]
+	res, err := s.Answer(context.Background(), session.UserID, item.Word.ID, item.ExerciseIndex, "Run")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Correct || res.Expected != "run" || res.Word.AnsweredCount != 1 || !res.Word.Exercises[2].Answered {
+		t.Errorf("unexpected result %+v", res)
+	}
+	if res.Word.LearnStatus != models.InProgress || !res.Word.Schedule.Due.After(now) {
+		t.Errorf("expected word to be rescheduled, got %+v", res.Word)
+	}
+
+	res, err = s.Answer(context.Background(), session.UserID, item.Word.ID, 0, "walked")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Correct || res.Word.AnsweredCount != 1 || res.Word.Schedule.Lapses != 1 {
+		t.Errorf("unexpected result %+v", res)
+	}
+
+	if _, err := s.Answer(context.Background(), session.UserID, item.Word.ID, 3, "x"); err == nil {
+		t.Error("expected error for unknown exercise")
+	}
+}
@@ -1,107 +1,1127 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/pavelpuchok/vocabforge/backup"
+	"github.com/pavelpuchok/vocabforge/grpcapi"
+	"github.com/pavelpuchok/vocabforge/httpapi"
 	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/mongodb"
+	"github.com/pavelpuchok/vocabforge/practice"
+	"github.com/pavelpuchok/vocabforge/scheduling"
+	"github.com/pavelpuchok/vocabforge/telegram"
+	"github.com/pavelpuchok/vocabforge/tui"
 	"github.com/pavelpuchok/vocabforge/usecases/addword"
+	"github.com/pavelpuchok/vocabforge/usecases/answerexercise"
+	"github.com/pavelpuchok/vocabforge/usecases/backupuser"
+	"github.com/pavelpuchok/vocabforge/usecases/createapikey"
+	"github.com/pavelpuchok/vocabforge/usecases/createsession"
 	"github.com/pavelpuchok/vocabforge/usecases/createuser"
+	"github.com/pavelpuchok/vocabforge/usecases/deleteword"
+	"github.com/pavelpuchok/vocabforge/usecases/drill"
+	"github.com/pavelpuchok/vocabforge/usecases/editword"
+	"github.com/pavelpuchok/vocabforge/usecases/exportanki"
+	"github.com/pavelpuchok/vocabforge/usecases/generateexercises"
+	"github.com/pavelpuchok/vocabforge/usecases/getuser"
+	"github.com/pavelpuchok/vocabforge/usecases/getword"
+	"github.com/pavelpuchok/vocabforge/usecases/importanki"
+	"github.com/pavelpuchok/vocabforge/usecases/importkindle"
+	"github.com/pavelpuchok/vocabforge/usecases/importwords"
+	"github.com/pavelpuchok/vocabforge/usecases/listwords"
+	"github.com/pavelpuchok/vocabforge/usecases/restoreuser"
+	"github.com/pavelpuchok/vocabforge/usecases/reviewword"
+	"github.com/pavelpuchok/vocabforge/usecases/revokeapikey"
+	"github.com/pavelpuchok/vocabforge/usecases/startpractice"
+	"github.com/pavelpuchok/vocabforge/usecases/stats"
+	"github.com/pavelpuchok/vocabforge/usecases/updateuser"
 	"github.com/pavelpuchok/vocabforge/users"
 	"github.com/pavelpuchok/vocabforge/vocabulary"
 	"github.com/pavelpuchok/vocabforge/vocabulary/sentences"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/sync/errgroup"
 )
 
 func run(cfg Config, logger *slog.Logger) error {
-	if cfg.Mongo.URI == "" {
-		return errors.New("main.run missing MongoDB URI")
+	st, err := openStorage(cfg)
+	if err != nil {
+		return fmt.Errorf("main.run unable to open storage. %w", err)
+	}
+	defer func() {
+		if err := st.close(context.Background()); err != nil {
+			logger.Error("main.run failed to close storage", slog.String("err", err.Error()))
+		}
+	}()
+
+	switch cfg.Subcommand {
+	case CreateUser:
+		err := processCreateUserCmd(logger, cfg, st)
+		if err != nil {
+			return fmt.Errorf("main.run create user command failed. %w", err)
+		}
+	case AddWord:
+		err := processAddWordCmd(logger, cfg, st)
+		if err != nil {
+			return fmt.Errorf("main.run add word command failed. %w", err)
+		}
+	case ListWords:
+		err := processListWordsCmd(logger, cfg, st)
+		if err != nil {
+			return fmt.Errorf("main.run list words command failed. %w", err)
+		}
+	case EditWord:
+		err := processEditWordCmd(logger, cfg, st)
+		if err != nil {
+			return fmt.Errorf("main.run edit word command failed. %w", err)
+		}
+	case DeleteWord:
+		err := processDeleteWordCmd(logger, cfg, st)
+		if err != nil {
+			return fmt.Errorf("main.run delete word command failed. %w", err)
+		}
+	case ReviewWord:
+		err := processReviewWordCmd(logger, cfg, st)
+		if err != nil {
+			return fmt.Errorf("main.run review word command failed. %w", err)
+		}
+	case Practice:
+		err := processPracticeCmd(logger, cfg, st)
+		if err != nil {
+			return fmt.Errorf("main.run practice command failed. %w", err)
+		}
+	case GetUser:
+		err := processGetUserCmd(logger, cfg, st)
+		if err != nil {
+			return fmt.Errorf("main.run get user command failed. %w", err)
+		}
+	case UpdateUser:
+		err := processUpdateUserCmd(logger, cfg, st)
+		if err != nil {
+			return fmt.Errorf("main.run update user command failed. %w", err)
+		}
+	case Serve:
+		err := processServeCmd(logger, cfg, st)
+		if err != nil {
+			return fmt.Errorf("main.run serve command failed. %w", err)
+		}
+	case CreateAPIKey:
+		err := processCreateAPIKeyCmd(logger, cfg, st)
+		if err != nil {
+			return fmt.Errorf("main.run create API key command failed. %w", err)
+		}
+	case RevokeAPIKey:
+		err := processRevokeAPIKeyCmd(logger, cfg, st)
+		if err != nil {
+			return fmt.Errorf("main.run revoke API key command failed. %w", err)
+		}
+	case CachePurge:
+		err := processCachePurgeCmd(logger, cfg, st)
+		if err != nil {
+			return fmt.Errorf("main.run cache purge command failed. %w", err)
+		}
+	case Import:
+		err := processImportCmd(logger, cfg, st)
+		if err != nil {
+			return fmt.Errorf("main.run import command failed. %w", err)
+		}
+	case AnkiExport:
+		err := processAnkiExportCmd(logger, cfg, st)
+		if err != nil {
+			return fmt.Errorf("main.run anki export command failed. %w", err)
+		}
+	case AnkiImport:
+		err := processAnkiImportCmd(logger, cfg, st)
+		if err != nil {
+			return fmt.Errorf("main.run anki import command failed. %w", err)
+		}
+	case KindleImport:
+		err := processKindleImportCmd(logger, cfg, st)
+		if err != nil {
+			return fmt.Errorf("main.run kindle import command failed. %w", err)
+		}
+	case Backup:
+		err := processBackupCmd(logger, cfg, st)
+		if err != nil {
+			return fmt.Errorf("main.run backup command failed. %w", err)
+		}
+	case Restore:
+		err := processRestoreCmd(cfg, st)
+		if err != nil {
+			return fmt.Errorf("main.run restore command failed. %w", err)
+		}
+	case MigrateUp:
+		err := processMigrateUpCmd(logger, st)
+		if err != nil {
+			return fmt.Errorf("main.run migrate up command failed. %w", err)
+		}
+	case MigrateDown:
+		err := processMigrateDownCmd(logger, st)
+		if err != nil {
+			return fmt.Errorf("main.run migrate down command failed. %w", err)
+		}
+	case MigrateStatus:
+		err := processMigrateStatusCmd(cfg, st)
+		if err != nil {
+			return fmt.Errorf("main.run migrate status command failed. %w", err)
+		}
+	case TUI:
+		err := processTUICmd(cfg, st)
+		if err != nil {
+			return fmt.Errorf("main.run tui command failed. %w", err)
+		}
+	}
+
+	return nil
+}
+
+func processCreateUserCmd(logger *slog.Logger, cfg Config, st storage) error {
+	createUser := createuser.UseCase{
+		UsersService: users.NewService(st.users),
+	}
+
+	nativeLang, err := models.LanguageFromText(cfg.NativeLanguage)
+	if err != nil {
+		return fmt.Errorf("main.processCreateUserCmd invalid native language received. %w", err)
+	}
+
+	targetLangs, err := models.LanguagesFromText(cfg.TargetLanguages)
+	if err != nil {
+		return fmt.Errorf("main.processCreateUserCmd invalid target languages received. %w", err)
+	}
+
+	exerciseTypes, err := models.ExerciseTypesFromText(cfg.ExerciseTypes)
+	if err != nil {
+		return fmt.Errorf("main.processCreateUserCmd invalid exercise types received. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.CLI.CommandTimeout)
+	defer cancel()
+
+	usr, err := createUser.Run(ctx, models.User{
+		DisplayName:     cfg.DisplayName,
+		NativeLanguage:  nativeLang,
+		TargetLanguages: targetLangs,
+		Timezone:        cfg.Timezone,
+		DailyGoal:       cfg.DailyGoal,
+		ExerciseTypes:   exerciseTypes,
+		SessionSize:     cfg.SessionSize,
+	})
+	if err != nil {
+		return fmt.Errorf("main.processCreateUserCmd unable to create user. %w", err)
+	}
+
+	logger.InfoContext(ctx, "CreateUser: User created", userAttrs(usr)...)
+	return nil
+}
+
+func processGetUserCmd(logger *slog.Logger, cfg Config, st storage) error {
+	getUser := getuser.UseCase{
+		UsersService: users.NewService(st.users),
+	}
+
+	userId, err := models.UserIDFromText(cfg.UserID)
+	if err != nil {
+		return fmt.Errorf("main.processGetUserCmd invalid user id received. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.CLI.CommandTimeout)
+	defer cancel()
+
+	usr, err := getUser.Run(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("main.processGetUserCmd unable to get user. %w", err)
+	}
+
+	logger.InfoContext(ctx, "GetUser: user", userAttrs(usr)...)
+	return nil
+}
+
+func processUpdateUserCmd(logger *slog.Logger, cfg Config, st storage) error {
+	updateUser := updateuser.UseCase{
+		UsersService: users.NewService(st.users),
+	}
+
+	userId, err := models.UserIDFromText(cfg.UserID)
+	if err != nil {
+		return fmt.Errorf("main.processUpdateUserCmd invalid user id received. %w", err)
+	}
+
+	patch, err := userPatchFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("main.processUpdateUserCmd invalid patch received. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.CLI.CommandTimeout)
+	defer cancel()
+
+	usr, err := updateUser.Run(ctx, userId, patch)
+	if err != nil {
+		return fmt.Errorf("main.processUpdateUserCmd unable to update user. %w", err)
+	}
+
+	logger.InfoContext(ctx, "UpdateUser: user updated", userAttrs(usr)...)
+	return nil
+}
+
+func userPatchFromConfig(cfg Config) (users.UserPatch, error) {
+	var patch users.UserPatch
+	if cfg.DisplayName != "" {
+		patch.DisplayName = &cfg.DisplayName
+	}
+	if cfg.NativeLanguage != "" {
+		lang, err := models.LanguageFromText(cfg.NativeLanguage)
+		if err != nil {
+			return patch, fmt.Errorf("main.userPatchFromConfig invalid native language received. %w", err)
+		}
+		patch.NativeLanguage = &lang
+	}
+	if cfg.TargetLanguages != "" {
+		langs, err := models.LanguagesFromText(cfg.TargetLanguages)
+		if err != nil {
+			return patch, fmt.Errorf("main.userPatchFromConfig invalid target languages received. %w", err)
+		}
+		patch.TargetLanguages = langs
+	}
+	if cfg.Timezone != "" {
+		patch.Timezone = &cfg.Timezone
+	}
+	if cfg.DailyGoal >= 0 {
+		patch.DailyGoal = &cfg.DailyGoal
+	}
+	if cfg.ExerciseTypes != "" {
+		types, err := models.ExerciseTypesFromText(cfg.ExerciseTypes)
+		if err != nil {
+			return patch, fmt.Errorf("main.userPatchFromConfig invalid exercise types received. %w", err)
+		}
+		patch.ExerciseTypes = types
+	}
+	if cfg.SessionSize >= 0 {
+		patch.SessionSize = &cfg.SessionSize
+	}
+	return patch, nil
+}
+
+func userAttrs(u models.User) []any {
+	targetLangs := make([]string, len(u.TargetLanguages))
+	for i, l := range u.TargetLanguages {
+		targetLangs[i] = l.String()
+	}
+	exerciseTypes := make([]string, len(u.ExerciseTypes))
+	for i, t := range u.ExerciseTypes {
+		exerciseTypes[i] = t.String()
+	}
+
+	return []any{
+		slog.String("user_id", u.ID.String()),
+		slog.String("display_name", u.DisplayName),
+		slog.String("native_language", u.NativeLanguage.String()),
+		slog.String("target_languages", strings.Join(targetLangs, ",")),
+		slog.String("timezone", u.Timezone),
+		slog.Int("daily_goal", u.DailyGoal),
+		slog.String("exercise_types", strings.Join(exerciseTypes, ",")),
+		slog.Int("session_size", u.SessionSize),
+	}
+}
+
+func processAddWordCmd(logger *slog.Logger, cfg Config, st storage) error {
+	aiGenerator, err := newSentencesGenerator(logger, cfg, st.db)
+	if err != nil {
+		return fmt.Errorf("main.processAddWordCmd unable to create sentences generator. %w", err)
+	}
+
+	addWord := addword.UseCase{
+		VocabularyService: vocabulary.NewService(st.vocabulary, aiGenerator, cfg.Exercise.Sentences.DefaultCount),
+		UsersService:      users.NewService(st.users),
+	}
+
+	userId, err := models.UserIDFromText(cfg.UserID)
+	if err != nil {
+		return fmt.Errorf("main.processAddWordCmd invalid user id received. %w", err)
+	}
+
+	var lang models.Language
+	if cfg.Language != "" {
+		lang, err = models.LanguageFromText(cfg.Language)
+		if err != nil {
+			return fmt.Errorf("main.processAddWordCmd invalid lang received. %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.CLI.CommandTimeout)
+	defer cancel()
+
+	word, err := addWord.Run(ctx, userId, cfg.Spelling, cfg.Definition, cfg.LexicalCategory, lang)
+	if err != nil {
+		return fmt.Errorf("main.processAddWordCmd unable to add word. %w", err)
+	}
+
+	logger.InfoContext(ctx, "AddWord: word added", slog.String("word_id", word.ID.String()))
+	return nil
+}
+
+func processListWordsCmd(logger *slog.Logger, cfg Config, st storage) error {
+	listWords := listwords.UseCase{
+		VocabularyService: vocabulary.NewService(st.vocabulary, nil, cfg.Exercise.Sentences.DefaultCount),
+	}
+
+	userId, err := models.UserIDFromText(cfg.UserID)
+	if err != nil {
+		return fmt.Errorf("main.processListWordsCmd invalid user id received. %w", err)
+	}
+
+	filter, err := listFilterFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("main.processListWordsCmd invalid filter received. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.CLI.CommandTimeout)
+	defer cancel()
+
+	page, err := listWords.Run(ctx, userId, filter)
+	if err != nil {
+		return fmt.Errorf("main.processListWordsCmd unable to list words. %w", err)
+	}
+
+	for _, w := range page.Words {
+		logger.InfoContext(ctx, "ListWords: word",
+			slog.String("word_id", w.ID.String()),
+			slog.String("spelling", w.Spelling),
+			slog.String("definition", w.Definition),
+			slog.String("lexical_category", w.LexicalCategory),
+			slog.String("language", w.Language.String()),
+			slog.String("learn_status", w.LearnStatus.String()),
+			slog.Int("exercises", len(w.Exercises)),
+			slog.Bool("archived", w.Archived),
+			slog.String("tags", strings.Join(w.Tags, ",")),
+			slog.String("source", w.Source),
+		)
+	}
+	logger.InfoContext(ctx, "ListWords: words listed", slog.Int("count", len(page.Words)), slog.String("next_cursor", page.NextCursor))
+	return nil
+}
+
+func listFilterFromConfig(cfg Config) (vocabulary.ListFilter, error) {
+	filter := vocabulary.ListFilter{
+		LexicalCategory: cfg.LexicalCategory,
+		SpellingPrefix:  cfg.SpellingPrefix,
+		IncludeArchived: cfg.IncludeArchived,
+		Cursor:          cfg.Cursor,
+		Limit:           cfg.Limit,
+	}
+
+	if cfg.Language != "" {
+		lang, err := models.LanguageFromText(cfg.Language)
+		if err != nil {
+			return filter, fmt.Errorf("main.listFilterFromConfig invalid lang received. %w", err)
+		}
+		filter.Language = lang
+	}
+
+	if cfg.LearnStatus != "" {
+		status, err := models.LearnStatusFromText(cfg.LearnStatus)
+		if err != nil {
+			return filter, fmt.Errorf("main.listFilterFromConfig invalid learn status received. %w", err)
+		}
+		filter.LearnStatus = &status
+	}
+
+	sort, err := vocabulary.SortOrderFromText(cfg.Sort)
+	if err != nil {
+		return filter, fmt.Errorf("main.listFilterFromConfig invalid sort order received. %w", err)
+	}
+	filter.Sort = sort
+
+	return filter, nil
+}
+
+func processEditWordCmd(logger *slog.Logger, cfg Config, st storage) error {
+	var generator vocabulary.SentencesGenerator
+	if cfg.RegenerateExercises {
+		aiGenerator, err := newSentencesGenerator(logger, cfg, st.db)
+		if err != nil {
+			return fmt.Errorf("main.processEditWordCmd unable to create sentences generator. %w", err)
+		}
+		generator = aiGenerator
+	}
+
+	editWord := editword.UseCase{
+		VocabularyService: vocabulary.NewService(st.vocabulary, generator, cfg.Exercise.Sentences.DefaultCount),
+	}
+
+	userId, err := models.UserIDFromText(cfg.UserID)
+	if err != nil {
+		return fmt.Errorf("main.processEditWordCmd invalid user id received. %w", err)
+	}
+
+	wordId, err := models.WordIDFromText(cfg.WordID)
+	if err != nil {
+		return fmt.Errorf("main.processEditWordCmd invalid word id received. %w", err)
+	}
+
+	var patch vocabulary.WordPatch
+	if cfg.Spelling != "" {
+		patch.Spelling = &cfg.Spelling
+	}
+	if cfg.Definition != "" {
+		patch.Definition = &cfg.Definition
+	}
+	if cfg.LexicalCategory != "" {
+		patch.LexicalCategory = &cfg.LexicalCategory
+	}
+	if cfg.Language != "" {
+		lang, err := models.LanguageFromText(cfg.Language)
+		if err != nil {
+			return fmt.Errorf("main.processEditWordCmd invalid lang received. %w", err)
+		}
+		patch.Language = &lang
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.CLI.CommandTimeout)
+	defer cancel()
+
+	word, err := editWord.Run(ctx, userId, wordId, patch, cfg.RegenerateExercises)
+	if err != nil {
+		return fmt.Errorf("main.processEditWordCmd unable to update word. %w", err)
+	}
+
+	logger.InfoContext(ctx, "EditWord: word updated", slog.String("word_id", word.ID.String()), slog.Int("exercises", len(word.Exercises)))
+	return nil
+}
+
+func processDeleteWordCmd(logger *slog.Logger, cfg Config, st storage) error {
+	deleteWord := deleteword.UseCase{
+		VocabularyService: vocabulary.NewService(st.vocabulary, nil, cfg.Exercise.Sentences.DefaultCount),
+	}
+
+	userId, err := models.UserIDFromText(cfg.UserID)
+	if err != nil {
+		return fmt.Errorf("main.processDeleteWordCmd invalid user id received. %w", err)
+	}
+
+	wordId, err := models.WordIDFromText(cfg.WordID)
+	if err != nil {
+		return fmt.Errorf("main.processDeleteWordCmd invalid word id received. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.CLI.CommandTimeout)
+	defer cancel()
+
+	err = deleteWord.Run(ctx, userId, wordId, cfg.Archive)
+	if err != nil {
+		return fmt.Errorf("main.processDeleteWordCmd unable to delete word. %w", err)
+	}
+
+	logger.InfoContext(ctx, "DeleteWord: word deleted", slog.String("word_id", wordId.String()), slog.Bool("archived", cfg.Archive))
+	return nil
+}
+
+func processReviewWordCmd(logger *slog.Logger, cfg Config, st storage) error {
+	scheduler, err := scheduling.New(cfg.Scheduling.Algorithm)
+	if err != nil {
+		return fmt.Errorf("main.processReviewWordCmd unable to create scheduler. %w", err)
+	}
+
+	reviewWord := reviewword.UseCase{
+		SchedulingService: scheduling.NewService(vocabulary.NewService(st.vocabulary, nil, cfg.Exercise.Sentences.DefaultCount), scheduler),
+	}
+
+	userId, err := models.UserIDFromText(cfg.UserID)
+	if err != nil {
+		return fmt.Errorf("main.processReviewWordCmd invalid user id received. %w", err)
+	}
+
+	wordId, err := models.WordIDFromText(cfg.WordID)
+	if err != nil {
+		return fmt.Errorf("main.processReviewWordCmd invalid word id received. %w", err)
+	}
+
+	grade, err := scheduling.GradeFromText(cfg.Grade)
+	if err != nil {
+		return fmt.Errorf("main.processReviewWordCmd invalid grade received. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.CLI.CommandTimeout)
+	defer cancel()
+
+	word, err := reviewWord.Run(ctx, userId, wordId, grade)
+	if err != nil {
+		return fmt.Errorf("main.processReviewWordCmd unable to review word. %w", err)
+	}
+
+	logger.InfoContext(ctx, "ReviewWord: word reviewed",
+		slog.String("word_id", word.ID.String()),
+		slog.String("learn_status", word.LearnStatus.String()),
+		slog.Time("due", word.Schedule.Due),
+	)
+	return nil
+}
+
+func processPracticeCmd(logger *slog.Logger, cfg Config, st storage) error {
+	scheduler, err := scheduling.New(cfg.Scheduling.Algorithm)
+	if err != nil {
+		return fmt.Errorf("main.processPracticeCmd unable to create scheduler. %w", err)
+	}
+
+	vocabularyService := vocabulary.NewService(st.vocabulary, nil, cfg.Exercise.Sentences.DefaultCount)
+	drillSession := drill.UseCase{
+		PracticeService: practice.NewService(vocabularyService, scheduler),
+		UsersService:    users.NewService(st.users),
+		In:              os.Stdin,
+		Out:             os.Stdout,
+	}
+
+	userId, err := models.UserIDFromText(cfg.UserID)
+	if err != nil {
+		return fmt.Errorf("main.processPracticeCmd invalid user id received. %w", err)
+	}
+
+	var lang models.Language
+	if cfg.Language != "" {
+		lang, err = models.LanguageFromText(cfg.Language)
+		if err != nil {
+			return fmt.Errorf("main.processPracticeCmd invalid lang received. %w", err)
+		}
+	}
+
+	var exerciseType *practice.ExerciseType
+	if cfg.ExerciseType != "" {
+		t, err := practice.ExerciseTypeFromText(cfg.ExerciseType)
+		if err != nil {
+			return fmt.Errorf("main.processPracticeCmd invalid exercise type received. %w", err)
+		}
+		exerciseType = &t
+	}
+
+	ctx, cancel := interactiveContext()
+	defer cancel()
+
+	summary, err := drillSession.Run(ctx, userId, lang, cfg.SessionSize, exerciseType)
+	if err != nil {
+		return fmt.Errorf("main.processPracticeCmd unable to run practice session. %w", err)
+	}
+
+	logger.InfoContext(ctx, "Practice: session finished",
+		slog.Int("answered", summary.Total),
+		slog.Int("correct", summary.Correct),
+		slog.Int("wrong", summary.Wrong),
+		slog.Int("learned", summary.Learned),
+	)
+	return nil
+}
+
+func processImportCmd(logger *slog.Logger, cfg Config, st storage) error {
+	aiGenerator, err := newSentencesGenerator(logger, cfg, st.db)
+	if err != nil {
+		return fmt.Errorf("main.processImportCmd unable to create sentences generator. %w", err)
+	}
+
+	importWords := importwords.UseCase{
+		VocabularyService: vocabulary.NewService(st.vocabulary, aiGenerator, cfg.Exercise.Sentences.DefaultCount),
+		UsersService:      users.NewService(st.users),
+		Parallelism:       cfg.Parallelism,
+	}
+
+	userId, err := models.UserIDFromText(cfg.UserID)
+	if err != nil {
+		return fmt.Errorf("main.processImportCmd invalid user id received. %w", err)
+	}
+
+	format := importwords.FormatFromFileName(cfg.File)
+	if cfg.Format != "" {
+		format, err = importwords.FormatFromText(cfg.Format)
+		if err != nil {
+			return fmt.Errorf("main.processImportCmd invalid format received. %w", err)
+		}
+	}
+
+	in := io.Reader(os.Stdin)
+	rejectsFile := cfg.RejectsFile
+	if cfg.File != "" && cfg.File != "-" {
+		f, err := os.Open(cfg.File)
+		if err != nil {
+			return fmt.Errorf("main.processImportCmd unable to open file. %w", err)
+		}
+		defer f.Close()
+		in = f
+	}
+	if rejectsFile == "" {
+		base := "import"
+		if cfg.File != "" && cfg.File != "-" {
+			base = strings.TrimSuffix(cfg.File, filepath.Ext(cfg.File))
+		}
+		rejectsFile = base + ".rejects." + format.String()
+	}
+
+	ctx, cancel := interactiveContext()
+	defer cancel()
+
+	reports, err := importWords.Run(ctx, userId, in, format)
+	if err != nil {
+		return fmt.Errorf("main.processImportCmd unable to import words. %w", err)
 	}
 
-	db, err := initializeMongoDB(cfg)
+	if err := importwords.PrintReport(os.Stdout, reports); err != nil {
+		return fmt.Errorf("main.processImportCmd unable to print report. %w", err)
+	}
+
+	var rejects bytes.Buffer
+	n, err := importwords.WriteRejects(&rejects, reports, format)
 	if err != nil {
-		return fmt.Errorf("main.run unable to establish mongo database connection. %w", err)
+		return fmt.Errorf("main.processImportCmd unable to write rejects. %w", err)
 	}
-	defer func() {
-		if err := db.Client().Disconnect(context.Background()); err != nil {
-			logger.Error("main.run failed to disconnect MongoDB client", slog.String("err", err.Error()))
+	if n == 0 {
+		return nil
+	}
+	//nolint:mnd
+	if err := os.WriteFile(rejectsFile, rejects.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("main.processImportCmd unable to write rejects file. %w", err)
+	}
+	logger.InfoContext(ctx, "Import: rejected rows written", slog.Int("rows", n), slog.String("file", rejectsFile))
+	return nil
+}
+
+func processAnkiExportCmd(logger *slog.Logger, cfg Config, st storage) error {
+	exportAnki := exportanki.UseCase{
+		VocabularyService: vocabulary.NewService(st.vocabulary, nil, cfg.Exercise.Sentences.DefaultCount),
+	}
+
+	userId, err := models.UserIDFromText(cfg.UserID)
+	if err != nil {
+		return fmt.Errorf("main.processAnkiExportCmd invalid user id received. %w", err)
+	}
+
+	var lang models.Language
+	if cfg.Language != "" {
+		lang, err = models.LanguageFromText(cfg.Language)
+		if err != nil {
+			return fmt.Errorf("main.processAnkiExportCmd invalid lang received. %w", err)
 		}
-	}()
+	}
 
-	switch cfg.Subcommand {
-	case CreateUser:
-		err := processCreateUserCmd(logger, cfg, db)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.CLI.CommandTimeout)
+	defer cancel()
+
+	var pkg bytes.Buffer
+	n, err := exportAnki.Run(ctx, userId, lang, cfg.Deck, &pkg, time.Now())
+	if err != nil {
+		return fmt.Errorf("main.processAnkiExportCmd unable to export words. %w", err)
+	}
+
+	if cfg.File == "-" {
+		if _, err := os.Stdout.Write(pkg.Bytes()); err != nil {
+			return fmt.Errorf("main.processAnkiExportCmd unable to write package. %w", err)
+		}
+		return nil
+	}
+	//nolint:mnd
+	if err := os.WriteFile(cfg.File, pkg.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("main.processAnkiExportCmd unable to write package file. %w", err)
+	}
+	logger.InfoContext(ctx, "AnkiExport: words exported", slog.Int("words", n), slog.String("file", cfg.File))
+	return nil
+}
+
+func processAnkiImportCmd(logger *slog.Logger, cfg Config, st storage) error {
+	aiGenerator, err := newSentencesGenerator(logger, cfg, st.db)
+	if err != nil {
+		return fmt.Errorf("main.processAnkiImportCmd unable to create sentences generator. %w", err)
+	}
+
+	importAnki := importanki.UseCase{
+		VocabularyService: vocabulary.NewService(st.vocabulary, aiGenerator, cfg.Exercise.Sentences.DefaultCount),
+		UsersService:      users.NewService(st.users),
+	}
+
+	userId, err := models.UserIDFromText(cfg.UserID)
+	if err != nil {
+		return fmt.Errorf("main.processAnkiImportCmd invalid user id received. %w", err)
+	}
+
+	var lang models.Language
+	if cfg.Language != "" {
+		lang, err = models.LanguageFromText(cfg.Language)
 		if err != nil {
-			return fmt.Errorf("main.run create user command failed. %w", err)
+			return fmt.Errorf("main.processAnkiImportCmd invalid lang received. %w", err)
 		}
-	case AddWord:
-		err := processAddWordCmd(logger, cfg, db)
+	}
+
+	f, err := os.Open(cfg.File)
+	if err != nil {
+		return fmt.Errorf("main.processAnkiImportCmd unable to open file. %w", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("main.processAnkiImportCmd unable to stat file. %w", err)
+	}
+
+	ctx, cancel := interactiveContext()
+	defer cancel()
+
+	reports, err := importAnki.Run(ctx, userId, f, info.Size(), lang)
+	if err != nil {
+		return fmt.Errorf("main.processAnkiImportCmd unable to import package. %w", err)
+	}
+
+	if err := importanki.PrintReport(os.Stdout, reports); err != nil {
+		return fmt.Errorf("main.processAnkiImportCmd unable to print report. %w", err)
+	}
+	return nil
+}
+
+func processKindleImportCmd(logger *slog.Logger, cfg Config, st storage) error {
+	aiGenerator, err := newSentencesGenerator(logger, cfg, st.db)
+	if err != nil {
+		return fmt.Errorf("main.processKindleImportCmd unable to create sentences generator. %w", err)
+	}
+
+	importKindle := importkindle.UseCase{
+		VocabularyService: vocabulary.NewService(st.vocabulary, aiGenerator, cfg.Exercise.Sentences.DefaultCount),
+		UsersService:      users.NewService(st.users),
+	}
+
+	userId, err := models.UserIDFromText(cfg.UserID)
+	if err != nil {
+		return fmt.Errorf("main.processKindleImportCmd invalid user id received. %w", err)
+	}
+
+	var lang models.Language
+	if cfg.Language != "" {
+		lang, err = models.LanguageFromText(cfg.Language)
 		if err != nil {
-			return fmt.Errorf("main.run add word command failed. %w", err)
+			return fmt.Errorf("main.processKindleImportCmd invalid lang received. %w", err)
 		}
 	}
 
+	ctx, cancel := interactiveContext()
+	defer cancel()
+
+	reports, err := importKindle.Run(ctx, userId, cfg.File, lang)
+	if err != nil {
+		return fmt.Errorf("main.processKindleImportCmd unable to import lookups. %w", err)
+	}
+
+	if err := importkindle.PrintReport(os.Stdout, reports); err != nil {
+		return fmt.Errorf("main.processKindleImportCmd unable to print report. %w", err)
+	}
 	return nil
 }
 
-func processCreateUserCmd(logger *slog.Logger, cfg Config, db *mongo.Database) error {
-	createUser := createuser.UseCase{
-		UsersService: users.NewService(users.NewMongoRepository(db)),
+func processTUICmd(cfg Config, st storage) error {
+	scheduler, err := scheduling.New(cfg.Scheduling.Algorithm)
+	if err != nil {
+		return fmt.Errorf("main.processTUICmd unable to create scheduler. %w", err)
+	}
+
+	vocabularyService := vocabulary.NewService(st.vocabulary, nil, cfg.Exercise.Sentences.DefaultCount)
+	app := tui.App{
+		VocabularyService: vocabularyService,
+		PracticeService:   practice.NewService(vocabularyService, scheduler),
+		UsersService:      users.NewService(st.users),
+		In:                os.Stdin,
+		Out:               os.Stdout,
+	}
+
+	userId, err := models.UserIDFromText(cfg.UserID)
+	if err != nil {
+		return fmt.Errorf("main.processTUICmd invalid user id received. %w", err)
+	}
+
+	var lang models.Language
+	if cfg.Language != "" {
+		lang, err = models.LanguageFromText(cfg.Language)
+		if err != nil {
+			return fmt.Errorf("main.processTUICmd invalid lang received. %w", err)
+		}
+	}
+
+	ctx, cancel := interactiveContext()
+	defer cancel()
+
+	if err := app.Run(ctx, userId, lang); err != nil {
+		return fmt.Errorf("main.processTUICmd. %w", err)
+	}
+	return nil
+}
+
+func processCreateAPIKeyCmd(logger *slog.Logger, cfg Config, st storage) error {
+	createAPIKey := createapikey.UseCase{
+		UsersService: users.NewService(st.users),
+	}
+
+	userId, err := models.UserIDFromText(cfg.UserID)
+	if err != nil {
+		return fmt.Errorf("main.processCreateAPIKeyCmd invalid user id received. %w", err)
+	}
+
+	scope, err := users.ScopeFromText(cfg.KeyScope)
+	if err != nil {
+		return fmt.Errorf("main.processCreateAPIKeyCmd invalid scope received. %w", err)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.CLI.CommandTimeout)
 	defer cancel()
 
-	usr, err := createUser.Run(ctx)
+	key, token, err := createAPIKey.Run(ctx, userId, cfg.KeyName, scope)
 	if err != nil {
-		return fmt.Errorf("main.processCreateUserCmd unable to create user. %w", err)
+		return fmt.Errorf("main.processCreateAPIKeyCmd unable to create API key. %w", err)
 	}
 
-	logger.InfoContext(ctx, "CreateUser: User created", slog.String("user_id", usr.ID.String()))
+	// the token is printed rather than logged, so it doesn't end up in collected logs
+	if _, err := fmt.Fprintln(os.Stdout, token); err != nil {
+		return fmt.Errorf("main.processCreateAPIKeyCmd unable to print token. %w", err)
+	}
+	logger.InfoContext(ctx, "CreateAPIKey: API key created, the token is shown only once",
+		slog.String("key_id", key.ID),
+		slog.String("scope", key.Scope.String()),
+	)
 	return nil
 }
 
-func processAddWordCmd(logger *slog.Logger, cfg Config, db *mongo.Database) error {
-	promptProvider, err := sentences.NewAIPromptProvider()
+func processRevokeAPIKeyCmd(logger *slog.Logger, cfg Config, st storage) error {
+	revokeAPIKey := revokeapikey.UseCase{
+		UsersService: users.NewService(st.users),
+	}
+
+	userId, err := models.UserIDFromText(cfg.UserID)
+	if err != nil {
+		return fmt.Errorf("main.processRevokeAPIKeyCmd invalid user id received. %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.CLI.CommandTimeout)
+	defer cancel()
+
+	if err := revokeAPIKey.Run(ctx, userId, cfg.KeyID); err != nil {
+		return fmt.Errorf("main.processRevokeAPIKeyCmd unable to revoke API key. %w", err)
+	}
+
+	logger.InfoContext(ctx, "RevokeAPIKey: API key revoked", slog.String("key_id", cfg.KeyID))
+	return nil
+}
+
+func processServeCmd(logger *slog.Logger, cfg Config, st storage) error {
+	aiGenerator, err := newSentencesGenerator(logger, cfg, st.db)
 	if err != nil {
-		return fmt.Errorf("main.processAddWordCmd unable to create prompt provider. %w", err)
+		return fmt.Errorf("main.processServeCmd unable to create sentences generator. %w", err)
 	}
 
-	aiGenerator, err := sentences.NewAIGenerator(cfg.ChatGPT.APIToken, promptProvider)
+	scheduler, err := scheduling.New(cfg.Scheduling.Algorithm)
 	if err != nil {
-		return fmt.Errorf("main.processAddWordCmd unable to create AI generator. %w", err)
+		return fmt.Errorf("main.processServeCmd unable to create scheduler. %w", err)
 	}
 
-	addWord := addword.UseCase{
-		VocabularyService: vocabulary.NewService(vocabulary.NewMongoRepository(db), aiGenerator, cfg.Exercise.Sentences.DefaultCount),
+	usersService := users.NewService(st.users)
+	vocabularyService := vocabulary.NewService(st.vocabulary, aiGenerator, cfg.Exercise.Sentences.DefaultCount)
+	practiceService := practice.NewService(vocabularyService, scheduler)
+
+	handler := httpapi.Handler{
+		CreateUser:    createuser.UseCase{UsersService: usersService},
+		GetUser:       getuser.UseCase{UsersService: usersService},
+		AddWord:       addword.UseCase{VocabularyService: vocabularyService, UsersService: usersService},
+		ListWords:     listwords.UseCase{VocabularyService: vocabularyService},
+		StartPractice: startpractice.UseCase{PracticeService: practiceService, UsersService: usersService},
+		Answer:        answerexercise.UseCase{PracticeService: practiceService},
+		Stats:         stats.UseCase{VocabularyService: vocabularyService},
+		CreateSession: createsession.UseCase{UsersService: usersService, TTL: cfg.Auth.SessionTTL},
+		Auth:          usersService,
+		Logger:        logger,
 	}
 
-	userId, err := models.UserIDFromText(cfg.UserID)
+	grpcServer := grpcapi.NewGRPCServer(grpcapi.Server{
+		UseCases: grpcapi.UseCases{
+			CreateUser:        handler.CreateUser,
+			GetUser:           handler.GetUser,
+			AddWord:           handler.AddWord,
+			GetWord:           getword.UseCase{VocabularyService: vocabularyService},
+			ListWords:         handler.ListWords,
+			EditWord:          editword.UseCase{VocabularyService: vocabularyService},
+			DeleteWord:        deleteword.UseCase{VocabularyService: vocabularyService},
+			GenerateExercises: generateexercises.UseCase{VocabularyService: vocabularyService},
+			StartPractice:     handler.StartPractice,
+			Answer:            handler.Answer,
+		},
+		Auth:   usersService,
+		Logger: logger,
+	}, cfg.Server.RequestTimeout)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	httpListener, err := net.Listen("tcp", cfg.Server.HTTP.Addr)
 	if err != nil {
-		return fmt.Errorf("main.processAddWordCmd invalid user id received. %w", err)
+		return fmt.Errorf("main.processServeCmd unable to listen on %s. %w", cfg.Server.HTTP.Addr, err)
+	}
+
+	var grpcListener net.Listener
+	if cfg.Server.GRPC.Addr != "" {
+		grpcListener, err = net.Listen("tcp", cfg.Server.GRPC.Addr)
+		if err != nil {
+			httpListener.Close()
+			return fmt.Errorf("main.processServeCmd unable to listen on %s. %w", cfg.Server.GRPC.Addr, err)
+		}
+	}
+
+	// when one of the servers fails the other one is stopped too
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		logger.InfoContext(ctx, "Serve: HTTP API listening", slog.String("addr", httpListener.Addr().String()))
+		err := httpapi.Serve(ctx, httpListener, httpapi.WithRequestTimeout(handler.Routes(), cfg.Server.RequestTimeout), cfg.Server.ShutdownTimeout)
+		if err != nil {
+			return fmt.Errorf("main.processServeCmd unable to serve HTTP API. %w", err)
+		}
+		logger.InfoContext(ctx, "Serve: HTTP API stopped")
+		return nil
+	})
+	if cfg.Telegram.Token != "" {
+		bot := &telegram.Bot{
+			Client:               telegram.NewClient(cfg.Telegram.BaseURL, cfg.Telegram.Token),
+			Chats:                st.chats,
+			Auth:                 usersService,
+			Users:                usersService,
+			AddWord:              handler.AddWord,
+			StartPractice:        handler.StartPractice,
+			Answer:               handler.Answer,
+			Stats:                handler.Stats,
+			ReminderHour:         cfg.Telegram.ReminderHour,
+			PollTimeout:          cfg.Telegram.PollTimeout,
+			UpdateTimeout:        cfg.Telegram.UpdateTimeout,
+			MaxConcurrentUpdates: cfg.Telegram.MaxConcurrentUpdates,
+			Logger:               logger,
+		}
+		g.Go(func() error {
+			logger.InfoContext(ctx, "Serve: Telegram bot polling")
+			return bot.Run(ctx)
+		})
+		g.Go(func() error {
+			return bot.RunReminders(ctx, cfg.Telegram.ReminderInterval)
+		})
 	}
+	if grpcListener != nil {
+		g.Go(func() error {
+			logger.InfoContext(ctx, "Serve: gRPC API listening", slog.String("addr", grpcListener.Addr().String()))
+			err := grpcapi.Serve(ctx, grpcListener, grpcServer, cfg.Server.ShutdownTimeout)
+			if err != nil {
+				return fmt.Errorf("main.processServeCmd unable to serve gRPC API. %w", err)
+			}
+			logger.InfoContext(ctx, "Serve: gRPC API stopped")
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
 
-	lang, err := models.LanguageFromText(cfg.Language)
+func processCachePurgeCmd(logger *slog.Logger, cfg Config, st storage) error {
+	store, err := newCacheStore(cfg, st.db)
 	if err != nil {
-		return fmt.Errorf("main.processAddWordCmd invalid lang received. %w", err)
+		return fmt.Errorf("main.processCachePurgeCmd unable to create cache store. %w", err)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.CLI.CommandTimeout)
 	defer cancel()
 
-	word, err := addWord.Run(ctx, userId, cfg.Spelling, cfg.Definition, cfg.LexicalCategory, lang)
+	purged, err := store.Purge(ctx, cfg.ExpiredOnly, time.Now())
 	if err != nil {
-		return fmt.Errorf("main.processAddWordCmd unable to add word. %w", err)
+		return fmt.Errorf("main.processCachePurgeCmd unable to purge cache. %w", err)
 	}
 
-	logger.InfoContext(ctx, "AddWord: word added", slog.String("word_id", word.ID.String()))
+	logger.InfoContext(ctx, "CachePurge: cache purged", slog.Int("purged", purged), slog.Bool("expired_only", cfg.ExpiredOnly))
 	return nil
 }
 
+func newSentencesGenerator(logger *slog.Logger, cfg Config, db *mongo.Database) (vocabulary.SentencesGenerator, error) {
+	promptProvider, err := sentences.NewAIPromptProvider()
+	if err != nil {
+		return nil, fmt.Errorf("main.newSentencesGenerator unable to create prompt provider. %w", err)
+	}
+
+	provider, err := sentences.NewProvider(cfg.AI.Provider, sentences.ProviderConfig{
+		Model:       cfg.AI.Model,
+		BaseURL:     cfg.AI.BaseURL,
+		APIToken:    cfg.AI.APIToken,
+		Temperature: cfg.AI.Temperature,
+		MaxTokens:   cfg.AI.MaxTokens,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("main.newSentencesGenerator unable to create AI provider. %w", err)
+	}
+
+	aiGenerator, err := sentences.NewAIGenerator(provider, promptProvider)
+	if err != nil {
+		return nil, fmt.Errorf("main.newSentencesGenerator unable to create AI generator. %w", err)
+	}
+
+	resilientGenerator := sentences.NewResilientGenerator(aiGenerator, sentences.RetryPolicy{
+		MaxRetries: cfg.AI.Retry.MaxRetries,
+		BaseDelay:  cfg.AI.Retry.BaseDelay,
+		MaxDelay:   cfg.AI.Retry.MaxDelay,
+	}, sentences.NewCircuitBreaker(cfg.AI.Breaker.Threshold, cfg.AI.Breaker.Cooldown))
+
+	validatingGenerator, err := sentences.NewValidatingGenerator(resilientGenerator, sentences.NewValidator(cfg.Exercise.Sentences.MaxLength), cfg.Exercise.Sentences.MaxAttempts)
+	if err != nil {
+		return nil, fmt.Errorf("main.newSentencesGenerator unable to create validating generator. %w", err)
+	}
+
+	// the mongo cache is kept next to the data, so it's left out when the data isn't in MongoDB
+	if cfg.Cache.Type == sentences.CacheNone || (cfg.Cache.Type == sentences.CacheMongo && db == nil) {
+		return validatingGenerator, nil
+	}
+
+	cacheStore, err := newCacheStore(cfg, db)
+	if err != nil {
+		return nil, fmt.Errorf("main.newSentencesGenerator unable to create cache store. %w", err)
+	}
+	cachingGenerator := sentences.NewCachingGenerator(validatingGenerator, cacheStore, promptProvider.Version(), cfg.Cache.TTL)
+	cachingGenerator.Logger = logger
+	return cachingGenerator, nil
+}
+
+func newCacheStore(cfg Config, db *mongo.Database) (sentences.CacheStore, error) {
+	switch cfg.Cache.Type {
+	case sentences.CacheMongo:
+		if db == nil {
+			return nil, fmt.Errorf("main.newCacheStore cache type %s needs %s storage", cfg.Cache.Type, StorageMongo)
+		}
+		store := sentences.NewMongoCacheStore(db)
+
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.CLI.CommandTimeout)
+		defer cancel()
+
+		if err := store.EnsureIndexes(ctx); err != nil {
+			return nil, fmt.Errorf("main.newCacheStore unable to ensure cache indexes. %w", err)
+		}
+		return store, nil
+	case sentences.CacheFile:
+		store, err := sentences.NewFileCacheStore(cfg.Cache.Dir)
+		if err != nil {
+			return nil, fmt.Errorf("main.newCacheStore unable to create file cache store. %w", err)
+		}
+		return store, nil
+	default:
+		return nil, fmt.Errorf("main.newCacheStore unknown cache type %s", cfg.Cache.Type)
+	}
+}
+
+// interactiveContext is for commands which run as long as the user needs, for ex: a practice
+// session, or as long as their input takes, for ex: an import generating exercises for every
+// word. Such commands aren't bounded by the command timeout, the user stops them with Ctrl+C.
+func interactiveContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt)
+}
+
 func initializeMongoDB(cfg Config) (*mongo.Database, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.Mongo.ConnectTimeout)
 	defer cancel()
@@ -130,3 +1150,159 @@ func initializeLogger(w io.Writer, cfg Config) (*slog.Logger, error) {
 	}
 	return slog.New(h), nil
 }
+
+func processBackupCmd(logger *slog.Logger, cfg Config, st storage) error {
+	backupUser := backupuser.UseCase{
+		UsersService:      users.NewService(st.users),
+		VocabularyService: vocabulary.NewService(st.vocabulary, nil, cfg.Exercise.Sentences.DefaultCount),
+	}
+
+	userId, err := models.UserIDFromText(cfg.UserID)
+	if err != nil {
+		return fmt.Errorf("main.processBackupCmd invalid user id received. %w", err)
+	}
+
+	format := backup.FormatFromFileName(cfg.File)
+	if cfg.Format != "" {
+		format, err = backup.FormatFromText(cfg.Format)
+		if err != nil {
+			return fmt.Errorf("main.processBackupCmd invalid format received. %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.CLI.CommandTimeout)
+	defer cancel()
+
+	var archive bytes.Buffer
+	n, err := backupUser.Run(ctx, userId, &archive, format, time.Now())
+	if err != nil {
+		return fmt.Errorf("main.processBackupCmd unable to back up user. %w", err)
+	}
+
+	if cfg.File == "" || cfg.File == "-" {
+		if _, err := os.Stdout.Write(archive.Bytes()); err != nil {
+			return fmt.Errorf("main.processBackupCmd unable to write archive. %w", err)
+		}
+		return nil
+	}
+	//nolint:mnd
+	if err := os.WriteFile(cfg.File, archive.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("main.processBackupCmd unable to write archive file. %w", err)
+	}
+	logger.InfoContext(ctx, "Backup: user backed up", slog.Int("words", n), slog.String("file", cfg.File))
+	return nil
+}
+
+func processRestoreCmd(cfg Config, st storage) error {
+	restoreUser := restoreuser.UseCase{
+		UsersService:      users.NewService(st.users),
+		VocabularyService: vocabulary.NewService(st.vocabulary, nil, cfg.Exercise.Sentences.DefaultCount),
+	}
+
+	var userId models.UserID
+	if cfg.UserID != "" {
+		var err error
+		userId, err = models.UserIDFromText(cfg.UserID)
+		if err != nil {
+			return fmt.Errorf("main.processRestoreCmd invalid user id received. %w", err)
+		}
+	}
+
+	format := backup.FormatFromFileName(cfg.File)
+	if cfg.Format != "" {
+		var err error
+		format, err = backup.FormatFromText(cfg.Format)
+		if err != nil {
+			return fmt.Errorf("main.processRestoreCmd invalid format received. %w", err)
+		}
+	}
+
+	in := io.Reader(os.Stdin)
+	if cfg.File != "" && cfg.File != "-" {
+		f, err := os.Open(cfg.File)
+		if err != nil {
+			return fmt.Errorf("main.processRestoreCmd unable to open file. %w", err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	ctx, cancel := interactiveContext()
+	defer cancel()
+
+	report, err := restoreUser.Run(ctx, in, format, userId, cfg.DryRun)
+	if err != nil {
+		return fmt.Errorf("main.processRestoreCmd unable to restore archive. %w", err)
+	}
+
+	if err := restoreuser.PrintReport(os.Stdout, report); err != nil {
+		return fmt.Errorf("main.processRestoreCmd unable to print report. %w", err)
+	}
+	return nil
+}
+
+// errMigrateNeedsMongo is returned by migrate commands for storages other than MongoDB, SQL
+// databases are migrated when they are opened.
+var errMigrateNeedsMongo = errors.New("migrations need mongo storage, sqlite and postgres are migrated on start")
+
+func processMigrateUpCmd(logger *slog.Logger, st storage) error {
+	if st.db == nil {
+		return fmt.Errorf("main.processMigrateUpCmd. %w", errMigrateNeedsMongo)
+	}
+
+	ctx, cancel := interactiveContext()
+	defer cancel()
+
+	version, err := mongodb.Up(ctx, st.db)
+	if err != nil {
+		return fmt.Errorf("main.processMigrateUpCmd unable to migrate. %w", err)
+	}
+	if err := vocabulary.NewMongoRepository(st.db).EnsureIndexes(ctx); err != nil {
+		return fmt.Errorf("main.processMigrateUpCmd unable to ensure vocabulary indexes. %w", err)
+	}
+
+	logger.InfoContext(ctx, "MigrateUp: database migrated", slog.Int("version", version))
+	return nil
+}
+
+func processMigrateDownCmd(logger *slog.Logger, st storage) error {
+	if st.db == nil {
+		return fmt.Errorf("main.processMigrateDownCmd. %w", errMigrateNeedsMongo)
+	}
+
+	ctx, cancel := interactiveContext()
+	defer cancel()
+
+	version, err := mongodb.Down(ctx, st.db)
+	if err != nil {
+		return fmt.Errorf("main.processMigrateDownCmd unable to revert migration. %w", err)
+	}
+
+	logger.InfoContext(ctx, "MigrateDown: migration reverted", slog.Int("version", version))
+	return nil
+}
+
+func processMigrateStatusCmd(cfg Config, st storage) error {
+	if st.db == nil {
+		return fmt.Errorf("main.processMigrateStatusCmd. %w", errMigrateNeedsMongo)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.CLI.CommandTimeout)
+	defer cancel()
+
+	statuses, err := mongodb.Status(ctx, st.db)
+	if err != nil {
+		return fmt.Errorf("main.processMigrateStatusCmd unable to get migrations. %w", err)
+	}
+
+	for _, s := range statuses {
+		applied := "pending"
+		if !s.AppliedAt.IsZero() {
+			applied = "applied " + s.AppliedAt.Format(time.RFC3339)
+		}
+		if _, err := fmt.Fprintf(os.Stdout, "%04d_%s\t%s\n", s.Version, s.Name, applied); err != nil {
+			return fmt.Errorf("main.processMigrateStatusCmd unable to print status. %w", err)
+		}
+	}
+	return nil
+}
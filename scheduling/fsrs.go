@@ -0,0 +1,108 @@
+package scheduling
+
+import (
+	"math"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+)
+
+// fsrsDefaultWeights are FSRS-4.5 default model parameters.
+var fsrsDefaultWeights = [17]float64{
+	0.4072, 1.1829, 3.1262, 15.4722, 7.2102, 0.5316, 1.0651, 0.0234, 1.616,
+	0.1544, 1.0824, 1.9813, 0.0953, 0.2975, 2.2042, 0.2407, 2.9466,
+}
+
+const (
+	fsrsDecay            = -0.5
+	fsrsFactor           = 19.0 / 81.0
+	fsrsRequestRetention = 0.9
+	fsrsMinDifficulty    = 1
+	fsrsMaxDifficulty    = 10
+	fsrsMaxIntervalDays  = 36500
+)
+
+// FSRS implements the Free Spaced Repetition Scheduler (v4.5) without short-term learning steps.
+type FSRS struct {
+	w                [17]float64
+	requestRetention float64
+}
+
+func NewFSRS() FSRS {
+	return FSRS{
+		w:                fsrsDefaultWeights,
+		requestRetention: fsrsRequestRetention,
+	}
+}
+
+func (f FSRS) Schedule(s models.Schedule, g Grade, now time.Time) models.Schedule {
+	rating := float64(g + 1)
+
+	if s.Stability == 0 {
+		s.Stability = f.w[g]
+		s.Difficulty = f.initialDifficulty(rating)
+	} else {
+		r := f.retrievability(daysBetween(s.LastReview, now), s.Stability)
+		d := s.Difficulty
+		s.Difficulty = f.nextDifficulty(d, rating)
+		if g == Again {
+			s.Stability = f.forgetStability(d, s.Stability, r)
+		} else {
+			s.Stability = f.recallStability(d, s.Stability, r, g)
+		}
+	}
+
+	if g == Again {
+		if s.Repetitions > 0 {
+			s.Lapses++
+		}
+		s.Repetitions = 0
+	} else {
+		s.Repetitions++
+	}
+
+	s.Interval = f.interval(s.Stability)
+	s.LastReview = now
+	s.Due = now.Add(s.Interval)
+	return s
+}
+
+func (f FSRS) retrievability(elapsedDays, stability float64) float64 {
+	return math.Pow(1+fsrsFactor*elapsedDays/stability, fsrsDecay)
+}
+
+func (f FSRS) interval(stability float64) time.Duration {
+	days := stability / fsrsFactor * (math.Pow(f.requestRetention, 1/fsrsDecay) - 1)
+	days = math.Min(math.Max(math.Round(days), 1), fsrsMaxIntervalDays)
+	return time.Duration(days) * day
+}
+
+func (f FSRS) initialDifficulty(rating float64) float64 {
+	return clampDifficulty(f.w[4] - (rating-3)*f.w[5])
+}
+
+func (f FSRS) nextDifficulty(d, rating float64) float64 {
+	next := d - f.w[6]*(rating-3)
+	// mean reversion towards the initial difficulty of an easy answer
+	return clampDifficulty(f.w[7]*f.initialDifficulty(4) + (1-f.w[7])*next)
+}
+
+func (f FSRS) recallStability(d, s, r float64, g Grade) float64 {
+	modifier := 1.0
+	switch g {
+	case Hard:
+		modifier = f.w[15]
+	case Easy:
+		modifier = f.w[16]
+	}
+	return s * (1 + math.Exp(f.w[8])*(11-d)*math.Pow(s, -f.w[9])*(math.Exp((1-r)*f.w[10])-1)*modifier)
+}
+
+func (f FSRS) forgetStability(d, s, r float64) float64 {
+	next := f.w[11] * math.Pow(d, -f.w[12]) * (math.Pow(s+1, f.w[13]) - 1) * math.Exp((1-r)*f.w[14])
+	return math.Min(next, s)
+}
+
+func clampDifficulty(d float64) float64 {
+	return math.Min(math.Max(d, fsrsMinDifficulty), fsrsMaxDifficulty)
+}
@@ -0,0 +1,47 @@
+package scheduling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+)
+
+func TestFSRS_Schedule(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	fsrs := NewFSRS()
+
+	first := map[Grade]time.Duration{
+		Again: day,
+		Hard:  day,
+		Good:  3 * day,
+		Easy:  15 * day,
+	}
+	for g, expected := range first {
+		s := fsrs.Schedule(models.Schedule{}, g, now)
+		if s.Interval != expected {
+			t.Errorf("unexpected first interval for %s: want %s, got %s", g.String(), expected, s.Interval)
+		}
+		if s.Difficulty < fsrsMinDifficulty || s.Difficulty > fsrsMaxDifficulty {
+			t.Errorf("difficulty %f is out of range", s.Difficulty)
+		}
+	}
+
+	s := fsrs.Schedule(models.Schedule{}, Good, now)
+	prev := s
+	s = fsrs.Schedule(s, Good, s.Due)
+	if s.Stability <= prev.Stability || s.Interval <= prev.Interval {
+		t.Errorf("expected stability and interval to grow after recall, got %+v after %+v", s, prev)
+	}
+
+	prev = s
+	s = fsrs.Schedule(s, Again, s.Due)
+	if s.Stability >= prev.Stability || s.Lapses != 1 || s.Repetitions != 0 {
+		t.Errorf("expected stability to drop after lapse, got %+v after %+v", s, prev)
+	}
+	if s.Difficulty <= prev.Difficulty {
+		t.Errorf("expected difficulty to grow after lapse, got %f after %f", s.Difficulty, prev.Difficulty)
+	}
+}
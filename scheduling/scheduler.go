@@ -0,0 +1,116 @@
+package scheduling
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+)
+
+type Grade int
+
+const (
+	Again Grade = iota
+	Hard
+	Good
+	Easy
+)
+
+func (g *Grade) String() string {
+	txt, err := g.MarshalText()
+	if err != nil {
+		return "unknown"
+	}
+	return txt
+}
+
+func (g *Grade) MarshalText() (string, error) {
+	switch *g {
+	case Again:
+		return "again", nil
+	case Hard:
+		return "hard", nil
+	case Good:
+		return "good", nil
+	case Easy:
+		return "easy", nil
+	default:
+		return "", fmt.Errorf("%d is unknown Grade", *g)
+	}
+}
+
+func (g *Grade) UnmarshalText(text string) error {
+	switch text {
+	case "again":
+		*g = Again
+	case "hard":
+		*g = Hard
+	case "good":
+		*g = Good
+	case "easy":
+		*g = Easy
+	default:
+		return fmt.Errorf("%s is unknown Grade representation", text)
+	}
+	return nil
+}
+
+func GradeFromText(s string) (Grade, error) {
+	var g Grade
+	err := g.UnmarshalText(s)
+	if err != nil {
+		return 0, fmt.Errorf("scheduling.GradeFromText invalid grade string %s. %w", s, err)
+	}
+	return g, nil
+}
+
+type Algorithm string
+
+const (
+	AlgorithmSM2  Algorithm = "sm2"
+	AlgorithmFSRS Algorithm = "fsrs"
+)
+
+// Scheduler computes the next spaced-repetition state of a word after a review.
+type Scheduler interface {
+	Schedule(s models.Schedule, g Grade, now time.Time) models.Schedule
+}
+
+func New(algorithm Algorithm) (Scheduler, error) {
+	switch algorithm {
+	case AlgorithmSM2:
+		return NewSM2(), nil
+	case AlgorithmFSRS:
+		return NewFSRS(), nil
+	default:
+		return nil, fmt.Errorf("scheduling.New unknown algorithm %s", algorithm)
+	}
+}
+
+// LearnedInterval is the review interval starting from which a word counts as learned.
+const LearnedInterval = 21 * 24 * time.Hour
+
+const day = 24 * time.Hour
+
+// NextStatus returns the learn status of a word whose schedule became next after a review graded g.
+// Any review moves a pending word in progress, a lapse moves a learned word back.
+func NextStatus(next models.Schedule, g Grade) models.LearnStatus {
+	if g != Again && next.Interval >= LearnedInterval {
+		return models.Learned
+	}
+	return models.InProgress
+}
+
+// Review applies review outcome to the word's schedule and learn status.
+func Review(s Scheduler, w models.Word, g Grade, now time.Time) models.Word {
+	w.Schedule = s.Schedule(w.Schedule, g, now)
+	w.LearnStatus = NextStatus(w.Schedule, g)
+	return w
+}
+
+func daysBetween(from, to time.Time) float64 {
+	if from.IsZero() || to.Before(from) {
+		return 0
+	}
+	return float64(to.Sub(from)) / float64(day)
+}
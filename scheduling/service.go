@@ -0,0 +1,56 @@
+package scheduling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+)
+
+type Service struct {
+	vocabulary VocabularyService
+	scheduler  Scheduler
+	now        func() time.Time
+}
+
+type VocabularyService interface {
+	GetWord(ctx context.Context, userID models.UserID, wordID models.WordID) (models.Word, error)
+	UpdateSchedule(ctx context.Context, userID models.UserID, wordID models.WordID, lastReview time.Time, status models.LearnStatus, schedule models.Schedule) (models.Word, error)
+}
+
+func NewService(vocabulary VocabularyService, scheduler Scheduler) Service {
+	return Service{
+		vocabulary,
+		scheduler,
+		time.Now,
+	}
+}
+
+// maxReviewAttempts bounds the retries of a review which raced with another one of the word.
+const maxReviewAttempts = 3
+
+// Review schedules the word by the grade. When the word gets reviewed concurrently, for ex: from
+// the HTTP API and the Telegram bot, the review is applied again on top of the other one.
+func (s Service) Review(ctx context.Context, userID models.UserID, wordID models.WordID, g Grade) (models.Word, error) {
+	for attempt := 1; ; attempt++ {
+		word, err := s.vocabulary.GetWord(ctx, userID, wordID)
+		if err != nil {
+			return word, fmt.Errorf("scheduling.Service.Review unable to get word. %w", err)
+		}
+
+		lastReview := word.Schedule.LastReview
+		word = Review(s.scheduler, word, g, s.now())
+
+		word, err = s.vocabulary.UpdateSchedule(ctx, userID, wordID, lastReview, word.LearnStatus, word.Schedule)
+		if errors.Is(err, vocabulary.ErrScheduleChanged) && attempt < maxReviewAttempts {
+			continue
+		}
+		if err != nil {
+			return word, fmt.Errorf("scheduling.Service.Review unable to update schedule. %w", err)
+		}
+		return word, nil
+	}
+}
@@ -0,0 +1,74 @@
+package scheduling
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+)
+
+const testUserID = "000000000000000000000001"
+
+// fakeVocabulary keeps a single word, which is reviewed concurrently at the next of
+// concurrentReviews before each update.
+type fakeVocabulary struct {
+	word              models.Word
+	concurrentReviews []time.Time
+	updates           int
+}
+
+func (f *fakeVocabulary) GetWord(_ context.Context, _ models.UserID, _ models.WordID) (models.Word, error) {
+	return f.word, nil
+}
+
+func (f *fakeVocabulary) UpdateSchedule(_ context.Context, _ models.UserID, _ models.WordID, lastReview time.Time, status models.LearnStatus, schedule models.Schedule) (models.Word, error) {
+	if len(f.concurrentReviews) > 0 {
+		f.word = Review(NewSM2(), f.word, Good, f.concurrentReviews[0])
+		f.concurrentReviews = f.concurrentReviews[1:]
+	}
+	if !f.word.Schedule.LastReview.Equal(lastReview) {
+		return models.Word{}, vocabulary.ErrScheduleChanged
+	}
+	f.updates++
+	f.word.LearnStatus, f.word.Schedule = status, schedule
+	return f.word, nil
+}
+
+func TestService_Review(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	vocab := &fakeVocabulary{
+		word:              models.Word{ID: "66f1a2b3c4d5e6f708091a2b", LearnStatus: models.Pending},
+		concurrentReviews: []time.Time{now.Add(-time.Minute)},
+	}
+	s := Service{vocabulary: vocab, scheduler: NewSM2(), now: func() time.Time { return now }}
+
+	word, err := s.Review(context.Background(), testUserID, vocab.word.ID, Good)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the review is applied on top of the concurrent one instead of overwriting it
+	if word.Schedule.Repetitions != 2 || !word.Schedule.LastReview.Equal(now) || vocab.updates != 1 {
+		t.Errorf("unexpected schedule %+v after %d updates", word.Schedule, vocab.updates)
+	}
+}
+
+func TestService_Review_Conflict(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	vocab := &fakeVocabulary{word: models.Word{ID: "66f1a2b3c4d5e6f708091a2b"}}
+	// the word is reviewed concurrently before every update
+	for i := range maxReviewAttempts {
+		vocab.concurrentReviews = append(vocab.concurrentReviews, now.Add(time.Duration(i)*time.Second))
+	}
+	s := NewService(vocab, NewSM2())
+
+	if _, err := s.Review(context.Background(), testUserID, vocab.word.ID, Good); !errors.Is(err, vocabulary.ErrScheduleChanged) {
+		t.Errorf("expected ErrScheduleChanged, got %v", err)
+	}
+}
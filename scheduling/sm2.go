@@ -0,0 +1,68 @@
+package scheduling
+
+import (
+	"math"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+)
+
+const (
+	sm2InitialEase = 2.5
+	sm2MinEase     = 1.3
+	sm2SecondStep  = 6
+)
+
+// SM2 implements the SuperMemo-2 algorithm.
+type SM2 struct{}
+
+func NewSM2() SM2 {
+	return SM2{}
+}
+
+func (SM2) Schedule(s models.Schedule, g Grade, now time.Time) models.Schedule {
+	if s.Ease == 0 {
+		s.Ease = sm2InitialEase
+	}
+
+	if g == Again {
+		// repetitions start over without touching the ease
+		if s.Repetitions > 0 {
+			s.Lapses++
+		}
+		s.Repetitions = 0
+		s.Interval = day
+	} else {
+		q := sm2Quality(g)
+		//nolint:mnd
+		s.Ease = math.Max(sm2MinEase, s.Ease+(0.1-(5-q)*(0.08+(5-q)*0.02)))
+
+		s.Repetitions++
+		switch s.Repetitions {
+		case 1:
+			s.Interval = day
+		case 2: //nolint:mnd
+			s.Interval = sm2SecondStep * day
+		default:
+			days := math.Round(s.Interval.Hours() / 24 * s.Ease) //nolint:mnd
+			s.Interval = time.Duration(days) * day
+		}
+	}
+
+	s.LastReview = now
+	s.Due = now.Add(s.Interval)
+	return s
+}
+
+// sm2Quality maps successful grade onto the 0-5 SM-2 response quality scale.
+func sm2Quality(g Grade) float64 {
+	//nolint:mnd
+	switch g {
+	case Hard:
+		return 3
+	case Good:
+		return 4
+	default:
+		return 5
+	}
+}
@@ -0,0 +1,69 @@
+package scheduling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+)
+
+func TestSM2_Schedule(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	sm2 := NewSM2()
+
+	s := sm2.Schedule(models.Schedule{}, Good, now)
+	if s.Interval != day || s.Repetitions != 1 || !s.Due.Equal(now.Add(day)) {
+		t.Errorf("unexpected first review schedule %+v", s)
+	}
+	if s.Ease != sm2InitialEase {
+		t.Errorf("unexpected ease %f after good answer", s.Ease)
+	}
+
+	s = sm2.Schedule(s, Good, now)
+	if s.Interval != 6*day {
+		t.Errorf("unexpected second interval %s", s.Interval)
+	}
+
+	s = sm2.Schedule(s, Easy, now)
+	if s.Interval != 16*day || s.Ease != 2.6 {
+		t.Errorf("unexpected third review schedule %+v", s)
+	}
+
+	s = sm2.Schedule(s, Again, now)
+	if s.Interval != day || s.Repetitions != 0 || s.Lapses != 1 || s.Ease != 2.6 {
+		t.Errorf("unexpected schedule after lapse %+v", s)
+	}
+
+	for range 10 {
+		s = sm2.Schedule(s, Hard, now)
+	}
+	if s.Ease != sm2MinEase {
+		t.Errorf("expected ease to be bounded by %f, got %f", sm2MinEase, s.Ease)
+	}
+}
+
+func TestReview(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	w := models.Word{LearnStatus: models.Pending}
+
+	w = Review(NewSM2(), w, Good, now)
+	if w.LearnStatus != models.InProgress {
+		t.Errorf("expected word in progress after first review, got %s", w.LearnStatus.String())
+	}
+
+	for w.LearnStatus != models.Learned {
+		if w.Schedule.Repetitions > 10 {
+			t.Fatalf("word isn't learned after %d reviews", w.Schedule.Repetitions)
+		}
+		w = Review(NewSM2(), w, Good, w.Schedule.Due)
+	}
+
+	w = Review(NewSM2(), w, Again, w.Schedule.Due)
+	if w.LearnStatus != models.InProgress || w.Schedule.Lapses != 1 {
+		t.Errorf("expected lapse to move word back in progress, got %s with %d lapses", w.LearnStatus.String(), w.Schedule.Lapses)
+	}
+}
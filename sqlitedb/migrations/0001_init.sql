@@ -0,0 +1,70 @@
+CREATE TABLE users (
+	id TEXT PRIMARY KEY NOT NULL,
+	display_name TEXT NOT NULL DEFAULT '',
+	native_language TEXT NOT NULL DEFAULT '',
+	-- JSON array of language tags
+	target_languages TEXT NOT NULL DEFAULT '[]',
+	timezone TEXT NOT NULL DEFAULT '',
+	daily_goal INTEGER NOT NULL DEFAULT 0,
+	-- JSON array of exercise types
+	exercise_types TEXT NOT NULL DEFAULT '[]',
+	session_size INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE credentials (
+	user_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	id TEXT NOT NULL,
+	kind TEXT NOT NULL,
+	name TEXT NOT NULL DEFAULT '',
+	scope TEXT NOT NULL,
+	hash TEXT NOT NULL UNIQUE,
+	-- times are unix milliseconds
+	created_at INTEGER NOT NULL,
+	expires_at INTEGER,
+	-- parent_id is the API key a session was issued for, sessions are removed together with it
+	parent_id TEXT NOT NULL DEFAULT '',
+	PRIMARY KEY (user_id, id)
+);
+
+CREATE INDEX credentials_parent ON credentials (user_id, parent_id);
+
+CREATE TABLE words (
+	id TEXT PRIMARY KEY NOT NULL,
+	user_id TEXT NOT NULL,
+	spelling TEXT NOT NULL,
+	-- spelling_key is the lower cased spelling, SQLite's lower() handles ASCII only
+	spelling_key TEXT NOT NULL,
+	definition TEXT NOT NULL DEFAULT '',
+	language TEXT NOT NULL,
+	definition_language TEXT NOT NULL DEFAULT '',
+	learn_status TEXT NOT NULL,
+	lexical_category TEXT NOT NULL DEFAULT '',
+	answered_count INTEGER NOT NULL DEFAULT 0,
+	-- JSON array of exercises
+	exercises TEXT NOT NULL DEFAULT '[]',
+	archived INTEGER NOT NULL DEFAULT 0,
+	-- JSON array of tags
+	tags TEXT NOT NULL DEFAULT '[]',
+	source TEXT NOT NULL DEFAULT '',
+	ease REAL NOT NULL DEFAULT 0,
+	stability REAL NOT NULL DEFAULT 0,
+	difficulty REAL NOT NULL DEFAULT 0,
+	-- interval is in nanoseconds, due and last_review are unix milliseconds
+	interval INTEGER NOT NULL DEFAULT 0,
+	due INTEGER NOT NULL,
+	last_review INTEGER NOT NULL,
+	repetitions INTEGER NOT NULL DEFAULT 0,
+	lapses INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX words_user_spelling ON words (user_id, spelling_key, language);
+CREATE INDEX words_user_due ON words (user_id, due);
+
+CREATE TABLE telegram_chats (
+	chat_id INTEGER PRIMARY KEY NOT NULL,
+	user_id TEXT NOT NULL,
+	-- credential_id is the API key the chat was linked with, the chat is unlinked once it's revoked
+	credential_id TEXT NOT NULL DEFAULT '',
+	linked_at INTEGER NOT NULL,
+	reminded_at INTEGER
+);
@@ -0,0 +1,4 @@
+-- a user has a word once per language, regardless of the spelling's case
+-- checkUniqueSpelling runs first and names the duplicates the index would fail on
+DROP INDEX words_user_spelling;
+CREATE UNIQUE INDEX words_user_spelling ON words (user_id, spelling_key, language);
@@ -0,0 +1,152 @@
+// Package sqlitedb opens the SQLite database vocabforge keeps all its data in for offline
+// use, and migrates its schema. The version of the schema is kept in PRAGMA user_version.
+package sqlitedb
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"strings"
+
+	"github.com/pavelpuchok/vocabforge/sqlmigrations"
+
+	// registers "sqlite" database/sql driver
+	_ "modernc.org/sqlite"
+)
+
+var (
+	ErrUnknownVersion = errors.New("database schema is newer than the known migrations")
+	ErrDuplicateWords = errors.New("words are in the vocabulary more than once")
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// checks run before the migrations of their versions, in the same transaction, and fail on
+// data the migration can't be applied to with an error telling what to fix.
+var checks = map[int]func(ctx context.Context, tx *sql.Tx) error{
+	2: checkUniqueSpelling,
+}
+
+// Migration changes the schema from Version-1 to Version.
+type Migration = sqlmigrations.Migration
+
+// Open opens the database at path, creating the file if there is none, and migrates it
+// to the latest schema.
+func Open(ctx context.Context, path string) (*sql.DB, error) {
+	// transactions take the write lock at once, so concurrent writers wait instead of failing on upgrade
+	dsn := "file:" + path + "?" + url.Values{
+		"_pragma": {"foreign_keys(1)", "journal_mode(WAL)", "busy_timeout(5000)"},
+		"_txlock": {"immediate"},
+	}.Encode()
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("sqlitedb.Open unable to open %s. %w", path, err)
+	}
+
+	if _, err := Migrate(ctx, db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("sqlitedb.Open. %w", err)
+	}
+	return db, nil
+}
+
+// Migrations returns all the migrations ordered by version.
+func Migrations() ([]Migration, error) {
+	dir, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("sqlitedb.Migrations. %w", err)
+	}
+	migrations, err := sqlmigrations.Load(dir)
+	if err != nil {
+		return nil, fmt.Errorf("sqlitedb.Migrations. %w", err)
+	}
+	return migrations, nil
+}
+
+// Migrate applies the migrations the database doesn't have yet, each in a transaction
+// of its own. It returns the version the database is at.
+func Migrate(ctx context.Context, db *sql.DB) (int, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return 0, fmt.Errorf("sqlitedb.Migrate. %w", err)
+	}
+
+	version, err := Version(ctx, db)
+	if err != nil {
+		return 0, fmt.Errorf("sqlitedb.Migrate. %w", err)
+	}
+	if version > len(migrations) {
+		return version, fmt.Errorf("sqlitedb.Migrate version %d. %w", version, ErrUnknownVersion)
+	}
+
+	for _, m := range migrations[version:] {
+		if err := apply(ctx, db, m); err != nil {
+			return version, fmt.Errorf("sqlitedb.Migrate unable to apply %d_%s. %w", m.Version, m.Name, err)
+		}
+		version = m.Version
+	}
+	return version, nil
+}
+
+// Version returns the version of the database's schema, zero for an empty database.
+func Version(ctx context.Context, db *sql.DB) (int, error) {
+	var version int
+	if err := db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		return 0, fmt.Errorf("sqlitedb.Version. %w", err)
+	}
+	return version, nil
+}
+
+func apply(ctx context.Context, db *sql.DB, m Migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if check, ok := checks[m.Version]; ok {
+		if err := check(ctx, tx); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, m.SQL); err != nil {
+		return err
+	}
+	// PRAGMA doesn't take parameters, the version is a number
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", m.Version)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// checkUniqueSpelling fails naming the words a user has more than once in a language, the
+// unique index of 0002_unique_spelling can't be created while they are there.
+func checkUniqueSpelling(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, `SELECT user_id, language, group_concat(spelling, ', ')
+		FROM words GROUP BY user_id, spelling_key, language HAVING COUNT(*) > 1`)
+	if err != nil {
+		return fmt.Errorf("unable to look for duplicate words. %w", err)
+	}
+	defer rows.Close()
+
+	var duplicates []string
+	for rows.Next() {
+		var userID, language, spellings string
+		if err := rows.Scan(&userID, &language, &spellings); err != nil {
+			return fmt.Errorf("unable to scan duplicate words. %w", err)
+		}
+		duplicates = append(duplicates, fmt.Sprintf("%s in %s of user %s", spellings, language, userID))
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("unable to read duplicate words. %w", err)
+	}
+	if len(duplicates) > 0 {
+		return fmt.Errorf("%w, delete all but one of each: %s", ErrDuplicateWords, strings.Join(duplicates, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package sqlitedb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOpen(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "vocabforge.db")
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the second time there is nothing to migrate
+	for range 2 {
+		db, err := Open(ctx, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		version, err := Version(ctx, db)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if version != len(migrations) {
+			t.Errorf("expected version %d, got %d", len(migrations), version)
+		}
+		db.Close()
+	}
+
+	db, err := Open(ctx, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.ExecContext(ctx, "PRAGMA user_version = 1000"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Migrate(ctx, db); !errors.Is(err, ErrUnknownVersion) {
+		t.Errorf("expected ErrUnknownVersion, got %v", err)
+	}
+}
+
+func TestMigrate_DuplicateWords(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "vocabforge.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := apply(ctx, db, migrations[0]); err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.ExecContext(ctx, `INSERT INTO words (id, user_id, spelling, spelling_key, language, learn_status, due, last_review)
+		VALUES ('1', 'u1', 'run', 'run', 'en', 'pending', 0, 0), ('2', 'u1', 'Run', 'run', 'en', 'pending', 0, 0),
+			('3', 'u1', 'run', 'run', 'de', 'pending', 0, 0), ('4', 'u2', 'run', 'run', 'en', 'pending', 0, 0)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Migrate(ctx, db)
+	if !errors.Is(err, ErrDuplicateWords) {
+		t.Fatalf("expected ErrDuplicateWords, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "run, Run in en of user u1") && !strings.Contains(err.Error(), "Run, run in en of user u1") {
+		t.Errorf("expected the duplicates to be named, got %v", err)
+	}
+	if version, err := Version(ctx, db); err != nil || version != 1 {
+		t.Errorf("expected version 1, got %d and %v", version, err)
+	}
+}
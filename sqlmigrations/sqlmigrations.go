@@ -0,0 +1,52 @@
+// Package sqlmigrations loads the SQL migrations sqlitedb and postgresdb embed, so both
+// name and order their migration files the same way.
+package sqlmigrations
+
+import (
+	"fmt"
+	"io/fs"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Migration changes the schema from Version-1 to Version.
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+// Load reads the migrations in the root of fsys ordered by version. Files are named
+// <version>_<name>.sql, for ex: 0001_init.sql, versions start from 1 and have no gaps.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("sqlmigrations.Load unable to list migrations. %w", err)
+	}
+
+	var migrations []Migration
+	for _, e := range entries {
+		version, name, ok := strings.Cut(strings.TrimSuffix(e.Name(), ".sql"), "_")
+		if !ok {
+			return nil, fmt.Errorf("sqlmigrations.Load invalid migration file name %s", e.Name())
+		}
+		v, err := strconv.Atoi(version)
+		if err != nil {
+			return nil, fmt.Errorf("sqlmigrations.Load invalid migration version %s. %w", e.Name(), err)
+		}
+		b, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("sqlmigrations.Load unable to read %s. %w", e.Name(), err)
+		}
+		migrations = append(migrations, Migration{Version: v, Name: name, SQL: string(b)})
+	}
+
+	slices.SortFunc(migrations, func(a, b Migration) int { return a.Version - b.Version })
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			return nil, fmt.Errorf("sqlmigrations.Load migration %d_%s is out of sequence", m.Version, m.Name)
+		}
+	}
+	return migrations, nil
+}
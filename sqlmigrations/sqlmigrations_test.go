@@ -0,0 +1,38 @@
+package sqlmigrations
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	migrations, err := Load(fstest.MapFS{
+		"0002_unique_spelling.sql": {Data: []byte("CREATE UNIQUE INDEX;")},
+		"0001_init.sql":            {Data: []byte("CREATE TABLE;")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Migration{
+		{Version: 1, Name: "init", SQL: "CREATE TABLE;"},
+		{Version: 2, Name: "unique_spelling", SQL: "CREATE UNIQUE INDEX;"},
+	}
+	if diff := cmp.Diff(expected, migrations); diff != "" {
+		t.Errorf("unexpected migrations (-want +got):\n%s", diff)
+	}
+
+	for name, fsys := range map[string]fstest.MapFS{
+		"gap":          {"0001_init.sql": {}, "0003_next.sql": {}},
+		"no name":      {"0001.sql": {}},
+		"no version":   {"init_schema.sql": {}},
+		"not from one": {"0002_init.sql": {}},
+	} {
+		if _, err := Load(fsys); err == nil {
+			t.Errorf("expected an error for %s", name)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/pavelpuchok/vocabforge/mongodb"
+	"github.com/pavelpuchok/vocabforge/postgresdb"
+	"github.com/pavelpuchok/vocabforge/sqlitedb"
+	"github.com/pavelpuchok/vocabforge/telegram"
+	"github.com/pavelpuchok/vocabforge/users"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	StorageMongo    = "mongo"
+	StoragePostgres = "postgres"
+	StorageSQLite   = "sqlite"
+	StorageMemory   = "memory"
+)
+
+// storage holds the repositories commands keep their data in.
+type storage struct {
+	vocabulary vocabulary.Repository
+	users      users.Repository
+	chats      telegram.ChatStore
+	// db is nil unless the data is kept in MongoDB.
+	db    *mongo.Database
+	close func(ctx context.Context) error
+}
+
+// memorySnapshot is the file memory storage is loaded from and saved to.
+type memorySnapshot struct {
+	Users      *users.MemoryRepository      `json:"users"`
+	Vocabulary *vocabulary.MemoryRepository `json:"vocabulary"`
+	Chats      *telegram.MemoryChatStore    `json:"telegramChats"`
+}
+
+func openStorage(cfg Config) (storage, error) {
+	switch cfg.Storage.Type {
+	case StorageMongo:
+		if cfg.Mongo.URI == "" {
+			return storage{}, errors.New("main.openStorage missing MongoDB URI")
+		}
+		db, err := initializeMongoDB(cfg)
+		if err != nil {
+			return storage{}, fmt.Errorf("main.openStorage unable to establish mongo database connection. %w", err)
+		}
+		vocab := vocabulary.NewMongoRepository(db)
+		usersRepo := users.NewMongoRepository(db)
+
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.CLI.CommandTimeout)
+		defer cancel()
+
+		// requests are authenticated by credential hashes, which are unique and looked up by the index
+		if err := usersRepo.EnsureIndexes(ctx); err != nil {
+			_ = db.Client().Disconnect(ctx)
+			return storage{}, fmt.Errorf("main.openStorage unable to ensure users indexes. %w", err)
+		}
+
+		// migrations may have to fix documents the unique index fails on, migrate up creates the indexes after them
+		if !isMigrateCmd(cfg.Subcommand) {
+			if err := checkMongoSchema(ctx, db); err != nil {
+				_ = db.Client().Disconnect(ctx)
+				return storage{}, fmt.Errorf("main.openStorage. %w", err)
+			}
+			if err := vocab.EnsureIndexes(ctx); err != nil {
+				_ = db.Client().Disconnect(ctx)
+				return storage{}, fmt.Errorf("main.openStorage unable to ensure vocabulary indexes. %w", err)
+			}
+		}
+		return storage{
+			vocabulary: vocab,
+			users:      usersRepo,
+			chats:      telegram.NewMongoChatStore(db),
+			db:         db,
+			close:      db.Client().Disconnect,
+		}, nil
+	case StoragePostgres:
+		if cfg.Postgres.URL == "" {
+			return storage{}, errors.New("main.openStorage missing PostgreSQL URL")
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.CLI.CommandTimeout)
+		defer cancel()
+
+		pool, err := postgresdb.Open(ctx, cfg.Postgres.URL, postgresdb.PoolConfig{
+			MaxConns:        cfg.Postgres.MaxConns,
+			MinConns:        cfg.Postgres.MinConns,
+			MaxConnLifetime: cfg.Postgres.MaxConnLifetime,
+			MaxConnIdleTime: cfg.Postgres.MaxConnIdleTime,
+		})
+		if err != nil {
+			return storage{}, fmt.Errorf("main.openStorage. %w", err)
+		}
+		return storage{
+			vocabulary: vocabulary.NewPostgresRepository(pool),
+			users:      users.NewPostgresRepository(pool),
+			chats:      telegram.NewPostgresChatStore(pool),
+			close: func(context.Context) error {
+				pool.Close()
+				return nil
+			},
+		}, nil
+	case StorageSQLite:
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.CLI.CommandTimeout)
+		defer cancel()
+
+		db, err := sqlitedb.Open(ctx, cfg.SQLite.Path)
+		if err != nil {
+			return storage{}, fmt.Errorf("main.openStorage. %w", err)
+		}
+		return storage{
+			vocabulary: vocabulary.NewSQLiteRepository(db),
+			users:      users.NewSQLiteRepository(db),
+			chats:      telegram.NewSQLiteChatStore(db),
+			close: func(context.Context) error {
+				return db.Close()
+			},
+		}, nil
+	case StorageMemory:
+		snapshot, err := loadMemorySnapshot(cfg.Storage.Snapshot)
+		if err != nil {
+			return storage{}, fmt.Errorf("main.openStorage. %w", err)
+		}
+		return storage{
+			vocabulary: snapshot.Vocabulary,
+			users:      snapshot.Users,
+			chats:      snapshot.Chats,
+			close: func(context.Context) error {
+				return saveMemorySnapshot(cfg.Storage.Snapshot, snapshot)
+			},
+		}, nil
+	default:
+		return storage{}, fmt.Errorf("main.openStorage unknown storage type %s", cfg.Storage.Type)
+	}
+}
+
+// errSchemaMismatch is returned for MongoDB databases migrated to another version than the one
+// the repositories read and write.
+var errSchemaMismatch = errors.New("database schema doesn't match, migrate the database or upgrade vocabforge")
+
+// checkMongoSchema refuses a database whose schema is behind or ahead of the repositories'. A database
+// without documents is migrated right away, there is nothing for migrations to go through.
+func checkMongoSchema(ctx context.Context, db *mongo.Database) error {
+	version, err := mongodb.Version(ctx, db)
+	if err != nil {
+		return err
+	}
+	if version == 0 {
+		empty, err := mongodb.Empty(ctx, db)
+		if err != nil {
+			return err
+		}
+		if empty {
+			if version, err = mongodb.Up(ctx, db); err != nil {
+				return err
+			}
+		}
+	}
+	if version != vocabulary.MongoSchemaVersion {
+		return fmt.Errorf("version %d, expected %d. %w", version, vocabulary.MongoSchemaVersion, errSchemaMismatch)
+	}
+	return nil
+}
+
+func isMigrateCmd(s Subcommand) bool {
+	return s == MigrateUp || s == MigrateDown || s == MigrateStatus
+}
+
+// loadMemorySnapshot reads the snapshot at path, it's empty if there is no path or file yet.
+func loadMemorySnapshot(path string) (memorySnapshot, error) {
+	snapshot := memorySnapshot{
+		Users:      users.NewMemoryRepository(),
+		Vocabulary: vocabulary.NewMemoryRepository(),
+		Chats:      telegram.NewMemoryChatStore(),
+	}
+	if path == "" {
+		return snapshot, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return snapshot, nil
+	}
+	if err != nil {
+		return memorySnapshot{}, fmt.Errorf("main.loadMemorySnapshot unable to read %s. %w", path, err)
+	}
+	if err := json.Unmarshal(b, &snapshot); err != nil {
+		return memorySnapshot{}, fmt.Errorf("main.loadMemorySnapshot unable to unmarshal %s. %w", path, err)
+	}
+	return snapshot, nil
+}
+
+// saveMemorySnapshot replaces the file at path with the snapshot, nothing is saved if there is no path.
+func saveMemorySnapshot(path string, snapshot memorySnapshot) error {
+	if path == "" {
+		return nil
+	}
+
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("main.saveMemorySnapshot unable to marshal snapshot. %w", err)
+	}
+
+	// the previous snapshot stays intact if writing fails halfway
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("main.saveMemorySnapshot unable to create temporary file. %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return fmt.Errorf("main.saveMemorySnapshot unable to write snapshot. %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("main.saveMemorySnapshot unable to close temporary file. %w", err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("main.saveMemorySnapshot unable to replace %s. %w", path, err)
+	}
+	return nil
+}
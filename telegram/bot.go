@@ -0,0 +1,348 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/practice"
+	"github.com/pavelpuchok/vocabforge/usecases/addword"
+	"github.com/pavelpuchok/vocabforge/usecases/stats"
+	"github.com/pavelpuchok/vocabforge/users"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+)
+
+const (
+	DefaultPollTimeout          = 30 * time.Second
+	DefaultUpdateTimeout        = time.Minute
+	DefaultMaxConcurrentUpdates = 8
+	// maxPollDelay caps the delay between failed polls.
+	maxPollDelay = time.Minute
+	// replyTimeout bounds sending an error reply.
+	replyTimeout = 10 * time.Second
+)
+
+const helpText = `Commands:
+/start <API key> - link this chat to your account
+/add word | definition - add a word
+/review - review due words
+/stop - stop the review`
+
+// Bot handles updates from Bot API. Every exported field except ReminderHour, UpdateTimeout and
+// MaxConcurrentUpdates has to be set, Bot must not be copied after first use.
+type Bot struct {
+	Client        Client
+	Chats         ChatStore
+	Auth          Authenticator
+	Users         UsersService
+	AddWord       AddWordUseCase
+	StartPractice StartPracticeUseCase
+	Answer        AnswerUseCase
+	Stats         StatsUseCase
+	// ReminderHour is the hour of the user's local day after which the daily reminder is sent.
+	ReminderHour int
+	PollTimeout  time.Duration
+	// UpdateTimeout bounds handling of a single update.
+	UpdateTimeout time.Duration
+	// MaxConcurrentUpdates is the number of chats whose updates are handled at the same time.
+	MaxConcurrentUpdates int
+	Logger               *slog.Logger
+
+	mu      sync.Mutex
+	reviews map[int64]*review
+	// queues holds the updates waiting for the chat's update in progress, a chat is in it while
+	// its updates are handled.
+	queues map[int64][]Update
+}
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (users.Principal, error)
+	// Reauthenticate fails with users.ErrUnauthenticated once the credential is revoked or expires.
+	Reauthenticate(ctx context.Context, userID models.UserID, credentialID string) (users.Principal, error)
+}
+
+type UsersService interface {
+	GetUser(ctx context.Context, id models.UserID) (models.User, error)
+}
+
+type AddWordUseCase interface {
+	Run(ctx context.Context, userID models.UserID, spell, definition, lexicalCategory string, lang models.Language) (models.Word, error)
+}
+
+type StartPracticeUseCase interface {
+	Run(ctx context.Context, userID models.UserID, lang models.Language, size int, t *practice.ExerciseType) (practice.Session, error)
+}
+
+type AnswerUseCase interface {
+	Run(ctx context.Context, userID models.UserID, wordID models.WordID, t practice.ExerciseType, exerciseIndex int, answer string) (practice.Result, error)
+}
+
+type StatsUseCase interface {
+	Run(ctx context.Context, userID models.UserID, lang models.Language) (stats.Stats, error)
+}
+
+// Run long polls for updates until ctx is done. Updates of different chats are handled
+// concurrently, up to MaxConcurrentUpdates at once, updates of a chat are handled in order.
+func (b *Bot) Run(ctx context.Context) error {
+	timeout := b.PollTimeout
+	if timeout <= 0 {
+		timeout = DefaultPollTimeout
+	}
+	workers := b.MaxConcurrentUpdates
+	if workers <= 0 {
+		workers = DefaultMaxConcurrentUpdates
+	}
+	slots := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	var offset int64
+	delay := time.Second
+	for {
+		updates, err := b.Client.GetUpdates(ctx, offset, timeout)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			wait := delay
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+				wait = apiErr.RetryAfter
+			}
+			b.Logger.WarnContext(ctx, "telegram: unable to get updates", slog.String("err", err.Error()), slog.Duration("retry", wait))
+			if err := sleep(ctx, wait); err != nil {
+				return nil
+			}
+			delay = min(delay*2, maxPollDelay)
+			continue
+		}
+		delay = time.Second
+
+		for _, u := range updates {
+			offset = u.UpdateID + 1
+			if err := b.dispatch(ctx, u, slots, &wg); err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+// dispatch queues the update after the chat's update in progress, or handles it once one of
+// the slots is free. It fails only when ctx is done while waiting for a slot.
+func (b *Bot) dispatch(ctx context.Context, u Update, slots chan struct{}, wg *sync.WaitGroup) error {
+	chatID := u.chatID()
+
+	b.mu.Lock()
+	if q, ok := b.queues[chatID]; ok {
+		b.queues[chatID] = append(q, u)
+		b.mu.Unlock()
+		return nil
+	}
+	if b.queues == nil {
+		b.queues = map[int64][]Update{}
+	}
+	b.queues[chatID] = nil
+	b.mu.Unlock()
+
+	select {
+	case slots <- struct{}{}:
+	case <-ctx.Done():
+		b.mu.Lock()
+		delete(b.queues, chatID)
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer func() { <-slots }()
+
+		for {
+			b.handleUpdate(ctx, u)
+
+			b.mu.Lock()
+			q := b.queues[chatID]
+			if len(q) == 0 {
+				delete(b.queues, chatID)
+				b.mu.Unlock()
+				return
+			}
+			u, b.queues[chatID] = q[0], q[1:]
+			b.mu.Unlock()
+		}
+	}()
+	return nil
+}
+
+func (b *Bot) handleUpdate(ctx context.Context, u Update) {
+	timeout := b.UpdateTimeout
+	if timeout <= 0 {
+		timeout = DefaultUpdateTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	switch {
+	case u.Message != nil:
+		b.handleMessage(ctx, *u.Message)
+	case u.CallbackQuery != nil:
+		b.handleCallback(ctx, *u.CallbackQuery)
+	}
+}
+
+func (b *Bot) handleMessage(ctx context.Context, msg Message) {
+	command, args := parseCommand(msg.Text)
+
+	var err error
+	switch command {
+	case "/start":
+		err = b.handleStart(ctx, msg, args)
+	case "/add":
+		err = b.handleAdd(ctx, msg.Chat.ID, args)
+	case "/review":
+		err = b.handleReview(ctx, msg.Chat.ID)
+	case "/stop":
+		err = b.handleStop(ctx, msg.Chat.ID)
+	default:
+		err = b.reply(ctx, msg.Chat.ID, helpText)
+	}
+
+	if err != nil {
+		b.replyError(ctx, msg.Chat.ID, err)
+	}
+}
+
+// handleStart links the chat to the owner of the API key. The message is deleted, so the key doesn't stay in the chat.
+func (b *Bot) handleStart(ctx context.Context, msg Message, key string) error {
+	if key == "" {
+		return b.reply(ctx, msg.Chat.ID, "Send /start <API key> to link this chat to your account.\n\n"+helpText)
+	}
+
+	if err := b.Client.DeleteMessage(ctx, msg.Chat.ID, msg.MessageID); err != nil {
+		b.Logger.WarnContext(ctx, "telegram: unable to delete message with API key", slog.String("err", err.Error()))
+	}
+
+	p, err := b.Auth.Authenticate(ctx, key)
+	if err != nil {
+		return fmt.Errorf("telegram.Bot.handleStart unable to authenticate. %w", err)
+	}
+	if p.Kind != users.APIKey || !p.Scope.Allows(users.ScopePractice) {
+		return b.reply(ctx, msg.Chat.ID, "The key needs the practice scope.")
+	}
+
+	if err := b.Chats.LinkChat(ctx, msg.Chat.ID, p.UserID, p.CredentialID, time.Now()); err != nil {
+		return fmt.Errorf("telegram.Bot.handleStart unable to link chat. %w", err)
+	}
+	return b.reply(ctx, msg.Chat.ID, "The chat is linked to your account.\n\n"+helpText)
+}
+
+func (b *Bot) handleAdd(ctx context.Context, chatID int64, args string) error {
+	spelling, definition, _ := strings.Cut(args, "|")
+	spelling, definition = strings.TrimSpace(spelling), strings.TrimSpace(definition)
+	if spelling == "" || definition == "" {
+		return b.reply(ctx, chatID, "Usage: /add word | definition")
+	}
+
+	userID, err := b.chatUserID(ctx, chatID)
+	if err != nil {
+		return err
+	}
+
+	word, err := b.AddWord.Run(ctx, userID, spelling, definition, "", "")
+	if err != nil {
+		return fmt.Errorf("telegram.Bot.handleAdd unable to add word. %w", err)
+	}
+	return b.reply(ctx, chatID, fmt.Sprintf("Added %q with %d exercises.", word.Spelling, len(word.Exercises)))
+}
+
+// chatUserID returns the user the chat is linked to, as long as the API key it was linked with is valid.
+func (b *Bot) chatUserID(ctx context.Context, chatID int64) (models.UserID, error) {
+	link, err := b.Chats.GetChatLink(ctx, chatID)
+	if err != nil {
+		return "", fmt.Errorf("telegram.Bot.chatUserID. %w", err)
+	}
+	if err := b.checkLink(ctx, link); err != nil {
+		return "", fmt.Errorf("telegram.Bot.chatUserID. %w", err)
+	}
+	return link.UserID, nil
+}
+
+// checkLink unlinks the chat if its API key is revoked or no longer allows practicing.
+func (b *Bot) checkLink(ctx context.Context, link ChatLink) error {
+	p, err := b.Auth.Reauthenticate(ctx, link.UserID, link.CredentialID)
+	if err == nil && !p.Scope.Allows(users.ScopePractice) {
+		err = fmt.Errorf("scope %s. %w", p.Scope.String(), users.ErrUnauthenticated)
+	}
+	if !errors.Is(err, users.ErrUnauthenticated) {
+		return err
+	}
+
+	b.setReview(link.ChatID, nil)
+	if unlinkErr := b.Chats.UnlinkChat(ctx, link.ChatID); unlinkErr != nil {
+		return fmt.Errorf("unable to unlink chat %d. %w", link.ChatID, unlinkErr)
+	}
+	return fmt.Errorf("chat %d unlinked. %w", link.ChatID, err)
+}
+
+func (b *Bot) reply(ctx context.Context, chatID int64, text string) error {
+	if _, err := b.Client.SendMessage(ctx, chatID, text, nil); err != nil {
+		return fmt.Errorf("telegram.Bot.reply. %w", err)
+	}
+	return nil
+}
+
+// replyError explains known errors to the user, the others are logged only.
+func (b *Bot) replyError(ctx context.Context, chatID int64, err error) {
+	var text string
+	switch {
+	case errors.Is(err, ErrChatNotLinked):
+		text = "This chat isn't linked yet. Send /start <API key> first."
+	case errors.Is(err, users.ErrUnauthenticated):
+		text = "The API key is invalid or revoked. Send /start <API key> to link this chat again."
+	case errors.Is(err, addword.ErrMissingLanguage):
+		text = "Set a target language in your profile first."
+	case errors.Is(err, vocabulary.ErrDuplicateWord):
+		text = "The word is in your vocabulary already."
+	case errors.Is(err, context.DeadlineExceeded):
+		b.Logger.WarnContext(ctx, "telegram: update timed out", slog.Int64("chatID", chatID), slog.String("err", err.Error()))
+		text = "It took too long, try again later."
+	default:
+		b.Logger.ErrorContext(ctx, "telegram: update failed", slog.Int64("chatID", chatID), slog.String("err", err.Error()))
+		text = "Something went wrong, try again later."
+	}
+
+	// the update's deadline may have passed already, the reply gets one of its own
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), replyTimeout)
+	defer cancel()
+	if _, err := b.Client.SendMessage(ctx, chatID, text, nil); err != nil {
+		b.Logger.ErrorContext(ctx, "telegram: unable to reply", slog.Int64("chatID", chatID), slog.String("err", err.Error()))
+	}
+}
+
+// parseCommand splits "/cmd@bot args" into "/cmd" and "args".
+func parseCommand(text string) (string, string) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "/") {
+		return "", text
+	}
+	command, args, _ := strings.Cut(text, " ")
+	command, _, _ = strings.Cut(command, "@")
+	return strings.ToLower(command), strings.TrimSpace(args)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,470 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/practice"
+	"github.com/pavelpuchok/vocabforge/practice/cloze"
+	"github.com/pavelpuchok/vocabforge/scheduling"
+	"github.com/pavelpuchok/vocabforge/usecases/stats"
+	"github.com/pavelpuchok/vocabforge/users"
+)
+
+const (
+	testToken  = "123:secret"
+	testUserID = "000000000000000000000001"
+	testChatID = 42
+	testAPIKey = "vfk_practice"
+	testKeyID  = "key"
+)
+
+type apiCall struct {
+	Method string
+	ChatID int64                 `json:"chat_id"` //nolint:tagliatelle
+	Text   string                `json:"text"`
+	Markup *InlineKeyboardMarkup `json:"reply_markup"` //nolint:tagliatelle
+}
+
+// fakeBotAPI is a local stand-in for Bot API recording the calls made.
+type fakeBotAPI struct {
+	t     *testing.T
+	mu    sync.Mutex
+	calls []apiCall
+}
+
+func (f *fakeBotAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	method, ok := strings.CutPrefix(r.URL.Path, "/bot"+testToken+"/")
+	if !ok {
+		f.t.Errorf("unexpected path %s", r.URL.Path)
+	}
+
+	var call apiCall
+	if err := json.NewDecoder(r.Body).Decode(&call); err != nil {
+		f.t.Error(err)
+	}
+	call.Method = method
+
+	f.mu.Lock()
+	f.calls = append(f.calls, call)
+	id := len(f.calls)
+	f.mu.Unlock()
+
+	_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":` + strconv.Itoa(id) + `,"chat":{"id":42}}}`))
+}
+
+// take returns the calls made since the previous take, except answerCallbackQuery and deleteMessage.
+func (f *fakeBotAPI) take() []apiCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var res []apiCall
+	for _, c := range f.calls {
+		if c.Method == "sendMessage" || c.Method == "editMessageText" {
+			res = append(res, c)
+		}
+	}
+	f.calls = nil
+	return res
+}
+
+type fakeChats map[int64]ChatLink
+
+func (f fakeChats) LinkChat(_ context.Context, chatID int64, userID models.UserID, credentialID string, at time.Time) error {
+	f[chatID] = ChatLink{ChatID: chatID, UserID: userID, CredentialID: credentialID, LinkedAt: at}
+	return nil
+}
+
+func (f fakeChats) UnlinkChat(_ context.Context, chatID int64) error {
+	delete(f, chatID)
+	return nil
+}
+
+func (f fakeChats) GetChatLink(_ context.Context, chatID int64) (ChatLink, error) {
+	link, ok := f[chatID]
+	if !ok {
+		return ChatLink{}, ErrChatNotLinked
+	}
+	return link, nil
+}
+
+func (f fakeChats) ListChatLinks(context.Context) ([]ChatLink, error) {
+	var res []ChatLink
+	for _, link := range f {
+		res = append(res, link)
+	}
+	return res, nil
+}
+
+func (f fakeChats) SetReminded(_ context.Context, chatID int64, at time.Time) error {
+	link := f[chatID]
+	link.RemindedAt = at
+	f[chatID] = link
+	return nil
+}
+
+type fakeAuth struct {
+	revoked bool
+}
+
+func (a fakeAuth) Authenticate(_ context.Context, token string) (users.Principal, error) {
+	if token != testAPIKey || a.revoked {
+		return users.Principal{}, users.ErrUnauthenticated
+	}
+	return users.Principal{UserID: testUserID, Scope: users.ScopePractice, Kind: users.APIKey, CredentialID: testKeyID}, nil
+}
+
+func (a fakeAuth) Reauthenticate(_ context.Context, userID models.UserID, credentialID string) (users.Principal, error) {
+	if userID != testUserID || credentialID != testKeyID || a.revoked {
+		return users.Principal{}, users.ErrUnauthenticated
+	}
+	return users.Principal{UserID: testUserID, Scope: users.ScopePractice, Kind: users.APIKey, CredentialID: testKeyID}, nil
+}
+
+type fakeUsers struct {
+	timezone string
+}
+
+func (f fakeUsers) GetUser(_ context.Context, id models.UserID) (models.User, error) {
+	return models.User{ID: id, Timezone: f.timezone}, nil
+}
+
+type fakeAddWord struct {
+	spelling, definition string
+}
+
+func (f *fakeAddWord) Run(_ context.Context, userID models.UserID, spell, definition, _ string, _ models.Language) (models.Word, error) {
+	f.spelling, f.definition = spell, definition
+	return models.Word{UserID: userID, Spelling: spell, Exercises: make([]models.SentenceExercise, 3)}, nil
+}
+
+type fakeStartPractice struct {
+	items []practice.Item
+}
+
+func (f fakeStartPractice) Run(_ context.Context, userID models.UserID, _ models.Language, _ int, t *practice.ExerciseType) (practice.Session, error) {
+	if t == nil || *t != practice.Cloze {
+		return practice.Session{}, nil
+	}
+	return practice.Session{UserID: userID, Items: f.items}, nil
+}
+
+type fakeAnswer struct {
+	answers []string
+}
+
+func (f *fakeAnswer) Run(_ context.Context, _ models.UserID, wordID models.WordID, _ practice.ExerciseType, _ int, answer string) (practice.Result, error) {
+	f.answers = append(f.answers, answer)
+	expected := map[models.WordID]string{"w1": "ran", "w2": "went"}[wordID]
+	grade := practice.Check(expected, answer)
+	return practice.Result{Correct: grade != scheduling.Again, Grade: grade, Expected: expected}, nil
+}
+
+type fakeStats struct {
+	due int
+}
+
+func (f fakeStats) Run(context.Context, models.UserID, models.Language) (stats.Stats, error) {
+	return stats.Stats{Due: f.due}, nil
+}
+
+func newTestBot(t *testing.T, chats fakeChats) (*Bot, *fakeBotAPI) {
+	t.Helper()
+
+	api := &fakeBotAPI{t: t}
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+
+	mustParse := func(s string) cloze.Cloze {
+		c, err := cloze.Parse(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	return &Bot{
+		Client:  NewClient(srv.URL, testToken),
+		Chats:   chats,
+		Auth:    fakeAuth{},
+		Users:   fakeUsers{timezone: "Europe/Berlin"},
+		AddWord: &fakeAddWord{},
+		StartPractice: fakeStartPractice{items: []practice.Item{
+			{Type: practice.Cloze, Word: models.Word{ID: "w1"}, Cloze: mustParse("She <%ran%> home.")},
+			{Type: practice.Cloze, Word: models.Word{ID: "w2"}, Cloze: mustParse("We <%went%> out.")},
+		}},
+		Answer:       &fakeAnswer{},
+		Stats:        fakeStats{due: 5},
+		ReminderHour: 9,
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}, api
+}
+
+func message(text string) Update {
+	return Update{Message: &Message{MessageID: 1, Chat: Chat{ID: testChatID}, Text: text}}
+}
+
+func TestBot_LinkAndAdd(t *testing.T) {
+	t.Parallel()
+
+	chats := fakeChats{}
+	bot, api := newTestBot(t, chats)
+	ctx := context.Background()
+
+	bot.handleUpdate(ctx, message("/add run | move fast"))
+	if calls := api.take(); len(calls) != 1 || !strings.Contains(calls[0].Text, "isn't linked") {
+		t.Errorf("unexpected calls %+v", calls)
+	}
+
+	bot.handleUpdate(ctx, message("/start vfk_wrong"))
+	if calls := api.take(); len(calls) != 1 || !strings.Contains(calls[0].Text, "invalid") {
+		t.Errorf("unexpected calls %+v", calls)
+	}
+
+	bot.handleUpdate(ctx, message("/start "+testAPIKey))
+	if chats[testChatID].UserID != testUserID || chats[testChatID].CredentialID != testKeyID {
+		t.Fatalf("chat is not linked: %+v", chats)
+	}
+	api.take()
+
+	bot.handleUpdate(ctx, message("/add@vocabforge_bot run | move fast"))
+	addWord := bot.AddWord.(*fakeAddWord)
+	if addWord.spelling != "run" || addWord.definition != "move fast" {
+		t.Errorf("unexpected word %+v", addWord)
+	}
+	if calls := api.take(); len(calls) != 1 || calls[0].Text != `Added "run" with 3 exercises.` {
+		t.Errorf("unexpected calls %+v", calls)
+	}
+
+	bot.handleUpdate(ctx, message("/add run"))
+	if calls := api.take(); len(calls) != 1 || !strings.HasPrefix(calls[0].Text, "Usage") {
+		t.Errorf("unexpected calls %+v", calls)
+	}
+}
+
+func TestBot_Review(t *testing.T) {
+	t.Parallel()
+
+	bot, api := newTestBot(t, fakeChats{testChatID: {ChatID: testChatID, UserID: testUserID, CredentialID: testKeyID}})
+	ctx := context.Background()
+
+	bot.handleUpdate(ctx, message("/review"))
+	calls := api.take()
+	if len(calls) != 1 || calls[0].Text != "[1/2] She _____ home." || calls[0].Markup == nil {
+		t.Fatalf("unexpected calls %+v", calls)
+	}
+	var correct string
+	for _, row := range calls[0].Markup.InlineKeyboard {
+		if row[0].Text == "ran" {
+			correct = row[0].CallbackData
+		}
+	}
+	if correct == "" || len(calls[0].Markup.InlineKeyboard) != 3 {
+		t.Fatalf("unexpected keyboard %+v", calls[0].Markup)
+	}
+
+	press := func(data string) {
+		bot.handleUpdate(ctx, Update{CallbackQuery: &CallbackQuery{ID: "q", Message: &Message{Chat: Chat{ID: testChatID}}, Data: data}})
+	}
+
+	press(correct)
+	calls = api.take()
+	if len(calls) != 2 || !strings.Contains(calls[0].Text, "Correct") || calls[1].Text != "[2/2] We _____ out." {
+		t.Fatalf("unexpected calls %+v", calls)
+	}
+
+	// a second press on the answered exercise is ignored
+	press(correct)
+	if calls := api.take(); len(calls) != 0 {
+		t.Errorf("unexpected calls %+v", calls)
+	}
+
+	press(answerData(1, dontKnowOption))
+	calls = api.take()
+	if len(calls) != 2 || !strings.Contains(calls[0].Text, "The answer is went") || calls[1].Text != "Review finished: 1 of 2 correct." {
+		t.Errorf("unexpected calls %+v", calls)
+	}
+
+	answers := bot.Answer.(*fakeAnswer).answers
+	if len(answers) != 2 || answers[0] != "ran" || answers[1] != "" {
+		t.Errorf("unexpected answers %q", answers)
+	}
+	if bot.getReview(testChatID) != nil {
+		t.Error("review is not finished")
+	}
+}
+
+func TestBot_SendReminders(t *testing.T) {
+	t.Parallel()
+
+	chats := fakeChats{testChatID: {ChatID: testChatID, UserID: testUserID, CredentialID: testKeyID}}
+	bot, api := newTestBot(t, chats)
+	ctx := context.Background()
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name  string
+		now   time.Time
+		sends int
+	}{
+		{"before reminder hour", time.Date(2024, 5, 1, 8, 59, 0, 0, berlin), 0},
+		{"after reminder hour", time.Date(2024, 5, 1, 9, 0, 0, 0, berlin), 1},
+		{"same day", time.Date(2024, 5, 1, 23, 0, 0, 0, berlin), 0},
+		{"next day", time.Date(2024, 5, 2, 10, 0, 0, 0, berlin), 1},
+	}
+	for _, c := range cases {
+		if err := bot.SendReminders(ctx, c.now); err != nil {
+			t.Fatal(err)
+		}
+		calls := api.take()
+		if len(calls) != c.sends {
+			t.Errorf("%s: expected %d reminders, got %+v", c.name, c.sends, calls)
+		}
+		if c.sends > 0 && !strings.HasPrefix(calls[0].Text, "5 words are due") {
+			t.Errorf("%s: unexpected reminder %q", c.name, calls[0].Text)
+		}
+	}
+}
+
+func TestBot_RevokedKey(t *testing.T) {
+	t.Parallel()
+
+	chats := fakeChats{testChatID: {ChatID: testChatID, UserID: testUserID, CredentialID: testKeyID}}
+	bot, api := newTestBot(t, chats)
+	bot.Auth = fakeAuth{revoked: true}
+	ctx := context.Background()
+
+	if err := bot.SendReminders(ctx, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	if calls := api.take(); len(calls) != 0 {
+		t.Errorf("expected no reminder for revoked key, got %+v", calls)
+	}
+	if _, ok := chats[testChatID]; ok {
+		t.Fatal("expected chat of revoked key to be unlinked")
+	}
+
+	// chats linked before the key was recorded have to be linked again
+	bot.Auth = fakeAuth{}
+	chats[testChatID] = ChatLink{ChatID: testChatID, UserID: testUserID}
+	bot.handleUpdate(ctx, message("/add run | move fast"))
+	if calls := api.take(); len(calls) != 1 || !strings.Contains(calls[0].Text, "revoked") {
+		t.Errorf("unexpected calls %+v", calls)
+	}
+	if _, ok := chats[testChatID]; ok {
+		t.Error("expected chat linked without a key to be unlinked")
+	}
+	if addWord := bot.AddWord.(*fakeAddWord); addWord.spelling != "" {
+		t.Errorf("expected no word to be added, got %+v", addWord)
+	}
+}
+
+// slowAddWord adds "slow" once released or its context is done, other words right away.
+type slowAddWord struct {
+	started chan string
+	release chan struct{}
+}
+
+func (f slowAddWord) Run(ctx context.Context, userID models.UserID, spell, _, _ string, _ models.Language) (models.Word, error) {
+	f.started <- spell
+	if spell == "slow" {
+		select {
+		case <-f.release:
+		case <-ctx.Done():
+			return models.Word{}, ctx.Err()
+		}
+	}
+	return models.Word{UserID: userID, Spelling: spell}, nil
+}
+
+func TestBot_Dispatch(t *testing.T) {
+	t.Parallel()
+
+	const otherChatID = 43
+	bot, _ := newTestBot(t, fakeChats{
+		testChatID:  {ChatID: testChatID, UserID: testUserID, CredentialID: testKeyID},
+		otherChatID: {ChatID: otherChatID, UserID: testUserID, CredentialID: testKeyID},
+	})
+	addWord := slowAddWord{started: make(chan string, 3), release: make(chan struct{})}
+	bot.AddWord = addWord
+	ctx := context.Background()
+
+	slots := make(chan struct{}, 2)
+	var wg sync.WaitGroup
+	other := message("/add fast | d")
+	other.Message.Chat.ID = otherChatID
+	for _, u := range []Update{message("/add slow | d"), message("/add second | d"), other} {
+		if err := bot.dispatch(ctx, u, slots, &wg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the other chat isn't held up by the slow update, the slow chat's next update waits for it
+	started := map[string]bool{<-addWord.started: true, <-addWord.started: true}
+	if !started["slow"] || !started["fast"] {
+		t.Fatalf("unexpected updates started %v", started)
+	}
+	select {
+	case spell := <-addWord.started:
+		t.Fatalf("expected %q to wait for the slow update", spell)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(addWord.release)
+	wg.Wait()
+	if spell := <-addWord.started; spell != "second" {
+		t.Errorf("expected second update to be handled last, got %q", spell)
+	}
+	if len(bot.queues) != 0 {
+		t.Errorf("expected no queued updates, got %v", bot.queues)
+	}
+}
+
+func TestBot_UpdateTimeout(t *testing.T) {
+	t.Parallel()
+
+	bot, api := newTestBot(t, fakeChats{testChatID: {ChatID: testChatID, UserID: testUserID, CredentialID: testKeyID}})
+	bot.AddWord = slowAddWord{started: make(chan string, 1), release: make(chan struct{})}
+	bot.UpdateTimeout = 10 * time.Millisecond
+
+	bot.handleUpdate(context.Background(), message("/add slow | d"))
+	if calls := api.take(); len(calls) != 1 || !strings.Contains(calls[0].Text, "too long") {
+		t.Errorf("unexpected calls %+v", calls)
+	}
+}
+
+func TestClient_APIError(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"ok":false,"error_code":429,"description":"Too Many Requests","parameters":{"retry_after":7}}`))
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL, testToken).SendMessage(context.Background(), testChatID, "hi", nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusTooManyRequests || apiErr.RetryAfter != 7*time.Second {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	srv.Close()
+	_, err = NewClient(srv.URL, testToken).SendMessage(context.Background(), testChatID, "hi", nil)
+	if err == nil || strings.Contains(err.Error(), testToken) {
+		t.Errorf("expected error without the token, got %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+)
+
+var ErrChatNotLinked = errors.New("chat is not linked to a user")
+
+// ChatLink maps a Telegram chat to the user who linked it with an API key.
+type ChatLink struct {
+	ChatID int64
+	UserID models.UserID
+	// CredentialID is the ID of the API key the chat was linked with, the chat is unlinked once
+	// the key is revoked. It's empty for chats linked before it was recorded.
+	CredentialID string
+	LinkedAt     time.Time
+	// RemindedAt is the time the last daily reminder was sent, zero if none was.
+	RemindedAt time.Time
+}
+
+type ChatStore interface {
+	// LinkChat maps the chat to the user, replacing a previous link of the chat.
+	LinkChat(ctx context.Context, chatID int64, userID models.UserID, credentialID string, at time.Time) error
+	// UnlinkChat removes the link of the chat, if there is one.
+	UnlinkChat(ctx context.Context, chatID int64) error
+	GetChatLink(ctx context.Context, chatID int64) (ChatLink, error)
+	ListChatLinks(ctx context.Context) ([]ChatLink, error)
+	SetReminded(ctx context.Context, chatID int64, at time.Time) error
+}
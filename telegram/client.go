@@ -0,0 +1,238 @@
+// Package telegram is a Telegram bot front-end: learners add words and review them from a chat.
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const DefaultBaseURL = "https://api.telegram.org"
+
+// APIError is a request rejected by Bot API.
+type APIError struct {
+	Code        int
+	Description string
+	// RetryAfter is set when the bot hit the flood limits.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("bot API error %d: %s", e.Code, e.Description)
+}
+
+// Client talks to Bot API at baseURL, which is the public one in production and
+// a local stand-in server in tests.
+type Client struct {
+	client  *http.Client
+	baseURL string
+	token   string
+}
+
+func NewClient(baseURL, token string) Client {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return Client{
+		client:  &http.Client{},
+		baseURL: baseURL,
+		token:   token,
+	}
+}
+
+type Update struct {
+	UpdateID      int64          `json:"update_id"`                //nolint:tagliatelle
+	Message       *Message       `json:"message,omitempty"`        //nolint:tagliatelle
+	CallbackQuery *CallbackQuery `json:"callback_query,omitempty"` //nolint:tagliatelle
+}
+
+// chatID returns the chat the update came from, zero if it's unknown.
+func (u Update) chatID() int64 {
+	switch {
+	case u.Message != nil:
+		return u.Message.Chat.ID
+	case u.CallbackQuery != nil && u.CallbackQuery.Message != nil:
+		return u.CallbackQuery.Message.Chat.ID
+	default:
+		return 0
+	}
+}
+
+type Message struct {
+	MessageID int64  `json:"message_id"` //nolint:tagliatelle
+	Chat      Chat   `json:"chat"`
+	Text      string `json:"text,omitempty"`
+}
+
+type Chat struct {
+	ID int64 `json:"id"`
+}
+
+type CallbackQuery struct {
+	ID      string   `json:"id"`
+	Message *Message `json:"message,omitempty"`
+	Data    string   `json:"data,omitempty"`
+}
+
+type InlineKeyboardMarkup struct {
+	InlineKeyboard [][]InlineKeyboardButton `json:"inline_keyboard"` //nolint:tagliatelle
+}
+
+type InlineKeyboardButton struct {
+	Text         string `json:"text"`
+	CallbackData string `json:"callback_data"` //nolint:tagliatelle
+}
+
+type getUpdatesRequest struct {
+	Offset         int64    `json:"offset,omitempty"`
+	Timeout        int      `json:"timeout,omitempty"`
+	AllowedUpdates []string `json:"allowed_updates"` //nolint:tagliatelle
+}
+
+type sendMessageRequest struct {
+	ChatID      int64                 `json:"chat_id"` //nolint:tagliatelle
+	Text        string                `json:"text"`
+	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"` //nolint:tagliatelle
+}
+
+type editMessageTextRequest struct {
+	ChatID      int64                 `json:"chat_id"`    //nolint:tagliatelle
+	MessageID   int64                 `json:"message_id"` //nolint:tagliatelle
+	Text        string                `json:"text"`
+	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"` //nolint:tagliatelle
+}
+
+type deleteMessageRequest struct {
+	ChatID    int64 `json:"chat_id"`    //nolint:tagliatelle
+	MessageID int64 `json:"message_id"` //nolint:tagliatelle
+}
+
+type answerCallbackQueryRequest struct {
+	CallbackQueryID string `json:"callback_query_id"` //nolint:tagliatelle
+	Text            string `json:"text,omitempty"`
+}
+
+type apiResponse struct {
+	OK          bool            `json:"ok"`
+	Result      json.RawMessage `json:"result"`
+	ErrorCode   int             `json:"error_code"` //nolint:tagliatelle
+	Description string          `json:"description"`
+	Parameters  struct {
+		RetryAfter int `json:"retry_after"` //nolint:tagliatelle
+	} `json:"parameters"`
+}
+
+// GetUpdates long polls for updates starting from offset, waiting up to timeout for the first one.
+func (c Client) GetUpdates(ctx context.Context, offset int64, timeout time.Duration) ([]Update, error) {
+	var updates []Update
+	err := c.call(ctx, "getUpdates", getUpdatesRequest{
+		Offset:         offset,
+		Timeout:        int(timeout.Seconds()),
+		AllowedUpdates: []string{"message", "callback_query"},
+	}, &updates)
+	if err != nil {
+		return nil, fmt.Errorf("telegram.Client.GetUpdates. %w", err)
+	}
+	return updates, nil
+}
+
+func (c Client) SendMessage(ctx context.Context, chatID int64, text string, markup *InlineKeyboardMarkup) (Message, error) {
+	var msg Message
+	err := c.call(ctx, "sendMessage", sendMessageRequest{ChatID: chatID, Text: text, ReplyMarkup: markup}, &msg)
+	if err != nil {
+		return Message{}, fmt.Errorf("telegram.Client.SendMessage. %w", err)
+	}
+	return msg, nil
+}
+
+// EditMessageText replaces text of the bot's message, nil markup removes its inline keyboard.
+func (c Client) EditMessageText(ctx context.Context, chatID, messageID int64, text string, markup *InlineKeyboardMarkup) error {
+	err := c.call(ctx, "editMessageText", editMessageTextRequest{ChatID: chatID, MessageID: messageID, Text: text, ReplyMarkup: markup}, nil)
+	if err != nil {
+		return fmt.Errorf("telegram.Client.EditMessageText. %w", err)
+	}
+	return nil
+}
+
+func (c Client) DeleteMessage(ctx context.Context, chatID, messageID int64) error {
+	err := c.call(ctx, "deleteMessage", deleteMessageRequest{ChatID: chatID, MessageID: messageID}, nil)
+	if err != nil {
+		return fmt.Errorf("telegram.Client.DeleteMessage. %w", err)
+	}
+	return nil
+}
+
+// AnswerCallbackQuery stops the progress indicator on the pressed button, non-empty text is shown as a notification.
+func (c Client) AnswerCallbackQuery(ctx context.Context, id, text string) error {
+	err := c.call(ctx, "answerCallbackQuery", answerCallbackQueryRequest{CallbackQueryID: id, Text: text}, nil)
+	if err != nil {
+		return fmt.Errorf("telegram.Client.AnswerCallbackQuery. %w", err)
+	}
+	return nil
+}
+
+func (c Client) call(ctx context.Context, method string, params, result any) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("unable to marshal %s request. %w", method, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/bot"+c.token+"/"+method, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("unable to build %s request. %w", method, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		// the URL holds the bot token, it must not end up in logs
+		return fmt.Errorf("unable to make %s request. %w", method, redactToken(err, c.token))
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read %s response. %w", method, err)
+	}
+
+	var apiResp apiResponse
+	if err := json.Unmarshal(respBody, &apiResp); err != nil {
+		return fmt.Errorf("unable to unmarshal %s response with status %d. %w", method, resp.StatusCode, err)
+	}
+	if !apiResp.OK {
+		return fmt.Errorf("%s request failed. %w", method, &APIError{
+			Code:        apiResp.ErrorCode,
+			Description: apiResp.Description,
+			RetryAfter:  time.Duration(apiResp.Parameters.RetryAfter) * time.Second,
+		})
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(apiResp.Result, result); err != nil {
+		return fmt.Errorf("unable to unmarshal %s result. %w", method, err)
+	}
+	return nil
+}
+
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e redactedError) Error() string { return e.msg }
+func (e redactedError) Unwrap() error { return e.err }
+
+func redactToken(err error, token string) error {
+	if token == "" {
+		return err
+	}
+	return redactedError{msg: strings.ReplaceAll(err.Error(), token, "<token>"), err: err}
+}
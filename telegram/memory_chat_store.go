@@ -0,0 +1,103 @@
+package telegram
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+)
+
+// MemoryChatStore keeps chat links in memory. It's safe for concurrent use.
+type MemoryChatStore struct {
+	mu    sync.RWMutex
+	links map[int64]ChatLink
+}
+
+func NewMemoryChatStore() *MemoryChatStore {
+	return &MemoryChatStore{
+		links: map[int64]ChatLink{},
+	}
+}
+
+func (s *MemoryChatStore) LinkChat(_ context.Context, chatID int64, userID models.UserID, credentialID string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.links[chatID] = ChatLink{ChatID: chatID, UserID: userID, CredentialID: credentialID, LinkedAt: at}
+	return nil
+}
+
+func (s *MemoryChatStore) UnlinkChat(_ context.Context, chatID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.links, chatID)
+	return nil
+}
+
+func (s *MemoryChatStore) GetChatLink(_ context.Context, chatID int64) (ChatLink, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	l, ok := s.links[chatID]
+	if !ok {
+		return ChatLink{}, fmt.Errorf("telegram.MemoryChatStore.GetChatLink chat %d. %w", chatID, ErrChatNotLinked)
+	}
+	return l, nil
+}
+
+func (s *MemoryChatStore) ListChatLinks(context.Context) ([]ChatLink, error) {
+	s.mu.RLock()
+	links := make([]ChatLink, 0, len(s.links))
+	for _, l := range s.links {
+		links = append(links, l)
+	}
+	s.mu.RUnlock()
+
+	slices.SortFunc(links, func(a, b ChatLink) int {
+		return cmp.Compare(a.ChatID, b.ChatID)
+	})
+	return links, nil
+}
+
+func (s *MemoryChatStore) SetReminded(_ context.Context, chatID int64, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l, ok := s.links[chatID]
+	if !ok {
+		return fmt.Errorf("telegram.MemoryChatStore.SetReminded chat %d. %w", chatID, ErrChatNotLinked)
+	}
+	l.RemindedAt = at
+	s.links[chatID] = l
+	return nil
+}
+
+// MarshalJSON writes all the chat links for a snapshot.
+func (s *MemoryChatStore) MarshalJSON() ([]byte, error) {
+	links, _ := s.ListChatLinks(context.Background())
+	b, err := json.Marshal(links)
+	if err != nil {
+		return nil, fmt.Errorf("telegram.MemoryChatStore.MarshalJSON. %w", err)
+	}
+	return b, nil
+}
+
+// UnmarshalJSON replaces the chat links with the ones of a snapshot.
+func (s *MemoryChatStore) UnmarshalJSON(b []byte) error {
+	var links []ChatLink
+	if err := json.Unmarshal(b, &links); err != nil {
+		return fmt.Errorf("telegram.MemoryChatStore.UnmarshalJSON. %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.links = make(map[int64]ChatLink, len(links))
+	for _, l := range links {
+		s.links[l.ChatID] = l
+	}
+	return nil
+}
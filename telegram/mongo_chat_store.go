@@ -0,0 +1,127 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	fieldID           = "_id"
+	fieldUserID       = "userId"
+	fieldCredentialID = "credentialId"
+	fieldLinkedAt     = "linkedAt"
+	fieldRemindedAt   = "remindedAt"
+)
+
+type MongoChatStore struct {
+	col *mongo.Collection
+}
+
+func NewMongoChatStore(db *mongo.Database) MongoChatStore {
+	col := db.Collection("telegram_chats")
+	return MongoChatStore{
+		col,
+	}
+}
+
+type chatLinkEntity struct {
+	ChatID int64              `bson:"_id"`
+	UserID primitive.ObjectID `bson:"userId"`
+	// CredentialID is missing in chats linked before it was recorded
+	CredentialID string    `bson:"credentialId,omitempty"`
+	LinkedAt     time.Time `bson:"linkedAt"`
+	RemindedAt   time.Time `bson:"remindedAt,omitempty"`
+}
+
+func chatLinkToModel(e chatLinkEntity) ChatLink {
+	return ChatLink{
+		ChatID:       e.ChatID,
+		UserID:       models.UserID(e.UserID.Hex()),
+		CredentialID: e.CredentialID,
+		LinkedAt:     e.LinkedAt,
+		RemindedAt:   e.RemindedAt,
+	}
+}
+
+func (s MongoChatStore) LinkChat(ctx context.Context, chatID int64, userID models.UserID, credentialID string, at time.Time) error {
+	objID, err := primitive.ObjectIDFromHex(userID.String())
+	if err != nil {
+		return fmt.Errorf("telegram.MongoChatStore.LinkChat unable to build ObjectId from user's ID %s. %w", userID, err)
+	}
+
+	_, err = s.col.UpdateOne(ctx,
+		bson.D{{Key: fieldID, Value: chatID}},
+		bson.D{
+			{Key: "$set", Value: bson.D{
+				{Key: fieldUserID, Value: objID},
+				{Key: fieldCredentialID, Value: credentialID},
+				{Key: fieldLinkedAt, Value: at},
+			}},
+			{Key: "$unset", Value: bson.D{{Key: fieldRemindedAt, Value: ""}}},
+		},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return fmt.Errorf("telegram.MongoChatStore.LinkChat unable to upsert chat %d. %w", chatID, err)
+	}
+	return nil
+}
+
+func (s MongoChatStore) UnlinkChat(ctx context.Context, chatID int64) error {
+	if _, err := s.col.DeleteOne(ctx, bson.D{{Key: fieldID, Value: chatID}}); err != nil {
+		return fmt.Errorf("telegram.MongoChatStore.UnlinkChat unable to delete chat %d. %w", chatID, err)
+	}
+	return nil
+}
+
+func (s MongoChatStore) GetChatLink(ctx context.Context, chatID int64) (ChatLink, error) {
+	var e chatLinkEntity
+	err := s.col.FindOne(ctx, bson.D{{Key: fieldID, Value: chatID}}).Decode(&e)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ChatLink{}, fmt.Errorf("telegram.MongoChatStore.GetChatLink chat %d. %w", chatID, ErrChatNotLinked)
+	}
+	if err != nil {
+		return ChatLink{}, fmt.Errorf("telegram.MongoChatStore.GetChatLink unable to fetch chat %d. %w", chatID, err)
+	}
+	return chatLinkToModel(e), nil
+}
+
+func (s MongoChatStore) ListChatLinks(ctx context.Context) ([]ChatLink, error) {
+	cur, err := s.col.Find(ctx, bson.D{})
+	if err != nil {
+		return nil, fmt.Errorf("telegram.MongoChatStore.ListChatLinks unable to find chats. %w", err)
+	}
+
+	var entities []chatLinkEntity
+	if err := cur.All(ctx, &entities); err != nil {
+		return nil, fmt.Errorf("telegram.MongoChatStore.ListChatLinks unable to decode chats. %w", err)
+	}
+
+	links := make([]ChatLink, 0, len(entities))
+	for _, e := range entities {
+		links = append(links, chatLinkToModel(e))
+	}
+	return links, nil
+}
+
+func (s MongoChatStore) SetReminded(ctx context.Context, chatID int64, at time.Time) error {
+	res, err := s.col.UpdateOne(ctx,
+		bson.D{{Key: fieldID, Value: chatID}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: fieldRemindedAt, Value: at}}}},
+	)
+	if err != nil {
+		return fmt.Errorf("telegram.MongoChatStore.SetReminded unable to update chat %d. %w", chatID, err)
+	}
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("telegram.MongoChatStore.SetReminded chat %d. %w", chatID, ErrChatNotLinked)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pavelpuchok/vocabforge/models"
+)
+
+// PostgresChatStore keeps chat links in the telegram_chats table of a database opened with postgresdb.Open.
+type PostgresChatStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresChatStore(pool *pgxpool.Pool) PostgresChatStore {
+	return PostgresChatStore{
+		pool,
+	}
+}
+
+func scanPostgresChatLink(row pgx.Row) (ChatLink, error) {
+	var l ChatLink
+	var remindedAt pgtype.Timestamptz
+	if err := row.Scan(&l.ChatID, &l.UserID, &l.CredentialID, &l.LinkedAt, &remindedAt); err != nil {
+		return ChatLink{}, err
+	}
+	l.LinkedAt = l.LinkedAt.UTC()
+	if remindedAt.Valid {
+		l.RemindedAt = remindedAt.Time.UTC()
+	}
+	return l, nil
+}
+
+func (s PostgresChatStore) LinkChat(ctx context.Context, chatID int64, userID models.UserID, credentialID string, at time.Time) error {
+	_, err := s.pool.Exec(ctx, `INSERT INTO telegram_chats (chat_id, user_id, credential_id, linked_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (chat_id) DO UPDATE SET user_id = excluded.user_id, credential_id = excluded.credential_id,
+		linked_at = excluded.linked_at, reminded_at = NULL`,
+		chatID, userID, credentialID, at)
+	if err != nil {
+		return fmt.Errorf("telegram.PostgresChatStore.LinkChat unable to upsert chat %d. %w", chatID, err)
+	}
+	return nil
+}
+
+func (s PostgresChatStore) UnlinkChat(ctx context.Context, chatID int64) error {
+	if _, err := s.pool.Exec(ctx, `DELETE FROM telegram_chats WHERE chat_id = $1`, chatID); err != nil {
+		return fmt.Errorf("telegram.PostgresChatStore.UnlinkChat unable to delete chat %d. %w", chatID, err)
+	}
+	return nil
+}
+
+func (s PostgresChatStore) GetChatLink(ctx context.Context, chatID int64) (ChatLink, error) {
+	row := s.pool.QueryRow(ctx, `SELECT chat_id, user_id, credential_id, linked_at, reminded_at FROM telegram_chats WHERE chat_id = $1`, chatID)
+	l, err := scanPostgresChatLink(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ChatLink{}, fmt.Errorf("telegram.PostgresChatStore.GetChatLink chat %d. %w", chatID, ErrChatNotLinked)
+	}
+	if err != nil {
+		return ChatLink{}, fmt.Errorf("telegram.PostgresChatStore.GetChatLink unable to fetch chat %d. %w", chatID, err)
+	}
+	return l, nil
+}
+
+func (s PostgresChatStore) ListChatLinks(ctx context.Context) ([]ChatLink, error) {
+	rows, err := s.pool.Query(ctx, `SELECT chat_id, user_id, credential_id, linked_at, reminded_at FROM telegram_chats ORDER BY chat_id`)
+	if err != nil {
+		return nil, fmt.Errorf("telegram.PostgresChatStore.ListChatLinks unable to find chats. %w", err)
+	}
+	defer rows.Close()
+
+	links := []ChatLink{}
+	for rows.Next() {
+		l, err := scanPostgresChatLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("telegram.PostgresChatStore.ListChatLinks unable to scan chat. %w", err)
+		}
+		links = append(links, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("telegram.PostgresChatStore.ListChatLinks unable to read chats. %w", err)
+	}
+	return links, nil
+}
+
+func (s PostgresChatStore) SetReminded(ctx context.Context, chatID int64, at time.Time) error {
+	tag, err := s.pool.Exec(ctx, `UPDATE telegram_chats SET reminded_at = $1 WHERE chat_id = $2`, at, chatID)
+	if err != nil {
+		return fmt.Errorf("telegram.PostgresChatStore.SetReminded unable to update chat %d. %w", chatID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("telegram.PostgresChatStore.SetReminded chat %d. %w", chatID, ErrChatNotLinked)
+	}
+	return nil
+}
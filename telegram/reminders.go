@@ -0,0 +1,95 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/users"
+)
+
+// DefaultReminderInterval is how often chats are checked for a due reminder.
+const DefaultReminderInterval = time.Minute
+
+// RunReminders sends daily reminders every interval until ctx is done.
+func (b *Bot) RunReminders(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		interval = DefaultReminderInterval
+	}
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		if err := b.SendReminders(ctx, time.Now()); err != nil {
+			b.Logger.ErrorContext(ctx, "telegram: unable to send reminders", slog.String("err", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+		}
+	}
+}
+
+// SendReminders tells every linked user with words due how many of them are waiting.
+// A chat gets one reminder a day, not earlier than ReminderHour of the user's local time.
+func (b *Bot) SendReminders(ctx context.Context, now time.Time) error {
+	links, err := b.Chats.ListChatLinks(ctx)
+	if err != nil {
+		return fmt.Errorf("telegram.Bot.SendReminders unable to list chats. %w", err)
+	}
+
+	for _, link := range links {
+		if err := b.remind(ctx, link, now); err != nil {
+			b.Logger.ErrorContext(ctx, "telegram: unable to remind", slog.Int64("chatID", link.ChatID), slog.String("err", err.Error()))
+		}
+	}
+	return nil
+}
+
+func (b *Bot) remind(ctx context.Context, link ChatLink, now time.Time) error {
+	err := b.checkLink(ctx, link)
+	if errors.Is(err, users.ErrUnauthenticated) {
+		// the chat is unlinked, there is no one to remind
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("telegram.Bot.remind. %w", err)
+	}
+
+	usr, err := b.Users.GetUser(ctx, link.UserID)
+	if err != nil {
+		return fmt.Errorf("telegram.Bot.remind unable to get user. %w", err)
+	}
+
+	local := now.In(usr.Location())
+	if local.Hour() < b.ReminderHour || sameDay(link.RemindedAt.In(local.Location()), local) {
+		return nil
+	}
+
+	st, err := b.Stats.Run(ctx, link.UserID, "")
+	if err != nil {
+		return fmt.Errorf("telegram.Bot.remind unable to count due words. %w", err)
+	}
+	if st.Due > 0 {
+		text := fmt.Sprintf("%d words are due for a review. Send /review to start.", st.Due)
+		if err := b.reply(ctx, link.ChatID, text); err != nil {
+			return fmt.Errorf("telegram.Bot.remind. %w", err)
+		}
+	}
+
+	// the day is marked even if nothing was due, so words are counted once a day
+	if err := b.Chats.SetReminded(ctx, link.ChatID, now); err != nil {
+		return fmt.Errorf("telegram.Bot.remind unable to mark chat reminded. %w", err)
+	}
+	return nil
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
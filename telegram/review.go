@@ -0,0 +1,220 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/practice"
+)
+
+const (
+	// maxDistractors is the number of wrong answers offered along with the right one.
+	maxDistractors = 3
+	answerPrefix   = "ans"
+	dontKnowOption = "-"
+)
+
+// review is a cloze review in progress in a chat.
+type review struct {
+	userID    models.UserID
+	items     []practice.Item
+	current   int
+	options   []string
+	messageID int64
+	correct   int
+}
+
+func (b *Bot) handleReview(ctx context.Context, chatID int64) error {
+	userID, err := b.chatUserID(ctx, chatID)
+	if err != nil {
+		return err
+	}
+
+	exerciseType := practice.Cloze
+	session, err := b.StartPractice.Run(ctx, userID, "", 0, &exerciseType)
+	if err != nil {
+		return fmt.Errorf("telegram.Bot.handleReview unable to start practice. %w", err)
+	}
+	if len(session.Items) == 0 {
+		b.setReview(chatID, nil)
+		return b.reply(ctx, chatID, "Nothing to review right now.")
+	}
+
+	r := &review{userID: userID, items: session.Items}
+	b.setReview(chatID, r)
+	return b.ask(ctx, chatID, r)
+}
+
+func (b *Bot) handleStop(ctx context.Context, chatID int64) error {
+	r := b.getReview(chatID)
+	if r == nil {
+		return b.reply(ctx, chatID, "No review in progress.")
+	}
+	b.setReview(chatID, nil)
+	return b.reply(ctx, chatID, summary(r))
+}
+
+// ask sends the current exercise with its answer options as an inline keyboard.
+func (b *Bot) ask(ctx context.Context, chatID int64, r *review) error {
+	item := r.items[r.current]
+	r.options = answerOptions(r.items, r.current)
+
+	var keyboard InlineKeyboardMarkup
+	for i, o := range r.options {
+		keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, []InlineKeyboardButton{{
+			Text:         o,
+			CallbackData: answerData(r.current, strconv.Itoa(i)),
+		}})
+	}
+	keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, []InlineKeyboardButton{{
+		Text:         "I don't know",
+		CallbackData: answerData(r.current, dontKnowOption),
+	}})
+
+	msg, err := b.Client.SendMessage(ctx, chatID, question(r, item), &keyboard)
+	if err != nil {
+		return fmt.Errorf("telegram.Bot.ask unable to send exercise. %w", err)
+	}
+	r.messageID = msg.MessageID
+	return nil
+}
+
+// handleCallback records the answer picked on the keyboard and moves on to the next exercise.
+func (b *Bot) handleCallback(ctx context.Context, q CallbackQuery) {
+	notice := ""
+	defer func() {
+		if err := b.Client.AnswerCallbackQuery(ctx, q.ID, notice); err != nil {
+			b.Logger.WarnContext(ctx, "telegram: unable to answer callback query", slog.String("err", err.Error()))
+		}
+	}()
+
+	if q.Message == nil {
+		return
+	}
+	chatID := q.Message.Chat.ID
+
+	r := b.getReview(chatID)
+	itemIndex, answer, ok := parseAnswerData(q.Data, r)
+	if !ok {
+		notice = "This review is over, send /review to start a new one."
+		return
+	}
+	if _, err := b.chatUserID(ctx, chatID); err != nil {
+		b.replyError(ctx, chatID, fmt.Errorf("telegram.Bot.handleCallback. %w", err))
+		return
+	}
+
+	item := r.items[itemIndex]
+	res, err := b.Answer.Run(ctx, r.userID, item.Word.ID, item.Type, item.ExerciseIndex, answer)
+	if err != nil {
+		b.replyError(ctx, chatID, fmt.Errorf("telegram.Bot.handleCallback unable to answer. %w", err))
+		return
+	}
+
+	verdict := "❌ The answer is " + res.Expected
+	if res.Correct {
+		r.correct++
+		verdict = "✅ Correct: " + res.Expected
+	}
+	if err := b.Client.EditMessageText(ctx, chatID, r.messageID, question(r, item)+"\n\n"+verdict, nil); err != nil {
+		b.Logger.WarnContext(ctx, "telegram: unable to show verdict", slog.String("err", err.Error()))
+	}
+
+	r.current++
+	if r.current == len(r.items) {
+		b.setReview(chatID, nil)
+		if err := b.reply(ctx, chatID, summary(r)); err != nil {
+			b.replyError(ctx, chatID, err)
+		}
+		return
+	}
+	if err := b.ask(ctx, chatID, r); err != nil {
+		b.replyError(ctx, chatID, err)
+	}
+}
+
+func (b *Bot) getReview(chatID int64) *review {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.reviews[chatID]
+}
+
+// setReview replaces the review of the chat, nil r ends it.
+func (b *Bot) setReview(chatID int64, r *review) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if r == nil {
+		delete(b.reviews, chatID)
+		return
+	}
+	if b.reviews == nil {
+		b.reviews = make(map[int64]*review)
+	}
+	b.reviews[chatID] = r
+}
+
+func question(r *review, item practice.Item) string {
+	return fmt.Sprintf("[%d/%d] %s", r.current+1, len(r.items), item.Prompt())
+}
+
+func summary(r *review) string {
+	return fmt.Sprintf("Review finished: %d of %d correct.", r.correct, r.current)
+}
+
+func answerData(itemIndex int, option string) string {
+	return answerPrefix + ":" + strconv.Itoa(itemIndex) + ":" + option
+}
+
+// parseAnswerData returns the item and the answer picked. Presses on keyboards of
+// answered or abandoned exercises are not ok.
+func parseAnswerData(data string, r *review) (int, string, bool) {
+	parts := strings.Split(data, ":")
+	if r == nil || len(parts) != 3 || parts[0] != answerPrefix {
+		return 0, "", false
+	}
+
+	itemIndex, err := strconv.Atoi(parts[1])
+	if err != nil || itemIndex != r.current {
+		return 0, "", false
+	}
+	if parts[2] == dontKnowOption {
+		return itemIndex, "", true
+	}
+
+	option, err := strconv.Atoi(parts[2])
+	if err != nil || option < 0 || option >= len(r.options) {
+		return 0, "", false
+	}
+	return itemIndex, r.options[option], true
+}
+
+// answerOptions returns the answer of the i-th item mixed with answers of the other
+// items of the session, which are forms of words the learner is studying too.
+func answerOptions(items []practice.Item, i int) []string {
+	answer := items[i].Cloze.Answer
+	seen := map[string]bool{strings.ToLower(answer): true}
+
+	var distractors []string
+	for j, item := range items {
+		key := strings.ToLower(item.Cloze.Answer)
+		if j == i || item.Cloze.Answer == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		distractors = append(distractors, item.Cloze.Answer)
+	}
+	rand.Shuffle(len(distractors), func(a, b int) {
+		distractors[a], distractors[b] = distractors[b], distractors[a]
+	})
+
+	options := append(distractors[:min(len(distractors), maxDistractors)], answer)
+	rand.Shuffle(len(options), func(a, b int) {
+		options[a], options[b] = options[b], options[a]
+	})
+	return options
+}
@@ -0,0 +1,106 @@
+package telegram
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+)
+
+// SQLiteChatStore keeps chat links in the telegram_chats table of a database opened with sqlitedb.Open.
+type SQLiteChatStore struct {
+	db *sql.DB
+}
+
+func NewSQLiteChatStore(db *sql.DB) SQLiteChatStore {
+	return SQLiteChatStore{
+		db,
+	}
+}
+
+type sqliteScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSQLiteChatLink(row sqliteScanner) (ChatLink, error) {
+	var l ChatLink
+	var linkedAt int64
+	var remindedAt sql.NullInt64
+	if err := row.Scan(&l.ChatID, &l.UserID, &l.CredentialID, &linkedAt, &remindedAt); err != nil {
+		return ChatLink{}, err
+	}
+	l.LinkedAt = time.UnixMilli(linkedAt).UTC()
+	if remindedAt.Valid {
+		l.RemindedAt = time.UnixMilli(remindedAt.Int64).UTC()
+	}
+	return l, nil
+}
+
+func (s SQLiteChatStore) LinkChat(ctx context.Context, chatID int64, userID models.UserID, credentialID string, at time.Time) error {
+	_, err := s.db.ExecContext(ctx, `INSERT INTO telegram_chats (chat_id, user_id, credential_id, linked_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (chat_id) DO UPDATE SET user_id = excluded.user_id, credential_id = excluded.credential_id,
+		linked_at = excluded.linked_at, reminded_at = NULL`,
+		chatID, userID, credentialID, at.UnixMilli())
+	if err != nil {
+		return fmt.Errorf("telegram.SQLiteChatStore.LinkChat unable to upsert chat %d. %w", chatID, err)
+	}
+	return nil
+}
+
+func (s SQLiteChatStore) UnlinkChat(ctx context.Context, chatID int64) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM telegram_chats WHERE chat_id = ?`, chatID); err != nil {
+		return fmt.Errorf("telegram.SQLiteChatStore.UnlinkChat unable to delete chat %d. %w", chatID, err)
+	}
+	return nil
+}
+
+func (s SQLiteChatStore) GetChatLink(ctx context.Context, chatID int64) (ChatLink, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT chat_id, user_id, credential_id, linked_at, reminded_at FROM telegram_chats WHERE chat_id = ?`, chatID)
+	l, err := scanSQLiteChatLink(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ChatLink{}, fmt.Errorf("telegram.SQLiteChatStore.GetChatLink chat %d. %w", chatID, ErrChatNotLinked)
+	}
+	if err != nil {
+		return ChatLink{}, fmt.Errorf("telegram.SQLiteChatStore.GetChatLink unable to fetch chat %d. %w", chatID, err)
+	}
+	return l, nil
+}
+
+func (s SQLiteChatStore) ListChatLinks(ctx context.Context) ([]ChatLink, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT chat_id, user_id, credential_id, linked_at, reminded_at FROM telegram_chats ORDER BY chat_id`)
+	if err != nil {
+		return nil, fmt.Errorf("telegram.SQLiteChatStore.ListChatLinks unable to find chats. %w", err)
+	}
+	defer rows.Close()
+
+	links := []ChatLink{}
+	for rows.Next() {
+		l, err := scanSQLiteChatLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("telegram.SQLiteChatStore.ListChatLinks unable to scan chat. %w", err)
+		}
+		links = append(links, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("telegram.SQLiteChatStore.ListChatLinks unable to read chats. %w", err)
+	}
+	return links, nil
+}
+
+func (s SQLiteChatStore) SetReminded(ctx context.Context, chatID int64, at time.Time) error {
+	res, err := s.db.ExecContext(ctx, `UPDATE telegram_chats SET reminded_at = ? WHERE chat_id = ?`, at.UnixMilli(), chatID)
+	if err != nil {
+		return fmt.Errorf("telegram.SQLiteChatStore.SetReminded unable to update chat %d. %w", chatID, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("telegram.SQLiteChatStore.SetReminded unable to count updated chats. %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("telegram.SQLiteChatStore.SetReminded chat %d. %w", chatID, ErrChatNotLinked)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// Package tui is a full-screen terminal front-end to browse the vocabulary, fix generated
+// exercises and run review sessions.
+package tui
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/usecases/answerexercise"
+	"github.com/pavelpuchok/vocabforge/usecases/startpractice"
+	"github.com/pavelpuchok/vocabforge/usecases/stats"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+)
+
+// App runs the TUI. Every field has to be set.
+type App struct {
+	VocabularyService VocabularyService
+	PracticeService   PracticeService
+	UsersService      UsersService
+	In                io.Reader
+	Out               io.Writer
+}
+
+type VocabularyService interface {
+	stats.VocabularyService
+	ListWords(ctx context.Context, userID models.UserID, filter vocabulary.ListFilter) (vocabulary.WordsPage, error)
+	UpdateWord(ctx context.Context, userID models.UserID, wordID models.WordID, patch vocabulary.WordPatch, regenerateExercises bool) (models.Word, error)
+}
+
+type PracticeService interface {
+	startpractice.PracticeService
+	answerexercise.PracticeService
+}
+
+type UsersService = startpractice.UsersService
+
+// Run shows the user's words, of lang only if it isn't empty, until the user quits or ctx is done.
+func (a App) Run(ctx context.Context, userID models.UserID, lang models.Language) error {
+	usr, err := a.UsersService.GetUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("tui.App.Run unable to get user. %w", err)
+	}
+
+	m := newModel(ctx, a, usr, lang)
+	p := tea.NewProgram(m, tea.WithContext(ctx), tea.WithInput(a.In), tea.WithOutput(a.Out), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("tui.App.Run. %w", err)
+	}
+	return nil
+}
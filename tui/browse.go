@@ -0,0 +1,182 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/usecases/stats"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+)
+
+// learnStatusFilters are cycled through by the status filter key, nil shows words of any status.
+var learnStatusFilters = []*models.LearnStatus{nil, ptr(models.Pending), ptr(models.InProgress), ptr(models.Learned)}
+
+type browseState struct {
+	languages []models.Language
+	langIndex int
+	// statusIndex points to learnStatusFilters.
+	statusIndex int
+	words       []models.Word
+	selected    int
+	// cursors of the pages shown before the current one, they are needed to go back.
+	prevCursors []string
+	cursor      string
+	nextCursor  string
+	stats       stats.Stats
+	loading     bool
+}
+
+type wordsMsg struct {
+	cursor string
+	page   vocabulary.WordsPage
+	stats  stats.Stats
+}
+
+func (m model) filter() vocabulary.ListFilter {
+	return vocabulary.ListFilter{
+		Language:    m.browse.languages[m.browse.langIndex],
+		LearnStatus: learnStatusFilters[m.browse.statusIndex],
+		Sort:        vocabulary.SortBySpellingAsc,
+		Limit:       pageSize,
+	}
+}
+
+// loadWords fetches the page at cursor along with the stats of the words of the filtered language.
+func (m model) loadWords(cursor string) tea.Cmd {
+	filter := m.filter()
+	filter.Cursor = cursor
+	return func() tea.Msg {
+		page, err := m.vocabulary.ListWords(m.ctx, m.userID, filter)
+		if err != nil {
+			return errMsg{fmt.Errorf("unable to list words. %w", err)}
+		}
+		st, err := m.stats.Run(m.ctx, m.userID, filter.Language)
+		if err != nil {
+			return errMsg{fmt.Errorf("unable to count words. %w", err)}
+		}
+		return wordsMsg{cursor: cursor, page: page, stats: st}
+	}
+}
+
+func (m model) reload() (model, tea.Cmd) {
+	m.browse.prevCursors = nil
+	m.browse.loading = true
+	return m, m.loadWords("")
+}
+
+func (m model) updateBrowse(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case wordsMsg:
+		m.browse.loading = false
+		m.browse.cursor = msg.cursor
+		m.browse.words = msg.page.Words
+		m.browse.nextCursor = msg.page.NextCursor
+		m.browse.stats = msg.stats
+		m.browse.selected = min(m.browse.selected, max(len(m.browse.words)-1, 0))
+		return m, nil
+	case sessionMsg:
+		if len(msg.session.Items) == 0 {
+			m.status = "Nothing to review right now."
+			return m, nil
+		}
+		m.screen = reviewScreen
+		m.review = reviewState{session: msg.session}
+		m.status = ""
+		m.input.Reset()
+		m.input.Focus()
+		return m, nil
+	case tea.KeyMsg:
+		return m.browseKey(msg)
+	}
+	return m, nil
+}
+
+func (m model) browseKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	b := &m.browse
+	switch msg.String() {
+	case "q", "esc":
+		return m, tea.Quit
+	case "up", "k":
+		b.selected = max(b.selected-1, 0)
+	case "down", "j":
+		b.selected = min(b.selected+1, max(len(b.words)-1, 0))
+	case "s":
+		b.statusIndex = (b.statusIndex + 1) % len(learnStatusFilters)
+		b.selected = 0
+		return m.reload()
+	case "l":
+		b.langIndex = (b.langIndex + 1) % len(b.languages)
+		b.selected = 0
+		return m.reload()
+	case "n", "right":
+		if b.nextCursor != "" && !b.loading {
+			b.prevCursors = append(b.prevCursors, b.cursor)
+			b.selected = 0
+			b.loading = true
+			return m, m.loadWords(b.nextCursor)
+		}
+	case "p", "left":
+		if len(b.prevCursors) > 0 && !b.loading {
+			prev := b.prevCursors[len(b.prevCursors)-1]
+			b.prevCursors = b.prevCursors[:len(b.prevCursors)-1]
+			b.selected = 0
+			b.loading = true
+			return m, m.loadWords(prev)
+		}
+	case "enter":
+		if len(b.words) > 0 {
+			m.screen = wordScreen
+			m.word = wordState{word: b.words[b.selected]}
+			m.status = ""
+		}
+	case "r":
+		return m.startReview()
+	}
+	return m, nil
+}
+
+func (m model) viewBrowse(b *strings.Builder) {
+	st := m.browse.stats
+	status := "all"
+	if s := learnStatusFilters[m.browse.statusIndex]; s != nil {
+		status = s.String()
+	}
+
+	b.WriteString(titleStyle.Render("Vocabulary") + mutedStyle.Render(fmt.Sprintf("  language: %s  status: %s", languageText(m.browse.languages[m.browse.langIndex]), status)) + "\n\n")
+
+	learned := 0.0
+	if st.Total > 0 {
+		learned = float64(st.Learned) / float64(st.Total)
+	}
+	b.WriteString(m.bar.ViewAs(learned) + "\n")
+	fmt.Fprintf(b, "learned %d of %d, in progress %d, pending %d, due %d\n\n", st.Learned, st.Total, st.InProgress, st.Pending, st.Due)
+
+	if len(m.browse.words) == 0 {
+		b.WriteString(mutedStyle.Render("No words.") + "\n")
+	}
+	for i, w := range m.browse.words {
+		line := fmt.Sprintf("%-24s %-6s %-12s %d exercises  %s", w.Spelling, w.Language, learnStatusText(w.LearnStatus), len(w.Exercises), w.Definition)
+		line = truncate(line, m.width-2)
+		if i == m.browse.selected {
+			line = selectedStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n" + mutedStyle.Render(fmt.Sprintf("page %d", len(m.browse.prevCursors)+1)) + "\n")
+	b.WriteString(mutedStyle.Render("↑/↓ move • enter open • s status • l language • n/p page • r review • q quit") + "\n")
+}
+
+func truncate(s string, width int) string {
+	r := []rune(s)
+	if width <= 1 || len(r) <= width {
+		return s
+	}
+	return string(r[:width-1]) + "…"
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
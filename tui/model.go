@@ -0,0 +1,178 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/usecases/answerexercise"
+	"github.com/pavelpuchok/vocabforge/usecases/startpractice"
+	"github.com/pavelpuchok/vocabforge/usecases/stats"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+)
+
+const (
+	pageSize       = 20
+	maxBarWidth    = 60
+	barMargin      = 4
+	defaultWidth   = 80
+	inputCharLimit = 500
+)
+
+type screen int
+
+const (
+	browseScreen screen = iota
+	wordScreen
+	reviewScreen
+)
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	mutedStyle    = lipgloss.NewStyle().Faint(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	correctStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+)
+
+// model is the bubbletea model of all screens. Service calls are made in commands,
+// their outcomes come back to Update as messages.
+type model struct {
+	ctx        context.Context
+	vocabulary VocabularyService
+	start      startpractice.UseCase
+	answer     answerexercise.UseCase
+	stats      stats.UseCase
+	userID     models.UserID
+
+	screen screen
+	width  int
+	status string
+	err    error
+	input  textinput.Model
+	bar    progress.Model
+
+	browse browseState
+	word   wordState
+	review reviewState
+}
+
+type errMsg struct {
+	err error
+}
+
+func newModel(ctx context.Context, a App, usr models.User, lang models.Language) model {
+	input := textinput.New()
+	input.CharLimit = inputCharLimit
+
+	bar := progress.New(progress.WithDefaultGradient(), progress.WithoutPercentage())
+	bar.Width = defaultWidth - barMargin
+
+	// the first option shows words of any language
+	languages := append([]models.Language{""}, usr.TargetLanguages...)
+	langIndex := 0
+	if lang != "" {
+		langIndex = len(languages)
+		for i, l := range languages {
+			if l == lang {
+				langIndex = i
+			}
+		}
+		if langIndex == len(languages) {
+			languages = append(languages, lang)
+		}
+	}
+
+	return model{
+		ctx:        ctx,
+		vocabulary: a.VocabularyService,
+		start:      startpractice.UseCase{PracticeService: a.PracticeService, UsersService: a.UsersService},
+		answer:     answerexercise.UseCase{PracticeService: a.PracticeService},
+		stats:      stats.UseCase{VocabularyService: a.VocabularyService},
+		userID:     usr.ID,
+		width:      defaultWidth,
+		input:      input,
+		bar:        bar,
+		browse: browseState{
+			languages: languages,
+			langIndex: langIndex,
+		},
+	}
+}
+
+func (m model) Init() tea.Cmd {
+	return m.loadWords("")
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.bar.Width = min(msg.Width-barMargin, maxBarWidth)
+		return m, nil
+	case errMsg:
+		m.err = msg.err
+		return m, nil
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		m.err = nil
+	}
+
+	switch m.screen {
+	case wordScreen:
+		return m.updateWord(msg)
+	case reviewScreen:
+		return m.updateReview(msg)
+	default:
+		return m.updateBrowse(msg)
+	}
+}
+
+func (m model) View() string {
+	var b strings.Builder
+	switch m.screen {
+	case wordScreen:
+		m.viewWord(&b)
+	case reviewScreen:
+		m.viewReview(&b)
+	default:
+		m.viewBrowse(&b)
+	}
+
+	b.WriteString("\n")
+	if m.err != nil {
+		b.WriteString(errorStyle.Render("Error: "+m.err.Error()) + "\n")
+	} else if m.status != "" {
+		b.WriteString(m.status + "\n")
+	}
+	return b.String()
+}
+
+// saveExercises replaces the word's exercises and reports the outcome with status.
+func (m model) saveExercises(word models.Word, exercises []models.SentenceExercise, status string) tea.Cmd {
+	return func() tea.Msg {
+		updated, err := m.vocabulary.UpdateWord(m.ctx, m.userID, word.ID, vocabulary.WordPatch{Exercises: exercises}, false)
+		if err != nil {
+			return errMsg{fmt.Errorf("unable to save exercises. %w", err)}
+		}
+		return wordSavedMsg{word: updated, status: status}
+	}
+}
+
+func learnStatusText(s models.LearnStatus) string {
+	return s.String()
+}
+
+func languageText(l models.Language) string {
+	if l == "" {
+		return "all"
+	}
+	return string(l)
+}
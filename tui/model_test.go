@@ -0,0 +1,280 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/practice"
+	"github.com/pavelpuchok/vocabforge/practice/cloze"
+	"github.com/pavelpuchok/vocabforge/scheduling"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+)
+
+const testUserID = "000000000000000000000001"
+
+type fakeVocabulary struct {
+	words []models.Word
+	// filter is the filter of the last listed page, stats requests are not recorded.
+	filter vocabulary.ListFilter
+}
+
+func (f *fakeVocabulary) ListWords(_ context.Context, _ models.UserID, filter vocabulary.ListFilter) (vocabulary.WordsPage, error) {
+	if filter.Limit == pageSize {
+		f.filter = filter
+	}
+	var page vocabulary.WordsPage
+	for _, w := range f.words {
+		if filter.LearnStatus != nil && w.LearnStatus != *filter.LearnStatus {
+			continue
+		}
+		if filter.Language != "" && w.Language != filter.Language {
+			continue
+		}
+		page.Words = append(page.Words, w)
+	}
+	return page, nil
+}
+
+func (f *fakeVocabulary) CountWords(_ context.Context, _ models.UserID, filter vocabulary.ListFilter, dueAt time.Time) (vocabulary.WordCounts, error) {
+	var counts vocabulary.WordCounts
+	for _, w := range f.words {
+		if filter.Language != "" && w.Language != filter.Language {
+			continue
+		}
+		switch w.LearnStatus {
+		case models.Pending:
+			counts.Pending++
+		case models.InProgress:
+			counts.InProgress++
+		case models.Learned:
+			counts.Learned++
+		}
+		if !w.Schedule.Due.After(dueAt) {
+			counts.Due++
+		}
+	}
+	return counts, nil
+}
+
+func (f *fakeVocabulary) UpdateWord(_ context.Context, _ models.UserID, wordID models.WordID, patch vocabulary.WordPatch, _ bool) (models.Word, error) {
+	for i, w := range f.words {
+		if w.ID == wordID {
+			f.words[i].Exercises = patch.Exercises
+			return f.words[i], nil
+		}
+	}
+	return models.Word{}, vocabulary.ErrWordNotFound
+}
+
+type fakePractice struct {
+	items   []practice.Item
+	answers []string
+}
+
+func (f *fakePractice) StartSession(_ context.Context, userID models.UserID, _ models.Language, _ int, _ practice.ExerciseType) (practice.Session, error) {
+	return practice.Session{UserID: userID, Items: f.items}, nil
+}
+
+func (f *fakePractice) Answer(_ context.Context, _ models.UserID, _ models.WordID, exerciseIndex int, answer string) (practice.Result, error) {
+	f.answers = append(f.answers, answer)
+	expected := f.items[exerciseIndex].Cloze.Answer
+	grade := practice.Check(expected, answer)
+	return practice.Result{Correct: grade != scheduling.Again, Grade: grade, Expected: expected}, nil
+}
+
+func (f *fakePractice) AnswerDefinition(context.Context, models.UserID, models.WordID, string) (practice.Result, error) {
+	return practice.Result{}, nil
+}
+
+type fakeUsers struct{}
+
+func (fakeUsers) GetUser(_ context.Context, id models.UserID) (models.User, error) {
+	return models.User{ID: id}, nil
+}
+
+func newTestModel(vocab *fakeVocabulary, pr *fakePractice) model {
+	app := App{VocabularyService: vocab, PracticeService: pr, UsersService: fakeUsers{}}
+	return newModel(context.Background(), app, models.User{ID: testUserID, TargetLanguages: []models.Language{"de", "en"}}, "")
+}
+
+// send passes msg to the model and runs the commands it returns, like bubbletea runtime does.
+func send(t *testing.T, m model, msg tea.Msg) model {
+	t.Helper()
+	for msg != nil {
+		updated, cmd := m.Update(msg)
+		m = updated.(model)
+		if cmd == nil {
+			break
+		}
+		msg = cmd()
+		if _, ok := msg.(tea.QuitMsg); ok {
+			break
+		}
+	}
+	if m.err != nil {
+		t.Fatalf("unexpected error %s", m.err)
+	}
+	return m
+}
+
+func key(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	default:
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+}
+
+// press passes msg to the model dropping the commands, the input returns ones blinking the cursor.
+func press(m model, msg tea.KeyMsg) model {
+	updated, _ := m.Update(msg)
+	return updated.(model)
+}
+
+func typeText(m model, text string) model {
+	for _, r := range text {
+		m = press(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return m
+}
+
+func clearInput(m model) model {
+	return press(m, tea.KeyMsg{Type: tea.KeyCtrlU})
+}
+
+func TestModel_Browse(t *testing.T) {
+	t.Parallel()
+
+	vocab := &fakeVocabulary{words: []models.Word{
+		{ID: "w1", Spelling: "laufen", Language: "de", LearnStatus: models.Learned},
+		{ID: "w2", Spelling: "run", Language: "en", LearnStatus: models.Pending},
+	}}
+	m := newTestModel(vocab, &fakePractice{})
+	m = send(t, m, m.Init()())
+
+	if len(m.browse.words) != 2 || m.browse.stats.Total != 2 || m.browse.stats.Learned != 1 {
+		t.Fatalf("unexpected browse state %+v", m.browse)
+	}
+	if view := m.View(); !strings.Contains(view, "laufen") || !strings.Contains(view, "learned 1 of 2") {
+		t.Errorf("unexpected view %s", view)
+	}
+
+	m = send(t, m, key("s"))
+	if f := vocab.filter; f.LearnStatus == nil || *f.LearnStatus != models.Pending {
+		t.Errorf("unexpected filter %+v", f)
+	}
+	if len(m.browse.words) != 1 || m.browse.words[0].Spelling != "run" {
+		t.Errorf("unexpected words %+v", m.browse.words)
+	}
+
+	m = send(t, m, key("l"))
+	if f := vocab.filter; f.Language != "de" {
+		t.Errorf("unexpected filter %+v", f)
+	}
+	if len(m.browse.words) != 0 {
+		t.Errorf("unexpected words %+v", m.browse.words)
+	}
+}
+
+func TestModel_EditExercises(t *testing.T) {
+	t.Parallel()
+
+	vocab := &fakeVocabulary{words: []models.Word{{
+		ID:       "w1",
+		Spelling: "run",
+		Exercises: []models.SentenceExercise{
+			{Sentence: "I <%run%> daily.", Answered: true},
+			{Sentence: "Bad sentence without markup"},
+			{Sentence: "She <%runs%> fast."},
+		},
+	}}}
+	m := newTestModel(vocab, &fakePractice{})
+	m = send(t, m, m.Init()())
+	m = send(t, m, key("enter"))
+	if m.screen != wordScreen {
+		t.Fatalf("unexpected screen %d", m.screen)
+	}
+
+	// delete the bad sentence
+	m = send(t, m, key("down"))
+	m = send(t, m, key("d"))
+	m = send(t, m, key("y"))
+	if exs := vocab.words[0].Exercises; len(exs) != 2 || exs[1].Sentence != "She <%runs%> fast." {
+		t.Fatalf("unexpected exercises %+v", exs)
+	}
+
+	// edit the first one, the sentence without markup is refused
+	m = send(t, m, key("k"))
+	m = send(t, m, key("e"))
+	m = clearInput(m)
+	m = typeText(m, "They run.")
+	if m = press(m, key("enter")); m.err == nil || !m.word.editing {
+		t.Fatal("expected markup error")
+	}
+	m.err = nil
+	m = clearInput(m)
+	m = typeText(m, "They <%run%> home.")
+	m = send(t, m, key("enter"))
+	if exs := vocab.words[0].Exercises; exs[0].Sentence != "They <%run%> home." || exs[0].Answered {
+		t.Errorf("unexpected exercises %+v", exs)
+	}
+
+	m = send(t, m, key("esc"))
+	if m.screen != browseScreen {
+		t.Errorf("unexpected screen %d", m.screen)
+	}
+}
+
+func TestModel_Review(t *testing.T) {
+	t.Parallel()
+
+	parse := func(s string) cloze.Cloze {
+		c, err := cloze.Parse(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	pr := &fakePractice{items: []practice.Item{
+		{Type: practice.Cloze, Word: models.Word{ID: "w1"}, ExerciseIndex: 0, Cloze: parse("She <%ran%> home.")},
+		{Type: practice.Cloze, Word: models.Word{ID: "w2"}, ExerciseIndex: 1, Cloze: parse("We <%went%> out.")},
+	}}
+	m := newTestModel(&fakeVocabulary{}, pr)
+	m = send(t, m, m.Init()())
+
+	m = send(t, m, key("r"))
+	if m.screen != reviewScreen || !strings.Contains(m.View(), "She _____ home.") {
+		t.Fatalf("unexpected view %s", m.View())
+	}
+
+	m = typeText(m, "ran")
+	m = send(t, m, key("enter"))
+	if !strings.Contains(m.View(), "Correct: ran") || m.review.answered() != 1 {
+		t.Errorf("unexpected view %s", m.View())
+	}
+
+	m = send(t, m, key("enter"))
+	m = typeText(m, "go")
+	m = send(t, m, key("enter"))
+	m = send(t, m, key("enter"))
+	if !m.review.done || !strings.Contains(m.View(), "Review finished: 1 of 2 correct.") {
+		t.Errorf("unexpected view %s", m.View())
+	}
+
+	m = send(t, m, key("enter"))
+	if m.screen != browseScreen {
+		t.Errorf("unexpected screen %d", m.screen)
+	}
+	if len(pr.answers) != 2 || pr.answers[0] != "ran" || pr.answers[1] != "go" {
+		t.Errorf("unexpected answers %q", pr.answers)
+	}
+}
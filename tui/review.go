@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pavelpuchok/vocabforge/practice"
+)
+
+type reviewState struct {
+	session practice.Session
+	current int
+	// result is the outcome of the current item, nil until it's answered.
+	result  *practice.Result
+	correct int
+	done    bool
+}
+
+type sessionMsg struct {
+	session practice.Session
+}
+
+type answerMsg struct {
+	result practice.Result
+}
+
+// startReview starts a session of due words of the filtered language, its size and
+// exercise type are the user's preferences.
+func (m model) startReview() (tea.Model, tea.Cmd) {
+	lang := m.browse.languages[m.browse.langIndex]
+	return m, func() tea.Msg {
+		session, err := m.start.Run(m.ctx, m.userID, lang, 0, nil)
+		if err != nil {
+			return errMsg{fmt.Errorf("unable to start review. %w", err)}
+		}
+		return sessionMsg{session}
+	}
+}
+
+func (m model) updateReview(msg tea.Msg) (tea.Model, tea.Cmd) {
+	r := &m.review
+	switch msg := msg.(type) {
+	case answerMsg:
+		r.result = &msg.result
+		if msg.result.Correct {
+			r.correct++
+		}
+		return m, nil
+	case tea.KeyMsg:
+		switch {
+		case msg.String() == "esc" || r.done && msg.String() == "enter":
+			m.input.Blur()
+			m.screen = browseScreen
+			m.status = fmt.Sprintf("Review finished: %d of %d correct.", r.correct, r.answered())
+			return m.reload()
+		case r.result != nil && msg.String() == "enter":
+			r.result = nil
+			r.current++
+			if r.current == len(r.session.Items) {
+				r.done = true
+				m.input.Blur()
+			}
+			m.input.Reset()
+			return m, nil
+		case r.result == nil && msg.String() == "enter":
+			return m, m.submitAnswer(strings.TrimSpace(m.input.Value()))
+		case r.result == nil:
+			var cmd tea.Cmd
+			m.input, cmd = m.input.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+func (m model) submitAnswer(answer string) tea.Cmd {
+	item := m.review.session.Items[m.review.current]
+	return func() tea.Msg {
+		res, err := m.answer.Run(m.ctx, m.userID, item.Word.ID, item.Type, item.ExerciseIndex, answer)
+		if err != nil {
+			return errMsg{fmt.Errorf("unable to answer. %w", err)}
+		}
+		return answerMsg{res}
+	}
+}
+
+func (r reviewState) answered() int {
+	if r.result != nil {
+		return r.current + 1
+	}
+	return r.current
+}
+
+func (m model) viewReview(b *strings.Builder) {
+	r := m.review
+	total := len(r.session.Items)
+
+	b.WriteString(titleStyle.Render("Review") + mutedStyle.Render(fmt.Sprintf("  %d of %d answered, %d correct", r.answered(), total, r.correct)) + "\n\n")
+	b.WriteString(m.bar.ViewAs(float64(r.answered())/float64(total)) + "\n\n")
+
+	if r.done {
+		fmt.Fprintf(b, "Review finished: %d of %d correct.\n\n", r.correct, total)
+		b.WriteString(mutedStyle.Render("enter back") + "\n")
+		return
+	}
+
+	item := r.session.Items[r.current]
+	b.WriteString(item.Prompt() + "\n\n")
+	if r.result == nil {
+		b.WriteString(m.input.View() + "\n\n")
+		b.WriteString(mutedStyle.Render("enter answer • esc stop") + "\n")
+		return
+	}
+
+	if r.result.Correct {
+		b.WriteString(correctStyle.Render("Correct: "+r.result.Expected) + "\n\n")
+	} else {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("Wrong, the answer is %s", r.result.Expected)) + "\n\n")
+	}
+	b.WriteString(mutedStyle.Render("enter next • esc stop") + "\n")
+}
@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/practice/cloze"
+)
+
+type wordState struct {
+	word     models.Word
+	selected int
+	editing  bool
+	// confirmDelete is set while deletion of the selected sentence waits for confirmation.
+	confirmDelete bool
+}
+
+type wordSavedMsg struct {
+	word   models.Word
+	status string
+}
+
+func (m model) updateWord(msg tea.Msg) (tea.Model, tea.Cmd) {
+	w := &m.word
+	switch msg := msg.(type) {
+	case wordSavedMsg:
+		w.word = msg.word
+		w.selected = min(w.selected, max(len(w.word.Exercises)-1, 0))
+		m.status = msg.status
+		return m, nil
+	case tea.KeyMsg:
+		if w.editing {
+			return m.editKey(msg)
+		}
+		if w.confirmDelete {
+			w.confirmDelete = false
+			if msg.String() != "y" {
+				m.status = "Deletion canceled."
+				return m, nil
+			}
+			exercises := slices.Delete(slices.Clone(w.word.Exercises), w.selected, w.selected+1)
+			return m, m.saveExercises(w.word, exercises, "Sentence deleted.")
+		}
+		return m.wordKey(msg)
+	}
+	return m, nil
+}
+
+func (m model) wordKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	w := &m.word
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "esc", "backspace":
+		m.screen = browseScreen
+		m.status = ""
+		// the word might have been changed
+		return m.reload()
+	case "up", "k":
+		w.selected = max(w.selected-1, 0)
+	case "down", "j":
+		w.selected = min(w.selected+1, max(len(w.word.Exercises)-1, 0))
+	case "e", "enter":
+		if len(w.word.Exercises) > 0 {
+			w.editing = true
+			m.input.SetValue(w.word.Exercises[w.selected].Sentence)
+			m.input.CursorEnd()
+			m.input.Focus()
+			m.status = ""
+		}
+	case "d":
+		if len(w.word.Exercises) > 0 {
+			w.confirmDelete = true
+			m.status = "Delete the sentence? y/n"
+		}
+	}
+	return m, nil
+}
+
+// editKey handles keys while the selected sentence is being edited. The sentence must
+// keep exactly one word marked with cloze markup to be saved.
+func (m model) editKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	w := &m.word
+	switch msg.String() {
+	case "esc":
+		w.editing = false
+		m.input.Blur()
+		m.status = "Edit canceled."
+		return m, nil
+	case "enter":
+		sentence := strings.TrimSpace(m.input.Value())
+		if _, err := cloze.Parse(sentence); err != nil {
+			m.err = fmt.Errorf("mark the word with %s and %s. %w", cloze.OpenTag, cloze.CloseTag, err)
+			return m, nil
+		}
+		w.editing = false
+		m.input.Blur()
+
+		exercises := slices.Clone(w.word.Exercises)
+		// it's a new exercise for the learner now
+		exercises[w.selected] = models.SentenceExercise{Sentence: sentence}
+		return m, m.saveExercises(w.word, exercises, "Sentence saved.")
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m model) viewWord(b *strings.Builder) {
+	word := m.word.word
+	b.WriteString(titleStyle.Render(word.Spelling) + mutedStyle.Render(fmt.Sprintf("  %s  %s  %s", word.Language, word.LexicalCategory, learnStatusText(word.LearnStatus))) + "\n")
+	b.WriteString(word.Definition + "\n\n")
+
+	if len(word.Exercises) == 0 {
+		b.WriteString(mutedStyle.Render("No exercises.") + "\n")
+	}
+	for i, e := range word.Exercises {
+		mark := " "
+		if e.Answered {
+			mark = "✓"
+		}
+		line := truncate(fmt.Sprintf("%s %d. %s", mark, i+1, e.Sentence), m.width-2)
+		if i == m.word.selected {
+			if m.word.editing {
+				line = fmt.Sprintf("%s %d. %s", mark, i+1, m.input.View())
+			} else {
+				line = selectedStyle.Render(line)
+			}
+		}
+		b.WriteString(line + "\n")
+	}
+
+	if m.word.editing {
+		b.WriteString("\n" + mutedStyle.Render("enter save • esc cancel") + "\n")
+		return
+	}
+	b.WriteString("\n" + mutedStyle.Render("↑/↓ move • e edit • d delete • esc back • q quit") + "\n")
+}
@@ -2,21 +2,43 @@ package addword
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/pavelpuchok/vocabforge/models"
 )
 
+var ErrMissingLanguage = errors.New("word's language is not given and user has no target language")
+
 type UseCase struct {
 	VocabularyService VocabularyService
+	UsersService      UsersService
 }
 
 type VocabularyService interface {
-	AddWord(ctx context.Context, userID models.UserID, spell, definition, lexicalCategory string, lang models.Language, exercises []models.SentenceExercise) (models.Word, error)
+	AddWord(ctx context.Context, userID models.UserID, spell, definition, lexicalCategory string, lang, definitionLang models.Language, exercises []models.SentenceExercise) (models.Word, error)
+}
+
+type UsersService interface {
+	GetUser(ctx context.Context, id models.UserID) (models.User, error)
 }
 
+// Run adds the word in lang, or in the user's first target language when lang is empty.
+// The definition is expected in the user's native language.
 func (u UseCase) Run(ctx context.Context, userID models.UserID, spell, definition, lexicalCategory string, lang models.Language) (models.Word, error) {
-	word, err := u.VocabularyService.AddWord(ctx, userID, spell, definition, lexicalCategory, lang, nil)
+	usr, err := u.UsersService.GetUser(ctx, userID)
+	if err != nil {
+		return models.Word{}, fmt.Errorf("addword.UseCase.Run unable to get user. %w", err)
+	}
+
+	if lang == "" {
+		if len(usr.TargetLanguages) == 0 {
+			return models.Word{}, fmt.Errorf("addword.UseCase.Run. %w", ErrMissingLanguage)
+		}
+		lang = usr.TargetLanguages[0]
+	}
+
+	word, err := u.VocabularyService.AddWord(ctx, userID, spell, definition, lexicalCategory, lang, usr.NativeLanguage, nil)
 	if err != nil {
 		return word, fmt.Errorf("addword.UseCase.Run unable to add word. %w", err)
 	}
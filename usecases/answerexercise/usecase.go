@@ -0,0 +1,35 @@
+package answerexercise
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/practice"
+)
+
+type UseCase struct {
+	PracticeService PracticeService
+}
+
+type PracticeService interface {
+	Answer(ctx context.Context, userID models.UserID, wordID models.WordID, exerciseIndex int, answer string) (practice.Result, error)
+	AnswerDefinition(ctx context.Context, userID models.UserID, wordID models.WordID, answer string) (practice.Result, error)
+}
+
+// Run checks the answer on the word's exercise of type t. ExerciseIndex is ignored for Definition exercises.
+func (u UseCase) Run(ctx context.Context, userID models.UserID, wordID models.WordID, t practice.ExerciseType, exerciseIndex int, answer string) (practice.Result, error) {
+	var (
+		res practice.Result
+		err error
+	)
+	if t == practice.Definition {
+		res, err = u.PracticeService.AnswerDefinition(ctx, userID, wordID, answer)
+	} else {
+		res, err = u.PracticeService.Answer(ctx, userID, wordID, exerciseIndex, answer)
+	}
+	if err != nil {
+		return res, fmt.Errorf("answerexercise.UseCase.Run unable to answer. %w", err)
+	}
+	return res, nil
+}
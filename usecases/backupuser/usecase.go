@@ -0,0 +1,53 @@
+package backupuser
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/backup"
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+)
+
+type UseCase struct {
+	UsersService      UsersService
+	VocabularyService VocabularyService
+}
+
+type UsersService interface {
+	GetUser(ctx context.Context, id models.UserID) (models.User, error)
+}
+
+type VocabularyService interface {
+	ListWords(ctx context.Context, userID models.UserID, filter vocabulary.ListFilter) (vocabulary.WordsPage, error)
+}
+
+// Run writes the user's profile and words, archived ones included, to w. It returns
+// the number of words backed up.
+func (u UseCase) Run(ctx context.Context, userID models.UserID, w io.Writer, format backup.Format, now time.Time) (int, error) {
+	usr, err := u.UsersService.GetUser(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("backupuser.UseCase.Run unable to get user. %w", err)
+	}
+
+	var words []models.Word
+	filter := vocabulary.ListFilter{IncludeArchived: true, Limit: vocabulary.MaxListLimit}
+	for {
+		page, err := u.VocabularyService.ListWords(ctx, userID, filter)
+		if err != nil {
+			return 0, fmt.Errorf("backupuser.UseCase.Run unable to list words. %w", err)
+		}
+		words = append(words, page.Words...)
+		if page.NextCursor == "" {
+			break
+		}
+		filter.Cursor = page.NextCursor
+	}
+
+	if err := backup.Write(w, backup.New(usr, words, now), format); err != nil {
+		return 0, fmt.Errorf("backupuser.UseCase.Run unable to write archive. %w", err)
+	}
+	return len(words), nil
+}
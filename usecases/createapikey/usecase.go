@@ -0,0 +1,31 @@
+package createapikey
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/users"
+)
+
+type UseCase struct {
+	UsersService UsersService
+}
+
+type UsersService interface {
+	GetUser(ctx context.Context, id models.UserID) (models.User, error)
+	CreateAPIKey(ctx context.Context, userID models.UserID, name string, scope users.Scope) (users.Credential, string, error)
+}
+
+// Run issues an API key for an existing user and returns it along with the token.
+func (u UseCase) Run(ctx context.Context, userID models.UserID, name string, scope users.Scope) (users.Credential, string, error) {
+	if _, err := u.UsersService.GetUser(ctx, userID); err != nil {
+		return users.Credential{}, "", fmt.Errorf("createapikey.UseCase.Run unable to get user. %w", err)
+	}
+
+	key, token, err := u.UsersService.CreateAPIKey(ctx, userID, name, scope)
+	if err != nil {
+		return key, "", fmt.Errorf("createapikey.UseCase.Run unable to create API key. %w", err)
+	}
+	return key, token, nil
+}
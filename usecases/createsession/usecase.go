@@ -0,0 +1,28 @@
+package createsession
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/users"
+)
+
+type UseCase struct {
+	UsersService UsersService
+	// TTL is the lifetime of issued sessions.
+	TTL time.Duration
+}
+
+type UsersService interface {
+	CreateSession(ctx context.Context, p users.Principal, ttl time.Duration) (users.Credential, string, error)
+}
+
+// Run issues a session token with the same user and scope as the principal.
+func (u UseCase) Run(ctx context.Context, p users.Principal) (users.Credential, string, error) {
+	session, token, err := u.UsersService.CreateSession(ctx, p, u.TTL)
+	if err != nil {
+		return session, "", fmt.Errorf("createsession.UseCase.Run unable to create session. %w", err)
+	}
+	return session, token, nil
+}
@@ -12,11 +12,11 @@ type UseCase struct {
 }
 
 type UsersService interface {
-	Create(ctx context.Context) (models.User, error)
+	Create(ctx context.Context, profile models.User) (models.User, error)
 }
 
-func (u UseCase) Run(ctx context.Context) (models.User, error) {
-	usr, err := u.UsersService.Create(ctx)
+func (u UseCase) Run(ctx context.Context, profile models.User) (models.User, error) {
+	usr, err := u.UsersService.Create(ctx, profile)
 	if err != nil {
 		return usr, fmt.Errorf("create_user.UseCase.Run unable to create user. %w", err)
 	}
@@ -0,0 +1,32 @@
+package deleteword
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pavelpuchok/vocabforge/models"
+)
+
+type UseCase struct {
+	VocabularyService VocabularyService
+}
+
+type VocabularyService interface {
+	ArchiveWord(ctx context.Context, userID models.UserID, wordID models.WordID) (models.Word, error)
+	DeleteWord(ctx context.Context, userID models.UserID, wordID models.WordID) error
+}
+
+// Run deletes the word or, when archive is set, only hides it from listings.
+func (u UseCase) Run(ctx context.Context, userID models.UserID, wordID models.WordID, archive bool) error {
+	if archive {
+		if _, err := u.VocabularyService.ArchiveWord(ctx, userID, wordID); err != nil {
+			return fmt.Errorf("deleteword.UseCase.Run unable to archive word. %w", err)
+		}
+		return nil
+	}
+
+	if err := u.VocabularyService.DeleteWord(ctx, userID, wordID); err != nil {
+		return fmt.Errorf("deleteword.UseCase.Run unable to delete word. %w", err)
+	}
+	return nil
+}
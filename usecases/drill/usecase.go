@@ -0,0 +1,161 @@
+package drill
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/practice"
+	"github.com/pavelpuchok/vocabforge/usecases/answerexercise"
+	"github.com/pavelpuchok/vocabforge/usecases/startpractice"
+)
+
+// QuitCommand ends a session before all exercises are answered.
+const QuitCommand = ":q"
+
+type UseCase struct {
+	PracticeService PracticeService
+	UsersService    UsersService
+	In              io.Reader
+	Out             io.Writer
+}
+
+type PracticeService interface {
+	startpractice.PracticeService
+	answerexercise.PracticeService
+}
+
+type UsersService = startpractice.UsersService
+
+type Summary struct {
+	Total   int
+	Correct int
+	Wrong   int
+	Learned int
+	// Mistakes are expected answers of wrongly answered exercises.
+	Mistakes []string
+}
+
+// Run drills a session of due words interactively, reading answers from In line by line. When ctx
+// is done, for ex: the user presses Ctrl+C, the session ends as if they quit. Zero size and nil t
+// are taken from the user's preferences.
+func (u UseCase) Run(ctx context.Context, userID models.UserID, lang models.Language, size int, t *practice.ExerciseType) (Summary, error) {
+	start := startpractice.UseCase{PracticeService: u.PracticeService, UsersService: u.UsersService}
+	session, err := start.Run(ctx, userID, lang, size, t)
+	if err != nil {
+		return Summary{}, fmt.Errorf("drill.UseCase.Run unable to start session. %w", err)
+	}
+
+	if len(session.Items) == 0 {
+		u.printf("Nothing to practice right now.\n")
+		return Summary{}, nil
+	}
+
+	u.printf("Practice session: %d exercises. Type the missing word, %s to quit.\n", len(session.Items), QuitCommand)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	lines := scanLines(u.In, stop)
+
+	answer := answerexercise.UseCase{PracticeService: u.PracticeService}
+	var summary Summary
+	for i, item := range session.Items {
+		u.printf("\n[%d/%d] %s\n> ", i+1, len(session.Items), item.Prompt())
+		text, ok, err := u.readAnswer(ctx, lines)
+		if err != nil {
+			return summary, fmt.Errorf("drill.UseCase.Run unable to read answer. %w", err)
+		}
+		if !ok {
+			break
+		}
+
+		res, err := answer.Run(ctx, session.UserID, item.Word.ID, item.Type, item.ExerciseIndex, text)
+		if err != nil && ctx.Err() != nil {
+			// interrupted while the answer was being checked, it's not counted
+			break
+		}
+		if err != nil {
+			return summary, fmt.Errorf("drill.UseCase.Run unable to answer. %w", err)
+		}
+
+		summary.Total++
+		if res.Correct {
+			summary.Correct++
+			u.printf("Correct! (%s)\n", res.Expected)
+		} else {
+			summary.Wrong++
+			summary.Mistakes = append(summary.Mistakes, res.Expected)
+			u.printf("Wrong. Correct answer: %s\n", res.Expected)
+		}
+		if item.Type == practice.Cloze {
+			u.printf("%s\n", item.Cloze.Text())
+		}
+		if res.Word.LearnStatus == models.Learned && item.Word.LearnStatus != models.Learned {
+			summary.Learned++
+		}
+	}
+
+	u.printf("\nSession finished: %d answered, %d correct, %d wrong, %d learned.\n", summary.Total, summary.Correct, summary.Wrong, summary.Learned)
+	if len(summary.Mistakes) > 0 {
+		u.printf("Review these: %s\n", strings.Join(summary.Mistakes, ", "))
+	}
+	return summary, nil
+}
+
+// line is a line of the input, or the error reading it failed with.
+type line struct {
+	text string
+	err  error
+}
+
+// scanLines reads r line by line in a goroutine, so waiting for the user's input doesn't block
+// the session from ending. The goroutine stops once stop is closed, or it may stay blocked on r
+// till the process exits, as reading from a terminal can't be interrupted.
+func scanLines(r io.Reader, stop <-chan struct{}) <-chan line {
+	lines := make(chan line)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			select {
+			case lines <- line{text: scanner.Text()}:
+			case <-stop:
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			select {
+			case lines <- line{err: err}:
+			case <-stop:
+			}
+		}
+	}()
+	return lines
+}
+
+// readAnswer waits for the next answer. It returns false when the session is to end: the user
+// quit, the input ended or ctx is done.
+func (u UseCase) readAnswer(ctx context.Context, lines <-chan line) (string, bool, error) {
+	select {
+	case <-ctx.Done():
+		u.printf("\n")
+		return "", false, nil
+	case l, ok := <-lines:
+		if !ok {
+			return "", false, nil
+		}
+		if l.err != nil {
+			return "", false, l.err
+		}
+		answer := strings.TrimSpace(l.text)
+		return answer, answer != QuitCommand, nil
+	}
+}
+
+func (u UseCase) printf(format string, args ...any) {
+	// output errors are not actionable in an interactive session
+	_, _ = fmt.Fprintf(u.Out, format, args...)
+}
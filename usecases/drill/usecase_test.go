@@ -0,0 +1,161 @@
+package drill
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/practice"
+)
+
+const testUserID = "000000000000000000000001"
+
+// fakePractice checks answers against the spelling, a correct answer makes the word learned.
+// afterAnswer, when set, is called after each answer. When blocked, answering waits for ctx to
+// be done.
+type fakePractice struct {
+	words       []models.Word
+	afterAnswer func()
+	blocked     bool
+}
+
+func (f *fakePractice) StartSession(_ context.Context, userID models.UserID, _ models.Language, _ int, t practice.ExerciseType) (practice.Session, error) {
+	session := practice.Session{UserID: userID}
+	for _, w := range f.words {
+		session.Items = append(session.Items, practice.Item{Type: t, Word: w})
+	}
+	return session, nil
+}
+
+func (f *fakePractice) Answer(context.Context, models.UserID, models.WordID, int, string) (practice.Result, error) {
+	panic("cloze exercises aren't drilled in tests")
+}
+
+func (f *fakePractice) AnswerDefinition(ctx context.Context, _ models.UserID, wordID models.WordID, answer string) (practice.Result, error) {
+	if f.blocked {
+		<-ctx.Done()
+		return practice.Result{}, ctx.Err()
+	}
+	if f.afterAnswer != nil {
+		defer f.afterAnswer()
+	}
+	for _, w := range f.words {
+		if w.ID != wordID {
+			continue
+		}
+		res := practice.Result{Correct: answer == w.Spelling, Expected: w.Spelling, Word: w}
+		if res.Correct {
+			res.Word.LearnStatus = models.Learned
+		}
+		return res, nil
+	}
+	return practice.Result{}, nil
+}
+
+// fakeUsers returns a user who practices definitions.
+type fakeUsers struct{}
+
+func (fakeUsers) GetUser(_ context.Context, id models.UserID) (models.User, error) {
+	return models.User{ID: id, ExerciseTypes: []models.ExerciseType{practice.Definition}}, nil
+}
+
+var testWords = []models.Word{
+	{ID: "66f1a2b3c4d5e6f708091a21", Spelling: "run", Definition: "move fast", LearnStatus: models.InProgress},
+	{ID: "66f1a2b3c4d5e6f708091a22", Spelling: "walk", Definition: "move slowly", LearnStatus: models.InProgress},
+	{ID: "66f1a2b3c4d5e6f708091a23", Spelling: "swim", Definition: "move in water", LearnStatus: models.InProgress},
+}
+
+func TestUseCase_Run(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		in       string
+		expected Summary
+	}{
+		{
+			name:     "all answered",
+			in:       "run\n walk \nfly\n",
+			expected: Summary{Total: 3, Correct: 2, Wrong: 1, Learned: 2, Mistakes: []string{"swim"}},
+		},
+		{
+			name:     "quit",
+			in:       "run\n" + QuitCommand + "\nwalk\n",
+			expected: Summary{Total: 1, Correct: 1, Learned: 1},
+		},
+		{
+			name:     "input ends",
+			in:       "jog",
+			expected: Summary{Total: 1, Wrong: 1, Mistakes: []string{"run"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+			u := UseCase{UsersService: fakeUsers{}, PracticeService: &fakePractice{words: testWords}, In: strings.NewReader(tt.in), Out: &out}
+			summary, err := u.Run(context.Background(), testUserID, "en", 0, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.expected, summary); diff != "" {
+				t.Errorf("unexpected summary (-want +got):\n%s", diff)
+			}
+			if !strings.Contains(out.String(), "Session finished") {
+				t.Errorf("expected session summary in output, got %q", out.String())
+			}
+		})
+	}
+}
+
+func TestUseCase_Run_Interrupted(t *testing.T) {
+	t.Parallel()
+
+	t.Run("waiting for input", func(t *testing.T) {
+		t.Parallel()
+
+		// the input never ends, as a terminal nobody types in
+		in, w := io.Pipe()
+		t.Cleanup(func() { w.Close() })
+		go func() {
+			_, _ = w.Write([]byte("run\n"))
+		}()
+		// Ctrl+C is pressed after the first answer
+		ctx, cancel := context.WithCancel(context.Background())
+
+		var out bytes.Buffer
+		u := UseCase{UsersService: fakeUsers{}, PracticeService: &fakePractice{words: testWords, afterAnswer: cancel}, In: in, Out: &out}
+		summary, err := u.Run(ctx, testUserID, "en", 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if summary.Total != 1 || summary.Correct != 1 {
+			t.Errorf("unexpected summary %+v", summary)
+		}
+		if !strings.Contains(out.String(), "Session finished: 1 answered") {
+			t.Errorf("expected session summary in output, got %q", out.String())
+		}
+	})
+
+	t.Run("checking answer", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var out bytes.Buffer
+		u := UseCase{UsersService: fakeUsers{}, PracticeService: &fakePractice{words: testWords, blocked: true}, In: strings.NewReader("run\n"), Out: &out}
+		summary, err := u.Run(ctx, testUserID, "en", 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if summary.Total != 0 {
+			t.Errorf("expected the interrupted answer not to be counted, got %+v", summary)
+		}
+	})
+}
@@ -0,0 +1,25 @@
+package editword
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+)
+
+type UseCase struct {
+	VocabularyService VocabularyService
+}
+
+type VocabularyService interface {
+	UpdateWord(ctx context.Context, userID models.UserID, wordID models.WordID, patch vocabulary.WordPatch, regenerateExercises bool) (models.Word, error)
+}
+
+func (u UseCase) Run(ctx context.Context, userID models.UserID, wordID models.WordID, patch vocabulary.WordPatch, regenerateExercises bool) (models.Word, error) {
+	word, err := u.VocabularyService.UpdateWord(ctx, userID, wordID, patch, regenerateExercises)
+	if err != nil {
+		return word, fmt.Errorf("editword.UseCase.Run unable to update word. %w", err)
+	}
+	return word, nil
+}
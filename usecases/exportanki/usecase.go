@@ -0,0 +1,56 @@
+package exportanki
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/anki"
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+)
+
+type UseCase struct {
+	VocabularyService VocabularyService
+}
+
+type VocabularyService interface {
+	ListWords(ctx context.Context, userID models.UserID, filter vocabulary.ListFilter) (vocabulary.WordsPage, error)
+}
+
+// Run writes the user's words, of lang only if it isn't empty, to w as an Anki package
+// with a cloze note per exercise. Archived words are left out. It returns the number of
+// words written.
+func (u UseCase) Run(ctx context.Context, userID models.UserID, lang models.Language, deckName string, w io.Writer, now time.Time) (int, error) {
+	var notes []anki.Note
+	words := 0
+	filter := vocabulary.ListFilter{Language: lang, Limit: vocabulary.MaxListLimit}
+	for {
+		page, err := u.VocabularyService.ListWords(ctx, userID, filter)
+		if err != nil {
+			return 0, fmt.Errorf("exportanki.UseCase.Run unable to list words. %w", err)
+		}
+		for _, word := range page.Words {
+			n, err := anki.WordNotes(word)
+			if err != nil {
+				return 0, fmt.Errorf("exportanki.UseCase.Run unable to make notes of word %s. %w", word.ID, err)
+			}
+			notes = append(notes, n...)
+		}
+		words += len(page.Words)
+
+		if page.NextCursor == "" {
+			break
+		}
+		filter.Cursor = page.NextCursor
+	}
+
+	if deckName == "" {
+		deckName = anki.DefaultDeckName
+	}
+	if err := anki.Write(ctx, w, deckName, notes, now); err != nil {
+		return 0, fmt.Errorf("exportanki.UseCase.Run unable to write package. %w", err)
+	}
+	return words, nil
+}
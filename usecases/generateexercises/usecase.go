@@ -0,0 +1,24 @@
+package generateexercises
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pavelpuchok/vocabforge/models"
+)
+
+type UseCase struct {
+	VocabularyService VocabularyService
+}
+
+type VocabularyService interface {
+	RegenerateExercises(ctx context.Context, userID models.UserID, wordID models.WordID) (models.Word, error)
+}
+
+func (u UseCase) Run(ctx context.Context, userID models.UserID, wordID models.WordID) (models.Word, error) {
+	word, err := u.VocabularyService.RegenerateExercises(ctx, userID, wordID)
+	if err != nil {
+		return word, fmt.Errorf("generateexercises.UseCase.Run unable to generate exercises. %w", err)
+	}
+	return word, nil
+}
@@ -0,0 +1,24 @@
+package getuser
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pavelpuchok/vocabforge/models"
+)
+
+type UseCase struct {
+	UsersService UsersService
+}
+
+type UsersService interface {
+	GetUser(ctx context.Context, id models.UserID) (models.User, error)
+}
+
+func (u UseCase) Run(ctx context.Context, userID models.UserID) (models.User, error) {
+	usr, err := u.UsersService.GetUser(ctx, userID)
+	if err != nil {
+		return usr, fmt.Errorf("getuser.UseCase.Run unable to get user. %w", err)
+	}
+	return usr, nil
+}
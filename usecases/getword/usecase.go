@@ -0,0 +1,24 @@
+package getword
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pavelpuchok/vocabforge/models"
+)
+
+type UseCase struct {
+	VocabularyService VocabularyService
+}
+
+type VocabularyService interface {
+	GetWord(ctx context.Context, userID models.UserID, wordID models.WordID) (models.Word, error)
+}
+
+func (u UseCase) Run(ctx context.Context, userID models.UserID, wordID models.WordID) (models.Word, error) {
+	word, err := u.VocabularyService.GetWord(ctx, userID, wordID)
+	if err != nil {
+		return word, fmt.Errorf("getword.UseCase.Run unable to get word. %w", err)
+	}
+	return word, nil
+}
@@ -0,0 +1,38 @@
+package importanki
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pavelpuchok/vocabforge/usecases/importwords"
+)
+
+// PrintReport prints a line per word and the totals.
+func PrintReport(w io.Writer, reports []WordReport) error {
+	counts := map[importwords.Status]int{}
+	for _, r := range reports {
+		counts[r.Status]++
+
+		line := fmt.Sprintf("%s %q", r.Status, r.Spelling)
+		if r.Language != "" {
+			line += " (" + r.Language.String() + ")"
+		}
+		line += " from notes " + strings.Join(r.Notes, ", ")
+		if r.Word.ID != "" {
+			line += fmt.Sprintf(" as %s with %d exercises, %s", r.Word.ID, len(r.Word.Exercises), r.Word.LearnStatus.String())
+		}
+		if r.Reason != "" {
+			line += ": " + r.Reason
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return fmt.Errorf("importanki.PrintReport. %w", err)
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "imported %d, skipped %d, rejected %d\n", counts[importwords.Imported], counts[importwords.Skipped], counts[importwords.Rejected])
+	if err != nil {
+		return fmt.Errorf("importanki.PrintReport. %w", err)
+	}
+	return nil
+}
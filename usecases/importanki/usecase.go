@@ -0,0 +1,176 @@
+package importanki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/pavelpuchok/vocabforge/anki"
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/usecases/importwords"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+)
+
+type UseCase struct {
+	VocabularyService VocabularyService
+	UsersService      UsersService
+}
+
+type VocabularyService interface {
+	ImportWord(ctx context.Context, userID models.UserID, w models.Word) (models.Word, error)
+	ListWords(ctx context.Context, userID models.UserID, filter vocabulary.ListFilter) (vocabulary.WordsPage, error)
+}
+
+type UsersService interface {
+	GetUser(ctx context.Context, id models.UserID) (models.User, error)
+}
+
+// WordReport is the outcome of a word, or of a note that isn't read as a word.
+type WordReport struct {
+	Spelling string
+	Language models.Language
+	// Notes are GUIDs of the notes the word is read from.
+	Notes  []string
+	Status importwords.Status
+	// Reason explains why the word was skipped or rejected.
+	Reason string
+	// Word is set for imported words.
+	Word models.Word
+}
+
+type word struct {
+	// report is the index of the word's report
+	report    int
+	entry     anki.Entry
+	language  models.Language
+	sentences []string
+	tags      []string
+	cards     []anki.Card
+}
+
+// Run imports words from the Anki package. Notes of a word, one per sentence in exported
+// packages, are merged into a single word with an exercise per sentence. Words without
+// sentences get exercises generated. Notes without language are imported in lang, or in
+// the user's first target language when lang is empty. The review state of the cards
+// becomes the word's learn status and schedule.
+func (u UseCase) Run(ctx context.Context, userID models.UserID, r io.ReaderAt, size int64, lang models.Language) ([]WordReport, error) {
+	usr, err := u.UsersService.GetUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("importanki.UseCase.Run unable to get user. %w", err)
+	}
+	if lang == "" && len(usr.TargetLanguages) > 0 {
+		lang = usr.TargetLanguages[0]
+	}
+
+	notes, err := anki.Read(ctx, r, size)
+	if err != nil {
+		return nil, fmt.Errorf("importanki.UseCase.Run unable to read package. %w", err)
+	}
+
+	var reports []WordReport
+	var words []*word
+	byKey := map[string]*word{}
+	for _, n := range notes {
+		rep := WordReport{Notes: []string{n.GUID}, Spelling: anki.StripHTML(n.Fields[anki.FieldSpelling])}
+		e, err := n.Entry()
+		if err != nil {
+			rep.Status, rep.Reason = importwords.Rejected, err.Error()
+			reports = append(reports, rep)
+			continue
+		}
+		rep.Spelling = e.Spelling
+
+		wordLang, err := entryLanguage(e, lang)
+		if err != nil {
+			rep.Status, rep.Reason = importwords.Rejected, err.Error()
+			reports = append(reports, rep)
+			continue
+		}
+
+		key := strings.ToLower(e.Spelling) + "\x00" + string(wordLang)
+		w, ok := byKey[key]
+		if !ok {
+			w = &word{report: len(reports), entry: e, language: wordLang}
+			byKey[key] = w
+			words = append(words, w)
+			reports = append(reports, WordReport{Spelling: e.Spelling, Language: wordLang})
+		}
+		w.merge(e, n.Cards)
+		reports[w.report].Notes = append(reports[w.report].Notes, n.GUID)
+	}
+
+	for _, w := range words {
+		rep := &reports[w.report]
+		rep.Status, rep.Reason, rep.Word = u.importWord(ctx, usr, w)
+	}
+	return reports, nil
+}
+
+func (w *word) merge(e anki.Entry, cards []anki.Card) {
+	if e.Sentence != "" && !slices.Contains(w.sentences, e.Sentence) {
+		w.sentences = append(w.sentences, e.Sentence)
+	}
+	for _, t := range e.Tags {
+		if !slices.Contains(w.tags, t) {
+			w.tags = append(w.tags, t)
+		}
+	}
+	w.cards = append(w.cards, cards...)
+}
+
+func (u UseCase) importWord(ctx context.Context, usr models.User, w *word) (importwords.Status, string, models.Word) {
+	page, err := u.VocabularyService.ListWords(ctx, usr.ID, vocabulary.ListFilter{
+		Language:        w.language,
+		Spelling:        w.entry.Spelling,
+		IncludeArchived: true,
+		Limit:           1,
+	})
+	if err != nil {
+		return importwords.Rejected, fmt.Sprintf("unable to look for duplicates: %s", err), models.Word{}
+	}
+	if len(page.Words) > 0 {
+		return importwords.Skipped, "already in vocabulary", models.Word{}
+	}
+
+	var exercises []models.SentenceExercise
+	for _, s := range w.sentences {
+		exercises = append(exercises, models.SentenceExercise{Sentence: s})
+	}
+	status, schedule := anki.ReviewState(w.cards)
+	added, err := u.VocabularyService.ImportWord(ctx, usr.ID, models.Word{
+		Spelling:           w.entry.Spelling,
+		Definition:         w.entry.Definition,
+		LexicalCategory:    w.entry.LexicalCategory,
+		Language:           w.language,
+		DefinitionLanguage: usr.NativeLanguage,
+		LearnStatus:        status,
+		Exercises:          exercises,
+		Schedule:           schedule,
+		Tags:               w.tags,
+	})
+	if errors.Is(err, vocabulary.ErrDuplicateWord) {
+		// added since it was looked for
+		return importwords.Skipped, "already in vocabulary", models.Word{}
+	}
+	if err != nil {
+		return importwords.Rejected, err.Error(), models.Word{}
+	}
+	return importwords.Imported, "", added
+}
+
+func entryLanguage(e anki.Entry, lang models.Language) (models.Language, error) {
+	if e.Language == "" {
+		if lang == "" {
+			return "", fmt.Errorf("%w: no language and user has no target language", anki.ErrInvalidNote)
+		}
+		return lang, nil
+	}
+	l, err := models.LanguageFromText(e.Language)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", anki.ErrInvalidNote, err)
+	}
+	return l, nil
+}
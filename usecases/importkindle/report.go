@@ -0,0 +1,46 @@
+package importkindle
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pavelpuchok/vocabforge/usecases/importwords"
+)
+
+// PrintReport prints a line per word and the totals.
+func PrintReport(w io.Writer, reports []WordReport) error {
+	counts := map[importwords.Status]int{}
+	for _, r := range reports {
+		counts[r.Status]++
+
+		line := fmt.Sprintf("%s %q", r.Status, r.Spelling)
+		if r.Language != "" {
+			line += " (" + r.Language.String() + ")"
+		}
+		line += fmt.Sprintf(" looked up %d times", r.Lookups)
+		if r.Source != "" {
+			line += fmt.Sprintf(" in %q", r.Source)
+		}
+		if r.Word.ID != "" {
+			line += fmt.Sprintf(" as %s with %d exercises", r.Word.ID, len(r.Word.Exercises))
+		}
+		if r.Reason != "" {
+			line += ": " + r.Reason
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return fmt.Errorf("importkindle.PrintReport. %w", err)
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "imported %d, skipped %d, rejected %d\n", counts[importwords.Imported], counts[importwords.Skipped], counts[importwords.Rejected])
+	if err != nil {
+		return fmt.Errorf("importkindle.PrintReport. %w", err)
+	}
+	if counts[importwords.Imported] > 0 {
+		// vocab.db has no definitions
+		if _, err := fmt.Fprintln(w, "imported words have no definitions, add them with edit-word"); err != nil {
+			return fmt.Errorf("importkindle.PrintReport. %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package importkindle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pavelpuchok/vocabforge/kindle"
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/usecases/importwords"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+)
+
+var ErrMissingLanguage = errors.New("looked up word has no language and user has no target language")
+
+type UseCase struct {
+	VocabularyService VocabularyService
+	UsersService      UsersService
+}
+
+type VocabularyService interface {
+	ImportWord(ctx context.Context, userID models.UserID, w models.Word) (models.Word, error)
+	ListWords(ctx context.Context, userID models.UserID, filter vocabulary.ListFilter) (vocabulary.WordsPage, error)
+}
+
+type UsersService interface {
+	GetUser(ctx context.Context, id models.UserID) (models.User, error)
+}
+
+// WordReport is the outcome of a looked up word.
+type WordReport struct {
+	Spelling string
+	Language models.Language
+	// Source is the title of the book the word was first looked up in.
+	Source string
+	// Lookups is the number of times the word was looked up.
+	Lookups int
+	Status  importwords.Status
+	// Reason explains why the word was skipped or rejected.
+	Reason string
+	// Word is set for imported words.
+	Word models.Word
+}
+
+type word struct {
+	// report is the index of the word's report
+	report    int
+	spelling  string
+	language  models.Language
+	source    string
+	sentences []string
+	mastered  bool
+}
+
+// Run imports words looked up on a Kindle from its vocab.db at path. Lookups of a word
+// are merged into a single word, the book sentences it was looked up in become its
+// exercises and the title of the first book becomes its source. Words the user marked as
+// mastered on the Kindle are skipped. The database has no definitions, so the words are
+// added without them: no exercises are generated besides the book sentences, and the
+// words aren't practiced by definition until one is set.
+func (u UseCase) Run(ctx context.Context, userID models.UserID, path string, lang models.Language) ([]WordReport, error) {
+	usr, err := u.UsersService.GetUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("importkindle.UseCase.Run unable to get user. %w", err)
+	}
+	if lang == "" && len(usr.TargetLanguages) > 0 {
+		lang = usr.TargetLanguages[0]
+	}
+
+	lookups, err := kindle.Read(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("importkindle.UseCase.Run unable to read lookups. %w", err)
+	}
+
+	var reports []WordReport
+	var words []*word
+	byKey := map[string]*word{}
+	for _, l := range lookups {
+		spelling := strings.TrimSpace(l.Spelling())
+		wordLang, err := lookupLanguage(l, lang)
+		if err != nil {
+			reports = append(reports, WordReport{Spelling: spelling, Source: l.BookTitle, Lookups: 1, Status: importwords.Rejected, Reason: err.Error()})
+			continue
+		}
+
+		key := strings.ToLower(spelling) + "\x00" + string(wordLang)
+		w, ok := byKey[key]
+		if !ok {
+			w = &word{report: len(reports), spelling: spelling, language: wordLang}
+			byKey[key] = w
+			words = append(words, w)
+			reports = append(reports, WordReport{Spelling: spelling, Language: wordLang})
+		}
+		w.merge(l)
+		reports[w.report].Lookups++
+		reports[w.report].Source = w.source
+	}
+
+	for _, w := range words {
+		rep := &reports[w.report]
+		rep.Status, rep.Reason, rep.Word = u.importWord(ctx, usr, w)
+	}
+	return reports, nil
+}
+
+func (w *word) merge(l kindle.Lookup) {
+	if w.source == "" {
+		w.source = l.BookTitle
+	}
+	w.mastered = w.mastered || l.Mastered
+	// the word isn't always in the sentence as it was looked up, for ex: with OCR'ed books
+	if sentence, err := l.Sentence(); err == nil && !slices.Contains(w.sentences, sentence) {
+		w.sentences = append(w.sentences, sentence)
+	}
+}
+
+func (u UseCase) importWord(ctx context.Context, usr models.User, w *word) (importwords.Status, string, models.Word) {
+	if w.mastered {
+		return importwords.Skipped, "mastered on Kindle", models.Word{}
+	}
+	if w.spelling == "" {
+		return importwords.Rejected, "empty spelling", models.Word{}
+	}
+
+	page, err := u.VocabularyService.ListWords(ctx, usr.ID, vocabulary.ListFilter{
+		Language:        w.language,
+		Spelling:        w.spelling,
+		IncludeArchived: true,
+		Limit:           1,
+	})
+	if err != nil {
+		return importwords.Rejected, fmt.Sprintf("unable to look for duplicates: %s", err), models.Word{}
+	}
+	if len(page.Words) > 0 {
+		return importwords.Skipped, "already in vocabulary", models.Word{}
+	}
+
+	var exercises []models.SentenceExercise
+	for _, s := range w.sentences {
+		exercises = append(exercises, models.SentenceExercise{Sentence: s})
+	}
+	added, err := u.VocabularyService.ImportWord(ctx, usr.ID, models.Word{
+		Spelling:           w.spelling,
+		Language:           w.language,
+		DefinitionLanguage: usr.NativeLanguage,
+		Exercises:          exercises,
+		Source:             w.source,
+	})
+	if errors.Is(err, vocabulary.ErrDuplicateWord) {
+		// added since it was looked for
+		return importwords.Skipped, "already in vocabulary", models.Word{}
+	}
+	if err != nil {
+		return importwords.Rejected, err.Error(), models.Word{}
+	}
+	return importwords.Imported, "", added
+}
+
+func lookupLanguage(l kindle.Lookup, lang models.Language) (models.Language, error) {
+	if l.Language == "" {
+		if lang == "" {
+			return "", ErrMissingLanguage
+		}
+		return lang, nil
+	}
+	parsed, err := models.LanguageFromText(l.Language)
+	if err != nil {
+		return "", err
+	}
+	return parsed, nil
+}
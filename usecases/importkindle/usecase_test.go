@@ -0,0 +1,118 @@
+package importkindle
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/usecases/importwords"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+)
+
+const testUserID = "000000000000000000000001"
+
+// vocabDB has run looked up three times in two books, once with a sentence seen already, go
+// marked as mastered, house which is in the vocabulary already and a word without language.
+const vocabDB = `
+CREATE TABLE WORDS (id TEXT PRIMARY KEY NOT NULL, word TEXT, stem TEXT, lang TEXT, category INTEGER DEFAULT 0, timestamp INTEGER DEFAULT 0, profileid TEXT);
+CREATE TABLE LOOKUPS (id TEXT PRIMARY KEY NOT NULL, word_key TEXT, book_key TEXT, dict_key TEXT, pos TEXT, usage TEXT, timestamp INTEGER DEFAULT 0);
+CREATE TABLE BOOK_INFO (id TEXT PRIMARY KEY NOT NULL, asin TEXT, guid TEXT, lang TEXT, title TEXT, authors TEXT);
+INSERT INTO WORDS VALUES ('en:ran', 'ran', 'run', 'en', 0, 0, '');
+INSERT INTO WORDS VALUES ('en:runs', 'runs', 'run', 'en', 0, 0, '');
+INSERT INTO WORDS VALUES ('en:went', 'went', 'go', 'en', 100, 0, '');
+INSERT INTO WORDS VALUES ('en:House', 'House', 'House', 'en', 0, 0, '');
+INSERT INTO WORDS VALUES ('xx:word', 'word', '', '', 0, 0, '');
+INSERT INTO BOOK_INFO VALUES ('b1', 'B001', 'g1', 'en', 'Book One', 'Author');
+INSERT INTO BOOK_INFO VALUES ('b2', 'B002', 'g2', 'en', 'Book Two', 'Author');
+INSERT INTO LOOKUPS VALUES ('l1', 'en:ran', 'b1', '', '', 'She ran home.', 1);
+INSERT INTO LOOKUPS VALUES ('l2', 'en:went', 'b1', '', '', 'We went out.', 2);
+INSERT INTO LOOKUPS VALUES ('l3', 'en:runs', 'b2', '', '', 'He runs daily.', 3);
+INSERT INTO LOOKUPS VALUES ('l4', 'en:ran', 'b2', '', '', 'She ran home.', 4);
+INSERT INTO LOOKUPS VALUES ('l5', 'en:House', 'b1', '', '', 'The House is big.', 5);
+INSERT INTO LOOKUPS VALUES ('l6', 'xx:word', 'b1', '', '', 'A word.', 6);
+`
+
+type fakeVocabulary struct {
+	words []models.Word
+}
+
+func (f *fakeVocabulary) ImportWord(_ context.Context, userID models.UserID, w models.Word) (models.Word, error) {
+	w.ID = models.WordID("00000000000000000000000" + string(rune('a'+len(f.words))))
+	w.UserID = userID
+	f.words = append(f.words, w)
+	return w, nil
+}
+
+func (f *fakeVocabulary) ListWords(_ context.Context, _ models.UserID, filter vocabulary.ListFilter) (vocabulary.WordsPage, error) {
+	var page vocabulary.WordsPage
+	for _, w := range f.words {
+		if w.Language == filter.Language && w.Spelling == filter.Spelling {
+			page.Words = append(page.Words, w)
+		}
+	}
+	return page, nil
+}
+
+type fakeUsers struct{}
+
+func (fakeUsers) GetUser(_ context.Context, id models.UserID) (models.User, error) {
+	return models.User{ID: id, NativeLanguage: "ru"}, nil
+}
+
+func TestUseCase_Run(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "vocab.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(vocabDB); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	vocab := &fakeVocabulary{words: []models.Word{{ID: "000000000000000000000000", Spelling: "House", Language: "en"}}}
+	u := UseCase{VocabularyService: vocab, UsersService: fakeUsers{}}
+
+	reports, err := u.Run(context.Background(), testUserID, path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type outcome struct {
+		Spelling string
+		Lookups  int
+		Source   string
+		Status   importwords.Status
+		Reason   string
+	}
+	var actual []outcome
+	for _, r := range reports {
+		actual = append(actual, outcome{r.Spelling, r.Lookups, r.Source, r.Status, r.Reason})
+	}
+	expected := []outcome{
+		{"run", 3, "Book One", importwords.Imported, ""},
+		{"go", 1, "Book One", importwords.Skipped, "mastered on Kindle"},
+		{"House", 1, "Book One", importwords.Skipped, "already in vocabulary"},
+		{"word", 1, "Book One", importwords.Rejected, ErrMissingLanguage.Error()},
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("unexpected reports (-want +got):\n%s", diff)
+	}
+
+	run := reports[0].Word
+	exercises := []models.SentenceExercise{{Sentence: "She <%ran%> home."}, {Sentence: "He <%runs%> daily."}}
+	if diff := cmp.Diff(exercises, run.Exercises); diff != "" {
+		t.Errorf("unexpected exercises (-want +got):\n%s", diff)
+	}
+	if run.Definition != "" || run.Language != "en" || run.DefinitionLanguage != "ru" || run.Source != "Book One" {
+		t.Errorf("unexpected word %+v", run)
+	}
+	if len(vocab.words) != 2 {
+		t.Errorf("expected only run to be added, got %+v", vocab.words)
+	}
+}
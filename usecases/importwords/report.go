@@ -0,0 +1,151 @@
+package importwords
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type Format int
+
+const (
+	CSV Format = iota
+	TSV
+)
+
+func (f *Format) String() string {
+	txt, err := f.MarshalText()
+	if err != nil {
+		return "unknown"
+	}
+	return txt
+}
+
+func (f *Format) MarshalText() (string, error) {
+	switch *f {
+	case CSV:
+		return "csv", nil
+	case TSV:
+		return "tsv", nil
+	default:
+		return "", fmt.Errorf("%d is unknown Format", *f)
+	}
+}
+
+func (f *Format) UnmarshalText(text string) error {
+	switch text {
+	case "csv":
+		*f = CSV
+	case "tsv":
+		*f = TSV
+	default:
+		return fmt.Errorf("%s is unknown Format representation", text)
+	}
+	return nil
+}
+
+func FormatFromText(s string) (Format, error) {
+	var f Format
+	err := f.UnmarshalText(s)
+	if err != nil {
+		return 0, fmt.Errorf("importwords.FormatFromText invalid format string %s. %w", s, err)
+	}
+	return f, nil
+}
+
+// FormatFromFileName guesses the format by the file extension, CSV is the default.
+func FormatFromFileName(name string) Format {
+	if strings.HasSuffix(strings.ToLower(name), ".tsv") {
+		return TSV
+	}
+	return CSV
+}
+
+func (f Format) separator() rune {
+	if f == TSV {
+		return '\t'
+	}
+	return ','
+}
+
+type Status int
+
+const (
+	Imported Status = iota
+	Skipped
+	Rejected
+)
+
+func (s Status) String() string {
+	switch s {
+	case Imported:
+		return "imported"
+	case Skipped:
+		return "skipped"
+	case Rejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
+
+// PrintReport prints a line per row and the totals.
+func PrintReport(w io.Writer, reports []RowReport) error {
+	counts := map[Status]int{}
+	for _, r := range reports {
+		counts[r.Status]++
+
+		subject := ""
+		if len(r.Record) > 0 {
+			subject = fmt.Sprintf(" %q", strings.TrimSpace(r.Record[0]))
+		}
+		line := fmt.Sprintf("line %d: %s%s", r.Line, r.Status, subject)
+		if r.Word.ID != "" {
+			line += fmt.Sprintf(" as %s with %d exercises", r.Word.ID, len(r.Word.Exercises))
+		}
+		if r.Reason != "" {
+			line += ": " + r.Reason
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return fmt.Errorf("importwords.PrintReport. %w", err)
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "imported %d, skipped %d, rejected %d\n", counts[Imported], counts[Skipped], counts[Rejected])
+	if err != nil {
+		return fmt.Errorf("importwords.PrintReport. %w", err)
+	}
+	return nil
+}
+
+// WriteRejects writes rejected rows as they were read, each preceded by a comment with
+// the reason, so the file can be fixed and imported again. It returns the number of rows written.
+func WriteRejects(w io.Writer, reports []RowReport, format Format) (int, error) {
+	writer := csv.NewWriter(w)
+	writer.Comma = format.separator()
+
+	n := 0
+	for _, r := range reports {
+		if r.Status != Rejected {
+			continue
+		}
+
+		reason := strings.Join(strings.Fields(r.Reason), " ")
+		if _, err := fmt.Fprintf(w, "%c line %d: %s\n", Comment, r.Line, reason); err != nil {
+			return n, fmt.Errorf("importwords.WriteRejects unable to write reason. %w", err)
+		}
+		if len(r.Record) > 0 {
+			if err := writer.Write(r.Record); err != nil {
+				return n, fmt.Errorf("importwords.WriteRejects unable to write row. %w", err)
+			}
+			// the next comment goes straight to w, so the row must be there already
+			writer.Flush()
+			if err := writer.Error(); err != nil {
+				return n, fmt.Errorf("importwords.WriteRejects unable to write row. %w", err)
+			}
+		}
+		n++
+	}
+	return n, nil
+}
@@ -0,0 +1,242 @@
+package importwords
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	DefaultParallelism = 4
+	// TagsSeparator separates tags in the tags column.
+	TagsSeparator = ";"
+	// Comment starts lines ignored by the reader, rejects files explain failures with them.
+	Comment    = '#'
+	minColumns = 2
+	maxColumns = 5
+)
+
+var ErrInvalidRow = errors.New("invalid row")
+
+type UseCase struct {
+	VocabularyService VocabularyService
+	UsersService      UsersService
+	// Parallelism is the max number of words added at once, each of them waits for
+	// exercises to be generated. DefaultParallelism is used if it's not positive.
+	Parallelism int
+}
+
+type VocabularyService interface {
+	ImportWord(ctx context.Context, userID models.UserID, w models.Word) (models.Word, error)
+	ListWords(ctx context.Context, userID models.UserID, filter vocabulary.ListFilter) (vocabulary.WordsPage, error)
+}
+
+type UsersService interface {
+	GetUser(ctx context.Context, id models.UserID) (models.User, error)
+}
+
+// RowReport is the outcome of a single row.
+type RowReport struct {
+	Line   int
+	Record []string
+	Status Status
+	// Reason explains why the row was skipped or rejected.
+	Reason string
+	// Word is set for imported rows.
+	Word models.Word
+}
+
+type row struct {
+	spelling        string
+	definition      string
+	lexicalCategory string
+	language        models.Language
+	tags            []string
+}
+
+// Run imports words from rows of spelling, definition, lexical category, language and
+// tags, the last three columns are optional. Words without language are added in the
+// user's first target language. An optional header row is skipped. Rows are reported
+// in the order they are read, failed rows don't stop the import.
+func (u UseCase) Run(ctx context.Context, userID models.UserID, r io.Reader, format Format) ([]RowReport, error) {
+	usr, err := u.UsersService.GetUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("importwords.UseCase.Run unable to get user. %w", err)
+	}
+
+	reader := newReader(r, format)
+
+	var reports []RowReport
+	var rows []row
+	// rows[i] is reported by reports[rowReports[i]]
+	var rowReports []int
+	seen := map[string]int{}
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			reports = append(reports, RowReport{Line: parseErr.StartLine, Record: record, Status: Rejected, Reason: parseErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return reports, fmt.Errorf("importwords.UseCase.Run unable to read rows. %w", err)
+		}
+		if first && isHeader(record) {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+
+		rep := RowReport{Line: line, Record: record}
+		parsed, err := parseRow(record, usr)
+		if err != nil {
+			rep.Status, rep.Reason = Rejected, err.Error()
+			reports = append(reports, rep)
+			continue
+		}
+
+		key := duplicateKey(parsed.spelling, parsed.language)
+		if prev, ok := seen[key]; ok {
+			rep.Status, rep.Reason = Skipped, fmt.Sprintf("duplicate of line %d", prev)
+			reports = append(reports, rep)
+			continue
+		}
+		seen[key] = line
+
+		rows = append(rows, parsed)
+		rowReports = append(rowReports, len(reports))
+		reports = append(reports, rep)
+	}
+
+	parallelism := u.Parallelism
+	if parallelism <= 0 {
+		parallelism = DefaultParallelism
+	}
+	var g errgroup.Group
+	g.SetLimit(parallelism)
+	for i, rw := range rows {
+		rep := &reports[rowReports[i]]
+		g.Go(func() error {
+			rep.Status, rep.Reason, rep.Word = u.importRow(ctx, usr, rw)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	return reports, nil
+}
+
+func (u UseCase) importRow(ctx context.Context, usr models.User, r row) (Status, string, models.Word) {
+	exists, err := u.exists(ctx, usr.ID, r)
+	if err != nil {
+		return Rejected, fmt.Sprintf("unable to look for duplicates: %s", err), models.Word{}
+	}
+	if exists {
+		return Skipped, "already in vocabulary", models.Word{}
+	}
+
+	word, err := u.VocabularyService.ImportWord(ctx, usr.ID, models.Word{
+		Spelling:           r.spelling,
+		Definition:         r.definition,
+		LexicalCategory:    r.lexicalCategory,
+		Language:           r.language,
+		DefinitionLanguage: usr.NativeLanguage,
+		Tags:               r.tags,
+	})
+	if errors.Is(err, vocabulary.ErrDuplicateWord) {
+		// added since it was looked for
+		return Skipped, "already in vocabulary", models.Word{}
+	}
+	if err != nil {
+		return Rejected, err.Error(), models.Word{}
+	}
+	return Imported, "", word
+}
+
+// exists reports whether the user has the word already, archived words included.
+func (u UseCase) exists(ctx context.Context, userID models.UserID, r row) (bool, error) {
+	page, err := u.VocabularyService.ListWords(ctx, userID, vocabulary.ListFilter{
+		Language:        r.language,
+		Spelling:        r.spelling,
+		IncludeArchived: true,
+		Limit:           1,
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(page.Words) > 0, nil
+}
+
+func newReader(r io.Reader, format Format) *csv.Reader {
+	reader := csv.NewReader(r)
+	reader.Comma = format.separator()
+	reader.Comment = Comment
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if format == TSV {
+		// quotes have no special meaning in tab separated values
+		reader.LazyQuotes = true
+	}
+	return reader
+}
+
+func parseRow(record []string, usr models.User) (row, error) {
+	if len(record) < minColumns || len(record) > maxColumns {
+		return row{}, fmt.Errorf("%w: expected %d to %d columns, got %d", ErrInvalidRow, minColumns, maxColumns, len(record))
+	}
+
+	columns := make([]string, maxColumns)
+	for i, v := range record {
+		columns[i] = strings.TrimSpace(v)
+	}
+
+	r := row{
+		spelling:        columns[0],
+		definition:      columns[1],
+		lexicalCategory: columns[2],
+	}
+	if r.spelling == "" {
+		return row{}, fmt.Errorf("%w: empty spelling", ErrInvalidRow)
+	}
+	if r.definition == "" {
+		return row{}, fmt.Errorf("%w: empty definition", ErrInvalidRow)
+	}
+
+	if columns[3] == "" {
+		if len(usr.TargetLanguages) == 0 {
+			return row{}, fmt.Errorf("%w: empty language and user has no target language", ErrInvalidRow)
+		}
+		r.language = usr.TargetLanguages[0]
+	} else {
+		lang, err := models.LanguageFromText(columns[3])
+		if err != nil {
+			return row{}, fmt.Errorf("%w: %w", ErrInvalidRow, err)
+		}
+		r.language = lang
+	}
+
+	for _, tag := range strings.Split(columns[4], TagsSeparator) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			r.tags = append(r.tags, tag)
+		}
+	}
+	return r, nil
+}
+
+func isHeader(record []string) bool {
+	return len(record) > 0 && strings.EqualFold(strings.TrimSpace(record[0]), "spelling")
+}
+
+func duplicateKey(spelling string, lang models.Language) string {
+	return strings.ToLower(spelling) + "\x00" + string(lang)
+}
@@ -0,0 +1,168 @@
+package importwords
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+)
+
+const testUserID = "000000000000000000000001"
+
+type fakeVocabulary struct {
+	mu    sync.Mutex
+	words []models.Word
+	// running and maxRunning track the number of concurrent ImportWord calls.
+	running, maxRunning int
+	release             chan struct{}
+}
+
+func (f *fakeVocabulary) ImportWord(_ context.Context, userID models.UserID, w models.Word) (models.Word, error) {
+	f.mu.Lock()
+	f.running++
+	f.maxRunning = max(f.maxRunning, f.running)
+	f.mu.Unlock()
+
+	if f.release != nil {
+		<-f.release
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.running--
+	if w.Spelling == "fail" {
+		return models.Word{}, errors.New("generation failed")
+	}
+	w.ID = models.WordID(strings.Repeat("0", 23) + string(rune('a'+len(f.words))))
+	w.UserID = userID
+	f.words = append(f.words, w)
+	return w, nil
+}
+
+func (f *fakeVocabulary) ListWords(_ context.Context, _ models.UserID, filter vocabulary.ListFilter) (vocabulary.WordsPage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var page vocabulary.WordsPage
+	for _, w := range f.words {
+		if w.Language == filter.Language && strings.EqualFold(w.Spelling, filter.Spelling) {
+			page.Words = append(page.Words, w)
+		}
+	}
+	return page, nil
+}
+
+type fakeUsers struct{}
+
+func (fakeUsers) GetUser(_ context.Context, id models.UserID) (models.User, error) {
+	return models.User{ID: id, NativeLanguage: "en", TargetLanguages: []models.Language{"de"}}, nil
+}
+
+func TestUseCase_Run(t *testing.T) {
+	t.Parallel()
+
+	vocab := &fakeVocabulary{words: []models.Word{{ID: "000000000000000000000000", Spelling: "Haus", Language: "de"}}}
+	u := UseCase{VocabularyService: vocab, UsersService: fakeUsers{}}
+
+	input := `spelling,definition,lexical category,language,tags
+laufen,to run,verb,de,movement; A1
+haus,house,noun
+Laufen,to run again,verb,de
+run,to move fast,verb,en_GB
+,no spelling
+fail,generation fails
+bad,language,,xx-!!
+too,many,columns,de,tags,extra
+`
+	reports, err := u.Run(context.Background(), testUserID, strings.NewReader(input), CSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type outcome struct {
+		Line   int
+		Status string
+	}
+	var actual []outcome
+	for _, r := range reports {
+		actual = append(actual, outcome{r.Line, r.Status.String()})
+	}
+	expected := []outcome{
+		{2, "imported"},
+		{3, "skipped"},
+		{4, "skipped"},
+		{5, "imported"},
+		{6, "rejected"},
+		{7, "rejected"},
+		{8, "rejected"},
+		{9, "rejected"},
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("unexpected reports (-want +got):\n%s", diff)
+	}
+
+	laufen := reports[0].Word
+	if laufen.Language != "de" || laufen.DefinitionLanguage != "en" || !cmp.Equal(laufen.Tags, []string{"movement", "A1"}) {
+		t.Errorf("unexpected word %+v", laufen)
+	}
+	if run := reports[3].Word; run.Language != "en-GB" {
+		t.Errorf("unexpected word %+v", run)
+	}
+	if reports[2].Reason != "duplicate of line 2" || reports[1].Reason != "already in vocabulary" {
+		t.Errorf("unexpected reasons %q, %q", reports[1].Reason, reports[2].Reason)
+	}
+
+	var rejects bytes.Buffer
+	n, err := WriteRejects(&rejects, reports, CSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 4 || !strings.Contains(rejects.String(), "# line 7: generation failed\nfail,generation fails\n") {
+		t.Errorf("unexpected rejects %d:\n%s", n, rejects.String())
+	}
+
+	// the rejects can be imported again once fixed
+	again, err := u.Run(context.Background(), testUserID, &rejects, CSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again) != 4 {
+		t.Errorf("unexpected reports of rejects %+v", again)
+	}
+}
+
+func TestUseCase_RunParallelism(t *testing.T) {
+	t.Parallel()
+
+	vocab := &fakeVocabulary{release: make(chan struct{})}
+	u := UseCase{VocabularyService: vocab, UsersService: fakeUsers{}, Parallelism: 2}
+
+	input := "a\tone\nb\ttwo\nc\tthree\nd\tfour\ne\tfive\n"
+	done := make(chan []RowReport)
+	go func() {
+		reports, err := u.Run(context.Background(), testUserID, strings.NewReader(input), TSV)
+		if err != nil {
+			t.Error(err)
+		}
+		done <- reports
+	}()
+
+	for range 5 {
+		vocab.release <- struct{}{}
+	}
+	reports := <-done
+
+	if vocab.maxRunning != 2 {
+		t.Errorf("expected 2 words added at once, got %d", vocab.maxRunning)
+	}
+	for _, r := range reports {
+		if r.Status != Imported {
+			t.Errorf("unexpected report %+v", r)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package listwords
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+)
+
+type UseCase struct {
+	VocabularyService VocabularyService
+}
+
+type VocabularyService interface {
+	ListWords(ctx context.Context, userID models.UserID, filter vocabulary.ListFilter) (vocabulary.WordsPage, error)
+}
+
+func (u UseCase) Run(ctx context.Context, userID models.UserID, filter vocabulary.ListFilter) (vocabulary.WordsPage, error) {
+	page, err := u.VocabularyService.ListWords(ctx, userID, filter)
+	if err != nil {
+		return page, fmt.Errorf("listwords.UseCase.Run unable to list words. %w", err)
+	}
+	return page, nil
+}
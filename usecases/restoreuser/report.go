@@ -0,0 +1,68 @@
+package restoreuser
+
+import (
+	"fmt"
+	"io"
+)
+
+type Status int
+
+const (
+	Restored Status = iota
+	Conflict
+	Failed
+)
+
+func (s Status) String() string {
+	switch s {
+	case Restored:
+		return "restored"
+	case Conflict:
+		return "conflict"
+	case Failed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// PrintReport prints the user, a line per conflict and per word, and the totals.
+func PrintReport(w io.Writer, rep Report) error {
+	var lines []string
+	if rep.DryRun {
+		lines = append(lines, "dry run, nothing is written")
+	}
+	switch {
+	case rep.UserCreated && rep.User.ID == "":
+		lines = append(lines, fmt.Sprintf("user %s would be created", rep.ArchivedUserID))
+	case rep.UserCreated:
+		lines = append(lines, fmt.Sprintf("user %s restored as %s", rep.ArchivedUserID, rep.User.ID))
+	default:
+		lines = append(lines, fmt.Sprintf("words of user %s restored to %s", rep.ArchivedUserID, rep.User.ID))
+	}
+	for _, c := range rep.Conflicts {
+		lines = append(lines, "conflict: "+c)
+	}
+
+	counts := map[Status]int{}
+	for _, r := range rep.Words {
+		counts[r.Status]++
+
+		line := fmt.Sprintf("word %s %q: %s", r.ID, r.Spelling, r.Status)
+		if r.NewID != "" {
+			line += fmt.Sprintf(" as %s", r.NewID)
+		}
+		if r.Reason != "" {
+			line += ": " + r.Reason
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, fmt.Sprintf("restored %d, conflicts %d, failed %d", counts[Restored], counts[Conflict], counts[Failed]))
+
+	for _, l := range lines {
+		if _, err := fmt.Fprintln(w, l); err != nil {
+			return fmt.Errorf("restoreuser.PrintReport. %w", err)
+		}
+	}
+	return nil
+}
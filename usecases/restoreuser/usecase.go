@@ -0,0 +1,176 @@
+package restoreuser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pavelpuchok/vocabforge/backup"
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/users"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+)
+
+type UseCase struct {
+	UsersService      UsersService
+	VocabularyService VocabularyService
+}
+
+type UsersService interface {
+	Create(ctx context.Context, profile models.User) (models.User, error)
+	GetUser(ctx context.Context, id models.UserID) (models.User, error)
+}
+
+type VocabularyService interface {
+	ListWords(ctx context.Context, userID models.UserID, filter vocabulary.ListFilter) (vocabulary.WordsPage, error)
+	RestoreWord(ctx context.Context, userID models.UserID, w models.Word) (models.Word, error)
+}
+
+// Report tells what was restored, or what would be on a dry run.
+type Report struct {
+	DryRun bool
+	// ArchivedUserID is the ID the user had when the archive was made.
+	ArchivedUserID models.UserID
+	// User is the user restored to. Its ID is empty on a dry run that would create the user.
+	User        models.User
+	UserCreated bool
+	// Conflicts are differences between the archive and the existing data, which don't stop the restore.
+	Conflicts []string
+	Words     []WordReport
+}
+
+type WordReport struct {
+	// ID is the ID the word had when the archive was made.
+	ID       models.WordID
+	Spelling string
+	Language models.Language
+	Status   Status
+	Reason   string
+	// NewID is the ID the word is restored under, empty on a dry run.
+	NewID models.WordID
+}
+
+// Run restores the archive read from r. When userID is empty a new user is created with
+// the archived profile, otherwise the words are added to the user. Restored words get
+// new IDs and keep their exercises and learning state. Words the user has already, by
+// spelling and language, are left out as conflicting. Nothing is written when dryRun
+// is set, the report tells what would be done then.
+func (u UseCase) Run(ctx context.Context, r io.Reader, format backup.Format, userID models.UserID, dryRun bool) (Report, error) {
+	archive, err := backup.Read(r, format)
+	if err != nil {
+		return Report{}, fmt.Errorf("restoreuser.UseCase.Run unable to read archive. %w", err)
+	}
+	profile, err := archive.User.Profile()
+	if err != nil {
+		return Report{}, fmt.Errorf("restoreuser.UseCase.Run. %w", err)
+	}
+
+	rep := Report{DryRun: dryRun, ArchivedUserID: profile.ID}
+	existing := map[string]models.WordID{}
+	if userID != "" {
+		rep.User, err = u.UsersService.GetUser(ctx, userID)
+		if err != nil {
+			return Report{}, fmt.Errorf("restoreuser.UseCase.Run unable to get user. %w", err)
+		}
+		if rep.User.NativeLanguage != profile.NativeLanguage {
+			rep.Conflicts = append(rep.Conflicts, fmt.Sprintf("user's native language is %s, definitions in the archive are in %s",
+				rep.User.NativeLanguage.String(), profile.NativeLanguage.String()))
+		}
+		existing, err = u.existingWords(ctx, userID)
+		if err != nil {
+			return Report{}, fmt.Errorf("restoreuser.UseCase.Run unable to list user's words. %w", err)
+		}
+	} else {
+		conflict, err := u.userExists(ctx, profile.ID)
+		if err != nil {
+			return Report{}, fmt.Errorf("restoreuser.UseCase.Run unable to look for archived user. %w", err)
+		}
+		if conflict {
+			rep.Conflicts = append(rep.Conflicts, fmt.Sprintf("archived user %s exists, a copy of it is created", profile.ID))
+		}
+
+		rep.User, rep.UserCreated = profile, true
+		rep.User.ID = ""
+		if !dryRun {
+			rep.User, err = u.UsersService.Create(ctx, profile)
+			if err != nil {
+				return Report{}, fmt.Errorf("restoreuser.UseCase.Run unable to create user. %w", err)
+			}
+		}
+	}
+
+	restored := map[string]models.WordID{}
+	for _, aw := range archive.Words {
+		wr := WordReport{ID: models.WordID(aw.ID), Spelling: aw.Spelling}
+		w, err := aw.Model()
+		if err != nil {
+			wr.Status, wr.Reason = Failed, err.Error()
+			rep.Words = append(rep.Words, wr)
+			continue
+		}
+		wr.Language = w.Language
+
+		key := strings.ToLower(w.Spelling) + "\x00" + string(w.Language)
+		if id, ok := existing[key]; ok {
+			wr.Status, wr.Reason = Conflict, fmt.Sprintf("already in vocabulary as %s", id)
+			rep.Words = append(rep.Words, wr)
+			continue
+		}
+		if id, ok := restored[key]; ok {
+			wr.Status, wr.Reason = Conflict, fmt.Sprintf("duplicate of %s in archive", id)
+			rep.Words = append(rep.Words, wr)
+			continue
+		}
+		restored[key] = wr.ID
+
+		wr.Status = Restored
+		if !dryRun {
+			added, err := u.VocabularyService.RestoreWord(ctx, rep.User.ID, w)
+			switch {
+			case errors.Is(err, vocabulary.ErrDuplicateWord):
+				wr.Status, wr.Reason = Conflict, "already in vocabulary"
+			case err != nil:
+				wr.Status, wr.Reason = Failed, err.Error()
+			}
+			wr.NewID = added.ID
+		}
+		rep.Words = append(rep.Words, wr)
+	}
+	return rep, nil
+}
+
+func (u UseCase) userExists(ctx context.Context, id models.UserID) (bool, error) {
+	if _, err := models.UserIDFromText(id.String()); err != nil {
+		// the archive comes from a backend with other IDs
+		return false, nil //nolint:nilerr
+	}
+	_, err := u.UsersService.GetUser(ctx, id)
+	if errors.Is(err, users.ErrUserNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// existingWords returns IDs of the user's words, archived ones included, by spelling and language.
+func (u UseCase) existingWords(ctx context.Context, userID models.UserID) (map[string]models.WordID, error) {
+	words := map[string]models.WordID{}
+	filter := vocabulary.ListFilter{IncludeArchived: true, Limit: vocabulary.MaxListLimit}
+	for {
+		page, err := u.VocabularyService.ListWords(ctx, userID, filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range page.Words {
+			words[strings.ToLower(w.Spelling)+"\x00"+string(w.Language)] = w.ID
+		}
+		if page.NextCursor == "" {
+			return words, nil
+		}
+		filter.Cursor = page.NextCursor
+	}
+}
@@ -0,0 +1,25 @@
+package reviewword
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/scheduling"
+)
+
+type UseCase struct {
+	SchedulingService SchedulingService
+}
+
+type SchedulingService interface {
+	Review(ctx context.Context, userID models.UserID, wordID models.WordID, g scheduling.Grade) (models.Word, error)
+}
+
+func (u UseCase) Run(ctx context.Context, userID models.UserID, wordID models.WordID, g scheduling.Grade) (models.Word, error) {
+	word, err := u.SchedulingService.Review(ctx, userID, wordID, g)
+	if err != nil {
+		return word, fmt.Errorf("reviewword.UseCase.Run unable to review word. %w", err)
+	}
+	return word, nil
+}
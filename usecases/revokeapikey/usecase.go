@@ -0,0 +1,23 @@
+package revokeapikey
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pavelpuchok/vocabforge/models"
+)
+
+type UseCase struct {
+	UsersService UsersService
+}
+
+type UsersService interface {
+	RevokeAPIKey(ctx context.Context, userID models.UserID, credentialID string) error
+}
+
+func (u UseCase) Run(ctx context.Context, userID models.UserID, keyID string) error {
+	if err := u.UsersService.RevokeAPIKey(ctx, userID, keyID); err != nil {
+		return fmt.Errorf("revokeapikey.UseCase.Run unable to revoke API key. %w", err)
+	}
+	return nil
+}
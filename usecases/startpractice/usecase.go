@@ -0,0 +1,46 @@
+package startpractice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/practice"
+)
+
+type UseCase struct {
+	PracticeService PracticeService
+	UsersService    UsersService
+}
+
+type PracticeService interface {
+	StartSession(ctx context.Context, userID models.UserID, lang models.Language, size int, t practice.ExerciseType) (practice.Session, error)
+}
+
+type UsersService interface {
+	GetUser(ctx context.Context, id models.UserID) (models.User, error)
+}
+
+// Run starts a practice session of due words. Zero size and nil t are taken from the user's preferences.
+func (u UseCase) Run(ctx context.Context, userID models.UserID, lang models.Language, size int, t *practice.ExerciseType) (practice.Session, error) {
+	usr, err := u.UsersService.GetUser(ctx, userID)
+	if err != nil {
+		return practice.Session{}, fmt.Errorf("startpractice.UseCase.Run unable to get user. %w", err)
+	}
+
+	if size == 0 {
+		size = usr.SessionSize
+	}
+	exerciseType := practice.Cloze
+	if t != nil {
+		exerciseType = *t
+	} else if len(usr.ExerciseTypes) > 0 {
+		exerciseType = usr.ExerciseTypes[0]
+	}
+
+	session, err := u.PracticeService.StartSession(ctx, userID, lang, size, exerciseType)
+	if err != nil {
+		return session, fmt.Errorf("startpractice.UseCase.Run unable to start session. %w", err)
+	}
+	return session, nil
+}
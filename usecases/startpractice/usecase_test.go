@@ -0,0 +1,84 @@
+package startpractice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/practice"
+)
+
+const testUserID = "000000000000000000000001"
+
+type fakePractice struct {
+	size         int
+	exerciseType practice.ExerciseType
+}
+
+func (f *fakePractice) StartSession(_ context.Context, userID models.UserID, _ models.Language, size int, t practice.ExerciseType) (practice.Session, error) {
+	f.size, f.exerciseType = size, t
+	return practice.Session{UserID: userID}, nil
+}
+
+type fakeUsers struct {
+	user models.User
+}
+
+func (f fakeUsers) GetUser(_ context.Context, id models.UserID) (models.User, error) {
+	usr := f.user
+	usr.ID = id
+	return usr, nil
+}
+
+func TestUseCase_Run(t *testing.T) {
+	t.Parallel()
+
+	definition := practice.Definition
+	cloze := practice.Cloze
+	tests := []struct {
+		name         string
+		user         models.User
+		size         int
+		exerciseType *practice.ExerciseType
+		expectedSize int
+		expectedType practice.ExerciseType
+	}{
+		{
+			name:         "no preferences",
+			expectedType: practice.Cloze,
+		},
+		{
+			name:         "preferences",
+			user:         models.User{SessionSize: 5, ExerciseTypes: []models.ExerciseType{practice.Definition, practice.Cloze}},
+			expectedSize: 5,
+			expectedType: practice.Definition,
+		},
+		{
+			name:         "arguments override preferences",
+			user:         models.User{SessionSize: 5, ExerciseTypes: []models.ExerciseType{practice.Definition}},
+			size:         3,
+			exerciseType: &cloze,
+			expectedSize: 3,
+			expectedType: practice.Cloze,
+		},
+		{
+			name:         "exercise type without preferences",
+			exerciseType: &definition,
+			expectedType: practice.Definition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := &fakePractice{}
+			u := UseCase{PracticeService: p, UsersService: fakeUsers{user: tt.user}}
+			if _, err := u.Run(context.Background(), testUserID, "en", tt.size, tt.exerciseType); err != nil {
+				t.Fatal(err)
+			}
+			if p.size != tt.expectedSize || p.exerciseType != tt.expectedType {
+				t.Errorf("expected session of %d %v, got %d %v", tt.expectedSize, tt.expectedType, p.size, p.exerciseType)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+)
+
+type UseCase struct {
+	VocabularyService VocabularyService
+	Now               func() time.Time
+}
+
+type VocabularyService interface {
+	CountWords(ctx context.Context, userID models.UserID, filter vocabulary.ListFilter, dueAt time.Time) (vocabulary.WordCounts, error)
+}
+
+// Stats summarises the user's vocabulary, archived words are not counted.
+type Stats struct {
+	Total      int
+	Pending    int
+	InProgress int
+	Learned    int
+	// Due is the number of words due for a review now.
+	Due int
+}
+
+// Run counts the user's words, of lang only if it isn't empty.
+func (u UseCase) Run(ctx context.Context, userID models.UserID, lang models.Language) (Stats, error) {
+	now := time.Now
+	if u.Now != nil {
+		now = u.Now
+	}
+
+	counts, err := u.VocabularyService.CountWords(ctx, userID, vocabulary.ListFilter{Language: lang}, now())
+	if err != nil {
+		return Stats{}, fmt.Errorf("stats.UseCase.Run unable to count words. %w", err)
+	}
+	return Stats{
+		Total:      counts.Pending + counts.InProgress + counts.Learned,
+		Pending:    counts.Pending,
+		InProgress: counts.InProgress,
+		Learned:    counts.Learned,
+		Due:        counts.Due,
+	}, nil
+}
@@ -0,0 +1,46 @@
+package stats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+)
+
+const testUserID = "000000000000000000000001"
+
+type fakeVocabulary struct {
+	filter vocabulary.ListFilter
+	dueAt  time.Time
+}
+
+func (f *fakeVocabulary) CountWords(_ context.Context, _ models.UserID, filter vocabulary.ListFilter, dueAt time.Time) (vocabulary.WordCounts, error) {
+	f.filter, f.dueAt = filter, dueAt
+	return vocabulary.WordCounts{Pending: 3, InProgress: 2, Learned: 1, Due: 4}, nil
+}
+
+func TestUseCase_Run(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	vocab := &fakeVocabulary{}
+	u := UseCase{VocabularyService: vocab, Now: func() time.Time { return now }}
+
+	stats, err := u.Run(context.Background(), testUserID, "en-US")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Stats{Total: 6, Pending: 3, InProgress: 2, Learned: 1, Due: 4}
+	if diff := cmp.Diff(expected, stats); diff != "" {
+		t.Errorf("unexpected stats (-want +got):\n%s", diff)
+	}
+	if vocab.filter.Language != "en-US" || vocab.filter.IncludeArchived {
+		t.Errorf("unexpected filter %+v", vocab.filter)
+	}
+	if !vocab.dueAt.Equal(now) {
+		t.Errorf("expected words due at %s, got %s", now, vocab.dueAt)
+	}
+}
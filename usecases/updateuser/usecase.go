@@ -0,0 +1,25 @@
+package updateuser
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/users"
+)
+
+type UseCase struct {
+	UsersService UsersService
+}
+
+type UsersService interface {
+	UpdateUser(ctx context.Context, id models.UserID, patch users.UserPatch) (models.User, error)
+}
+
+func (u UseCase) Run(ctx context.Context, userID models.UserID, patch users.UserPatch) (models.User, error) {
+	usr, err := u.UsersService.UpdateUser(ctx, userID, patch)
+	if err != nil {
+		return usr, fmt.Errorf("updateuser.UseCase.Run unable to update user. %w", err)
+	}
+	return usr, nil
+}
@@ -0,0 +1,252 @@
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+)
+
+var (
+	ErrUnauthenticated    = errors.New("invalid or expired credentials")
+	ErrCredentialNotFound = errors.New("credential not found")
+)
+
+const (
+	apiKeyPrefix  = "vfk_"
+	sessionPrefix = "vfs_"
+	// tokenSecretSize is the number of random bytes in a token
+	tokenSecretSize = 32
+	credentialIDLen = 12
+)
+
+// Scope limits what a credential may be used for. Every scope includes the lower ones.
+type Scope int
+
+const (
+	// ScopeReadOnly allows reading the user's data.
+	ScopeReadOnly Scope = iota
+	// ScopePractice additionally allows adding words and practicing.
+	ScopePractice
+	// ScopeAdmin allows everything on the user's own account.
+	ScopeAdmin
+	// ScopeOperator additionally allows creating users and accessing other users' data. It's
+	// meant for whoever runs the service, keys issued as admin don't get it.
+	ScopeOperator
+)
+
+func (s *Scope) String() string {
+	txt, err := s.MarshalText()
+	if err != nil {
+		return "unknown"
+	}
+	return txt
+}
+
+func (s *Scope) MarshalText() (string, error) {
+	switch *s {
+	case ScopeReadOnly:
+		return "read-only", nil
+	case ScopePractice:
+		return "practice", nil
+	case ScopeAdmin:
+		return "admin", nil
+	case ScopeOperator:
+		return "operator", nil
+	default:
+		return "", fmt.Errorf("%d is unknown Scope", *s)
+	}
+}
+
+func (s *Scope) UnmarshalText(text string) error {
+	switch text {
+	case "read-only":
+		*s = ScopeReadOnly
+	case "practice":
+		*s = ScopePractice
+	case "admin":
+		*s = ScopeAdmin
+	case "operator":
+		*s = ScopeOperator
+	default:
+		return fmt.Errorf("%s is unknown Scope representation", text)
+	}
+	return nil
+}
+
+func ScopeFromText(s string) (Scope, error) {
+	var scope Scope
+	err := scope.UnmarshalText(s)
+	if err != nil {
+		return 0, fmt.Errorf("users.ScopeFromText invalid scope string %s. %w", s, err)
+	}
+	return scope, nil
+}
+
+// Allows reports whether a credential of scope s may be used where required scope is needed.
+func (s Scope) Allows(required Scope) bool {
+	return s >= required
+}
+
+type CredentialKind int
+
+const (
+	// APIKey is a long living credential, valid until revoked.
+	APIKey CredentialKind = iota
+	// Session is a short living credential issued for an API key.
+	Session
+)
+
+// Credential is an API key or a session token of a user. Only the token's hash is stored.
+type Credential struct {
+	ID        string
+	Kind      CredentialKind
+	Name      string
+	Scope     Scope
+	Hash      string
+	CreatedAt time.Time
+	// ExpiresAt is zero for credentials which don't expire.
+	ExpiresAt time.Time
+	// ParentID is the ID of the API key a session was issued for, it's empty for API keys.
+	ParentID string
+}
+
+// Principal is the user a request is made on behalf of.
+type Principal struct {
+	UserID models.UserID
+	Scope  Scope
+	// Kind is the kind of credential used.
+	Kind CredentialKind
+	// CredentialID is the ID of the credential used.
+	CredentialID string
+}
+
+type principalKey struct{}
+
+// ContextWithPrincipal returns a copy of ctx carrying the authenticated principal.
+func ContextWithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the principal stored by ContextWithPrincipal.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// CanAccess reports whether the principal may access the user's data.
+func (p Principal) CanAccess(userID models.UserID) bool {
+	return p.UserID == userID || p.Scope.Allows(ScopeOperator)
+}
+
+// CreateAPIKey issues a new API key. The returned token is shown only once, it can't be restored.
+func (s Service) CreateAPIKey(ctx context.Context, userID models.UserID, name string, scope Scope) (Credential, string, error) {
+	c, token, err := s.issue(ctx, userID, Credential{Kind: APIKey, Name: name, Scope: scope})
+	if err != nil {
+		return c, "", fmt.Errorf("users.Service.CreateAPIKey failed. %w", err)
+	}
+	return c, token, nil
+}
+
+// RevokeAPIKey removes the user's API key or session. Sessions issued for the API key are
+// removed with it.
+func (s Service) RevokeAPIKey(ctx context.Context, userID models.UserID, credentialID string) error {
+	if err := s.repo.RemoveCredential(ctx, userID, credentialID); err != nil {
+		return fmt.Errorf("users.Service.RevokeAPIKey failed. %w", err)
+	}
+	return nil
+}
+
+// CreateSession issues a session token for the principal, which expires after ttl or when the
+// principal's API key is revoked.
+func (s Service) CreateSession(ctx context.Context, p Principal, ttl time.Duration) (Credential, string, error) {
+	c, token, err := s.issue(ctx, p.UserID, Credential{Kind: Session, Scope: p.Scope, ExpiresAt: s.now().Add(ttl), ParentID: p.CredentialID})
+	if err != nil {
+		return c, "", fmt.Errorf("users.Service.CreateSession failed. %w", err)
+	}
+	return c, token, nil
+}
+
+// Authenticate resolves the user the token was issued for.
+func (s Service) Authenticate(ctx context.Context, token string) (Principal, error) {
+	if !strings.HasPrefix(token, apiKeyPrefix) && !strings.HasPrefix(token, sessionPrefix) {
+		return Principal{}, fmt.Errorf("users.Service.Authenticate malformed token. %w", ErrUnauthenticated)
+	}
+
+	userID, c, err := s.repo.FindCredential(ctx, hashToken(token))
+	if errors.Is(err, ErrCredentialNotFound) {
+		return Principal{}, fmt.Errorf("users.Service.Authenticate unknown token. %w", ErrUnauthenticated)
+	}
+	if err != nil {
+		return Principal{}, fmt.Errorf("users.Service.Authenticate unable to find credential. %w", err)
+	}
+	if !c.ExpiresAt.IsZero() && !c.ExpiresAt.After(s.now()) {
+		return Principal{}, fmt.Errorf("users.Service.Authenticate token expired. %w", ErrUnauthenticated)
+	}
+
+	return Principal{UserID: userID, Scope: c.Scope, Kind: c.Kind, CredentialID: c.ID}, nil
+}
+
+// Reauthenticate resolves the principal of a credential which was authenticated before, so
+// whatever relies on it learns when it's revoked or expires.
+func (s Service) Reauthenticate(ctx context.Context, userID models.UserID, credentialID string) (Principal, error) {
+	c, err := s.repo.GetCredential(ctx, userID, credentialID)
+	if errors.Is(err, ErrCredentialNotFound) {
+		return Principal{}, fmt.Errorf("users.Service.Reauthenticate credential %s revoked. %w", credentialID, ErrUnauthenticated)
+	}
+	if err != nil {
+		return Principal{}, fmt.Errorf("users.Service.Reauthenticate unable to get credential. %w", err)
+	}
+	if !c.ExpiresAt.IsZero() && !c.ExpiresAt.After(s.now()) {
+		return Principal{}, fmt.Errorf("users.Service.Reauthenticate credential %s expired. %w", credentialID, ErrUnauthenticated)
+	}
+
+	return Principal{UserID: userID, Scope: c.Scope, Kind: c.Kind, CredentialID: c.ID}, nil
+}
+
+// issue generates a token for the credential c, whose ID, hash and creation time are filled in.
+func (s Service) issue(ctx context.Context, userID models.UserID, c Credential) (Credential, string, error) {
+	id, err := randomHex(credentialIDLen)
+	if err != nil {
+		return Credential{}, "", err
+	}
+	secret := make([]byte, tokenSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return Credential{}, "", fmt.Errorf("unable to generate token. %w", err)
+	}
+
+	prefix := apiKeyPrefix
+	if c.Kind == Session {
+		prefix = sessionPrefix
+	}
+	token := prefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	c.ID = id
+	c.Hash = hashToken(token)
+	c.CreatedAt = s.now()
+	if err := s.repo.AddCredential(ctx, userID, c); err != nil {
+		return Credential{}, "", fmt.Errorf("unable to store credential. %w", err)
+	}
+	return c, token, nil
+}
+
+// hashToken hashes a token for storage. Tokens are random and long, so a fast hash is enough.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("unable to generate random ID. %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
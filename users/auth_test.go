@@ -0,0 +1,96 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+)
+
+func TestService_APIKeys(t *testing.T) {
+	t.Parallel()
+
+	const userID models.UserID = "000000000000000000000001"
+	ctx := context.Background()
+	now := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	repo := &fakeRepository{}
+	s := NewService(repo)
+	s.now = func() time.Time { return now }
+
+	key, token, err := s.CreateAPIKey(ctx, userID, "laptop", ScopePractice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(key.Hash, token) || key.Hash == "" {
+		t.Errorf("expected token to be stored hashed, got %q", key.Hash)
+	}
+
+	p, err := s.Authenticate(ctx, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.UserID != userID || p.Scope != ScopePractice || p.Kind != APIKey || p.CredentialID != key.ID {
+		t.Errorf("unexpected principal %+v", p)
+	}
+	if !p.Scope.Allows(ScopeReadOnly) || p.Scope.Allows(ScopeAdmin) {
+		t.Errorf("unexpected scope permissions of %s", p.Scope.String())
+	}
+
+	_, sessionToken, err := s.CreateSession(ctx, p, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sp, err := s.Authenticate(ctx, sessionToken); err != nil || sp.Kind != Session || sp.Scope != ScopePractice {
+		t.Errorf("unexpected session principal %+v, %v", sp, err)
+	}
+	now = now.Add(2 * time.Hour)
+	if _, err := s.Authenticate(ctx, sessionToken); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("expected expired session to be rejected, got %v", err)
+	}
+
+	if err := s.RevokeAPIKey(ctx, "000000000000000000000002", key.ID); !errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("expected other user's key not to be found, got %v", err)
+	}
+	if rp, err := s.Reauthenticate(ctx, userID, key.ID); err != nil || rp != p {
+		t.Errorf("expected principal %+v on reauthentication, got %+v, %v", p, rp, err)
+	}
+	_, sessionToken, err = s.CreateSession(ctx, p, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RevokeAPIKey(ctx, userID, key.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Authenticate(ctx, token); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("expected revoked key to be rejected, got %v", err)
+	}
+	if _, err := s.Authenticate(ctx, sessionToken); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("expected session of revoked key to be rejected, got %v", err)
+	}
+	if _, err := s.Reauthenticate(ctx, userID, key.ID); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("expected revoked key to fail reauthentication, got %v", err)
+	}
+	if _, err := s.Authenticate(ctx, "garbage"); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("expected malformed token to be rejected, got %v", err)
+	}
+}
+
+func TestPrincipal_CanAccess(t *testing.T) {
+	t.Parallel()
+
+	const own, other models.UserID = "000000000000000000000001", "000000000000000000000002"
+	cases := map[Scope]bool{ScopeReadOnly: false, ScopePractice: false, ScopeAdmin: false, ScopeOperator: true}
+	for scope, expected := range cases {
+		p := Principal{UserID: own, Scope: scope}
+		if !p.CanAccess(own) {
+			t.Errorf("expected %s to access its own user", scope.String())
+		}
+		if p.CanAccess(other) != expected {
+			t.Errorf("expected %s access to other user to be %t", scope.String(), expected)
+		}
+	}
+}
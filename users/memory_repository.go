@@ -0,0 +1,189 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryRepository keeps users in memory, for experiments and tests. It's safe for concurrent use.
+type MemoryRepository struct {
+	mu    sync.RWMutex
+	users map[models.UserID]memoryUser
+}
+
+// memoryUser is a user with credentials, exported fields make the snapshot.
+type memoryUser struct {
+	Profile     models.User
+	Credentials []Credential
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		users: map[models.UserID]memoryUser{},
+	}
+}
+
+func (r *MemoryRepository) Create(_ context.Context, profile models.User) (models.User, error) {
+	u := copyUser(profile)
+	u.ID = models.UserID(primitive.NewObjectID().Hex())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users[u.ID] = memoryUser{Profile: u}
+	return copyUser(u), nil
+}
+
+func (r *MemoryRepository) Get(_ context.Context, id models.UserID) (models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	u, ok := r.users[id]
+	if !ok {
+		return models.User{}, fmt.Errorf("users.MemoryRepository.Get user %s. %w", id, ErrUserNotFound)
+	}
+	return copyUser(u.Profile), nil
+}
+
+func (r *MemoryRepository) Update(_ context.Context, id models.UserID, patch UserPatch) (models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u, ok := r.users[id]
+	if !ok {
+		return models.User{}, fmt.Errorf("users.MemoryRepository.Update user %s. %w", id, ErrUserNotFound)
+	}
+
+	p := copyUser(u.Profile)
+	if patch.DisplayName != nil {
+		p.DisplayName = *patch.DisplayName
+	}
+	if patch.NativeLanguage != nil {
+		p.NativeLanguage = *patch.NativeLanguage
+	}
+	if patch.TargetLanguages != nil {
+		p.TargetLanguages = slices.Clone(patch.TargetLanguages)
+	}
+	if patch.Timezone != nil {
+		p.Timezone = *patch.Timezone
+	}
+	if patch.DailyGoal != nil {
+		p.DailyGoal = *patch.DailyGoal
+	}
+	if patch.ExerciseTypes != nil {
+		p.ExerciseTypes = slices.Clone(patch.ExerciseTypes)
+	}
+	if patch.SessionSize != nil {
+		p.SessionSize = *patch.SessionSize
+	}
+
+	u.Profile = p
+	r.users[id] = u
+	return copyUser(p), nil
+}
+
+func (r *MemoryRepository) AddCredential(_ context.Context, id models.UserID, c Credential) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u, ok := r.users[id]
+	if !ok {
+		return fmt.Errorf("users.MemoryRepository.AddCredential user %s. %w", id, ErrUserNotFound)
+	}
+
+	// drop expired sessions first, they'd pile up otherwise
+	credentials := slices.DeleteFunc(slices.Clone(u.Credentials), func(e Credential) bool {
+		return e.Kind == Session && !e.ExpiresAt.IsZero() && !e.ExpiresAt.After(c.CreatedAt)
+	})
+	u.Credentials = append(credentials, c)
+	r.users[id] = u
+	return nil
+}
+
+func (r *MemoryRepository) RemoveCredential(_ context.Context, id models.UserID, credentialID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u, ok := r.users[id]
+	if !ok || !slices.ContainsFunc(u.Credentials, func(c Credential) bool { return c.ID == credentialID }) {
+		return fmt.Errorf("users.MemoryRepository.RemoveCredential credential %s of user %s. %w", credentialID, id, ErrCredentialNotFound)
+	}
+	u.Credentials = slices.DeleteFunc(slices.Clone(u.Credentials), func(c Credential) bool {
+		return c.ID == credentialID || c.ParentID == credentialID
+	})
+	r.users[id] = u
+	return nil
+}
+
+func (r *MemoryRepository) GetCredential(_ context.Context, id models.UserID, credentialID string) (Credential, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	u := r.users[id]
+	i := slices.IndexFunc(u.Credentials, func(c Credential) bool { return c.ID == credentialID })
+	if i < 0 {
+		return Credential{}, fmt.Errorf("users.MemoryRepository.GetCredential credential %s of user %s. %w", credentialID, id, ErrCredentialNotFound)
+	}
+	return u.Credentials[i], nil
+}
+
+func (r *MemoryRepository) FindCredential(_ context.Context, hash string) (models.UserID, Credential, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for id, u := range r.users {
+		for _, c := range u.Credentials {
+			if c.Hash == hash {
+				return id, c, nil
+			}
+		}
+	}
+	return "", Credential{}, fmt.Errorf("users.MemoryRepository.FindCredential. %w", ErrCredentialNotFound)
+}
+
+// MarshalJSON writes all the users with their credentials, ordered by ID, for a snapshot.
+func (r *MemoryRepository) MarshalJSON() ([]byte, error) {
+	r.mu.RLock()
+	users := make([]memoryUser, 0, len(r.users))
+	for _, u := range r.users {
+		users = append(users, u)
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(users, func(a, b memoryUser) int {
+		return strings.Compare(a.Profile.ID.String(), b.Profile.ID.String())
+	})
+	b, err := json.Marshal(users)
+	if err != nil {
+		return nil, fmt.Errorf("users.MemoryRepository.MarshalJSON. %w", err)
+	}
+	return b, nil
+}
+
+// UnmarshalJSON replaces the users with the ones of a snapshot.
+func (r *MemoryRepository) UnmarshalJSON(b []byte) error {
+	var users []memoryUser
+	if err := json.Unmarshal(b, &users); err != nil {
+		return fmt.Errorf("users.MemoryRepository.UnmarshalJSON. %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users = make(map[models.UserID]memoryUser, len(users))
+	for _, u := range users {
+		r.users[u.Profile.ID] = u
+	}
+	return nil
+}
+
+func copyUser(u models.User) models.User {
+	u.TargetLanguages = slices.Clone(u.TargetLanguages)
+	u.ExerciseTypes = slices.Clone(u.ExerciseTypes)
+	return u
+}
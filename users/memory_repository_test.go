@@ -0,0 +1,16 @@
+package users_test
+
+import (
+	"testing"
+
+	"github.com/pavelpuchok/vocabforge/users"
+	"github.com/pavelpuchok/vocabforge/users/userstest"
+)
+
+func TestMemoryRepository(t *testing.T) {
+	t.Parallel()
+
+	userstest.TestRepository(t, func(*testing.T) users.Repository {
+		return users.NewMemoryRepository()
+	})
+}
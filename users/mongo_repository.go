@@ -2,14 +2,35 @@ package users
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/pavelpuchok/vocabforge/models"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+const (
+	fieldID              = "_id"
+	fieldDisplayName     = "displayName"
+	fieldNativeLanguage  = "nativeLanguage"
+	fieldTargetLanguages = "targetLanguages"
+	fieldTimezone        = "timezone"
+	fieldDailyGoal       = "dailyGoal"
+	fieldExerciseTypes   = "exerciseTypes"
+	fieldSessionSize     = "sessionSize"
+	fieldCredentials     = "credentials"
+	fieldCredentialID    = "credentials.id"
+	fieldCredentialHash  = "credentials.hash"
+)
+
+// MongoSchemaVersion is the version of the latest migration in package mongodb, new users are
+// written with it.
+const MongoSchemaVersion = 2
+
 type MongoRepository struct {
 	col *mongo.Collection
 }
@@ -21,40 +42,389 @@ func NewMongoRepository(db *mongo.Database) MongoRepository {
 	}
 }
 
+// EnsureIndexes creates the unique index on credential hashes, which every request is
+// authenticated by. Users without credentials aren't indexed.
+func (r MongoRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: fieldCredentialHash, Value: 1}},
+		Options: options.Index().
+			SetName("credentials_hash").
+			SetUnique(true).
+			// unlike a sparse index, the filter also leaves out users whose credentials were all
+			// removed, an empty array would be indexed as a missing hash otherwise
+			SetPartialFilterExpression(bson.D{{Key: fieldCredentialHash, Value: bson.D{{Key: "$exists", Value: true}}}}),
+	})
+	if err != nil {
+		return fmt.Errorf("users.MongoRepository.EnsureIndexes unable to create indexes. %w", err)
+	}
+	return nil
+}
+
 type entity struct {
-	ID primitive.ObjectID `bson:"_id,omitempty"`
+	ID              primitive.ObjectID `bson:"_id,omitempty"`
+	DisplayName     string             `bson:"displayName,omitempty"`
+	NativeLanguage  string             `bson:"nativeLanguage,omitempty"`
+	TargetLanguages []string           `bson:"targetLanguages,omitempty"`
+	Timezone        string             `bson:"timezone,omitempty"`
+	DailyGoal       int                `bson:"dailyGoal,omitempty"`
+	ExerciseTypes   []string           `bson:"exerciseTypes,omitempty"`
+	SessionSize     int                `bson:"sessionSize,omitempty"`
+	Credentials     []credentialEntity `bson:"credentials,omitempty"`
+	// SchemaVersion is the version of the latest migration the document went through, zero for
+	// documents added before migrations.
+	SchemaVersion int `bson:"schemaVersion"`
+}
+
+type credentialEntity struct {
+	ID        string    `bson:"id"`
+	Kind      string    `bson:"kind"`
+	Name      string    `bson:"name,omitempty"`
+	Scope     string    `bson:"scope"`
+	Hash      string    `bson:"hash"`
+	CreatedAt time.Time `bson:"createdAt"`
+	ExpiresAt time.Time `bson:"expiresAt,omitempty"`
+	ParentID  string    `bson:"parentId,omitempty"`
+}
+
+const (
+	credentialKindAPIKey  = "apiKey"
+	credentialKindSession = "session"
+)
+
+func credentialFromModel(c Credential) (credentialEntity, error) {
+	scope, err := c.Scope.MarshalText()
+	if err != nil {
+		return credentialEntity{}, fmt.Errorf("unable to marshal credential's scope. %w", err)
+	}
+	kind := credentialKindAPIKey
+	if c.Kind == Session {
+		kind = credentialKindSession
+	}
+	return credentialEntity{
+		ID:        c.ID,
+		Kind:      kind,
+		Name:      c.Name,
+		Scope:     scope,
+		Hash:      c.Hash,
+		CreatedAt: c.CreatedAt,
+		ExpiresAt: c.ExpiresAt,
+		ParentID:  c.ParentID,
+	}, nil
+}
+
+func credentialToModel(e credentialEntity) (Credential, error) {
+	var scope Scope
+	if err := scope.UnmarshalText(e.Scope); err != nil {
+		return Credential{}, fmt.Errorf("unable to unmarshal credential's scope %s. %w", e.Scope, err)
+	}
+	kind := APIKey
+	if e.Kind == credentialKindSession {
+		kind = Session
+	}
+	return Credential{
+		ID:        e.ID,
+		Kind:      kind,
+		Name:      e.Name,
+		Scope:     scope,
+		Hash:      e.Hash,
+		CreatedAt: e.CreatedAt,
+		ExpiresAt: e.ExpiresAt,
+		ParentID:  e.ParentID,
+	}, nil
+}
+
+func entityFromModel(u models.User) (entity, error) {
+	targetLangs, err := marshalLanguages(u.TargetLanguages)
+	if err != nil {
+		return entity{}, err
+	}
+	exerciseTypes, err := marshalExerciseTypes(u.ExerciseTypes)
+	if err != nil {
+		return entity{}, err
+	}
+
+	return entity{
+		DisplayName:     u.DisplayName,
+		NativeLanguage:  string(u.NativeLanguage),
+		TargetLanguages: targetLangs,
+		Timezone:        u.Timezone,
+		DailyGoal:       u.DailyGoal,
+		ExerciseTypes:   exerciseTypes,
+		SessionSize:     u.SessionSize,
+	}, nil
+}
+
+func entityToModel(e entity) (models.User, error) {
+	u := models.User{
+		ID:          models.UserID(e.ID.Hex()),
+		DisplayName: e.DisplayName,
+		Timezone:    e.Timezone,
+		DailyGoal:   e.DailyGoal,
+		SessionSize: e.SessionSize,
+	}
+
+	// users created before languages were introduced have none
+	if e.NativeLanguage != "" {
+		if err := u.NativeLanguage.UnmarshalText(e.NativeLanguage); err != nil {
+			return models.User{}, fmt.Errorf("unable to unmarshal entity's native language %s. %w", e.NativeLanguage, err)
+		}
+	}
+	for _, l := range e.TargetLanguages {
+		var lang models.Language
+		if err := lang.UnmarshalText(l); err != nil {
+			return models.User{}, fmt.Errorf("unable to unmarshal entity's target language %s. %w", l, err)
+		}
+		u.TargetLanguages = append(u.TargetLanguages, lang)
+	}
+	for _, et := range e.ExerciseTypes {
+		var t models.ExerciseType
+		if err := t.UnmarshalText(et); err != nil {
+			return models.User{}, fmt.Errorf("unable to unmarshal entity's exercise type %s. %w", et, err)
+		}
+		u.ExerciseTypes = append(u.ExerciseTypes, t)
+	}
+
+	return u, nil
+}
+
+func marshalLanguages(langs []models.Language) ([]string, error) {
+	var res []string
+	for _, l := range langs {
+		s, err := l.MarshalText()
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal language %s. %w", l, err)
+		}
+		res = append(res, s)
+	}
+	return res, nil
+}
+
+func marshalExerciseTypes(types []models.ExerciseType) ([]string, error) {
+	var res []string
+	for _, t := range types {
+		s, err := t.MarshalText()
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal exercise type. %w", err)
+		}
+		res = append(res, s)
+	}
+	return res, nil
+}
+
+func (r MongoRepository) Create(ctx context.Context, profile models.User) (models.User, error) {
+	newEntity, err := entityFromModel(profile)
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.MongoRepository.Create unable to map model to entity. %w", err)
+	}
+	newEntity.SchemaVersion = MongoSchemaVersion
+
+	insRes, err := r.col.InsertOne(ctx, newEntity)
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.MongoRepository.Create unable to insert new user. %w", err)
+	}
+	var res entity
+	err = r.col.FindOne(ctx, bson.D{bson.E{Key: fieldID, Value: insRes.InsertedID}}).Decode(&res)
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.MongoRepository.Create unable to fetch newly created user %s. %w", insRes.InsertedID, err)
+	}
+	u, err := entityToModel(res)
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.MongoRepository.Create unable to map entity to model. %w", err)
+	}
+	return u, nil
+}
+
+func (r MongoRepository) Get(ctx context.Context, id models.UserID) (models.User, error) {
+	objID, err := primitive.ObjectIDFromHex(id.String())
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.MongoRepository.Get unable to build ObjectId from user's ID %s. %w", id, err)
+	}
+
+	var res entity
+	err = r.col.FindOne(ctx, bson.D{bson.E{Key: fieldID, Value: objID}}).Decode(&res)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return models.User{}, fmt.Errorf("users.MongoRepository.Get user %s. %w", id, ErrUserNotFound)
+	}
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.MongoRepository.Get unable to fetch user %s. %w", id, err)
+	}
+
+	u, err := entityToModel(res)
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.MongoRepository.Get unable to map entity to model. %w", err)
+	}
+	return u, nil
+}
+
+func (r MongoRepository) Update(ctx context.Context, id models.UserID, patch UserPatch) (models.User, error) {
+	objID, err := primitive.ObjectIDFromHex(id.String())
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.MongoRepository.Update unable to build ObjectId from user's ID %s. %w", id, err)
+	}
+
+	set := bson.D{}
+	if patch.DisplayName != nil {
+		set = append(set, bson.E{Key: fieldDisplayName, Value: *patch.DisplayName})
+	}
+	if patch.NativeLanguage != nil {
+		set = append(set, bson.E{Key: fieldNativeLanguage, Value: string(*patch.NativeLanguage)})
+	}
+	if patch.TargetLanguages != nil {
+		langs, err := marshalLanguages(patch.TargetLanguages)
+		if err != nil {
+			return models.User{}, fmt.Errorf("users.MongoRepository.Update. %w", err)
+		}
+		set = append(set, bson.E{Key: fieldTargetLanguages, Value: langs})
+	}
+	if patch.Timezone != nil {
+		set = append(set, bson.E{Key: fieldTimezone, Value: *patch.Timezone})
+	}
+	if patch.DailyGoal != nil {
+		set = append(set, bson.E{Key: fieldDailyGoal, Value: *patch.DailyGoal})
+	}
+	if patch.ExerciseTypes != nil {
+		types, err := marshalExerciseTypes(patch.ExerciseTypes)
+		if err != nil {
+			return models.User{}, fmt.Errorf("users.MongoRepository.Update. %w", err)
+		}
+		set = append(set, bson.E{Key: fieldExerciseTypes, Value: types})
+	}
+	if patch.SessionSize != nil {
+		set = append(set, bson.E{Key: fieldSessionSize, Value: *patch.SessionSize})
+	}
+
+	if len(set) == 0 {
+		return r.Get(ctx, id)
+	}
+
+	var res entity
+	err = r.col.FindOneAndUpdate(ctx,
+		bson.D{bson.E{Key: fieldID, Value: objID}},
+		bson.D{{Key: "$set", Value: set}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&res)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return models.User{}, fmt.Errorf("users.MongoRepository.Update user %s. %w", id, ErrUserNotFound)
+	}
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.MongoRepository.Update unable to update user %s. %w", id, err)
+	}
+
+	u, err := entityToModel(res)
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.MongoRepository.Update unable to map entity to model. %w", err)
+	}
+	return u, nil
 }
 
-// func entityFromModel(u models.User) (entity, error) {
-// 	var id primitive.ObjectID
-// 	if u.ID != "" {
-// 		parsedId, err := primitive.ObjectIDFromHex(u.ID.String())
-// 		if err != nil {
-// 			return entity{}, fmt.Errorf("unable to create ObjectID from hex value %s. %w", u.ID, err)
-// 		}
-// 		id = parsedId
-// 	}
+func (r MongoRepository) AddCredential(ctx context.Context, id models.UserID, c Credential) error {
+	objID, err := primitive.ObjectIDFromHex(id.String())
+	if err != nil {
+		return fmt.Errorf("users.MongoRepository.AddCredential unable to build ObjectId from user's ID %s. %w", id, err)
+	}
+
+	e, err := credentialFromModel(c)
+	if err != nil {
+		return fmt.Errorf("users.MongoRepository.AddCredential unable to map credential to entity. %w", err)
+	}
 
-// 	return entity{
-// 		ID: id,
-// 	}, nil
-// }
+	// drop expired sessions first, they'd pile up otherwise
+	_, err = r.col.UpdateOne(ctx,
+		bson.D{{Key: fieldID, Value: objID}},
+		bson.D{{Key: "$pull", Value: bson.D{{Key: fieldCredentials, Value: bson.D{
+			{Key: "kind", Value: credentialKindSession},
+			{Key: "expiresAt", Value: bson.D{{Key: "$lte", Value: c.CreatedAt}}},
+		}}}}},
+	)
+	if err != nil {
+		return fmt.Errorf("users.MongoRepository.AddCredential unable to remove expired sessions. %w", err)
+	}
 
-func entityToModel(e entity) models.User {
-	return models.User{
-		ID: models.UserID(e.ID.Hex()),
+	res, err := r.col.UpdateOne(ctx,
+		bson.D{{Key: fieldID, Value: objID}},
+		bson.D{{Key: "$push", Value: bson.D{{Key: fieldCredentials, Value: e}}}},
+	)
+	if err != nil {
+		return fmt.Errorf("users.MongoRepository.AddCredential unable to add credential. %w", err)
 	}
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("users.MongoRepository.AddCredential user %s. %w", id, ErrUserNotFound)
+	}
+	return nil
 }
 
-func (r MongoRepository) Create(ctx context.Context) (models.User, error) {
-	insRes, err := r.col.InsertOne(ctx, entity{})
+func (r MongoRepository) RemoveCredential(ctx context.Context, id models.UserID, credentialID string) error {
+	objID, err := primitive.ObjectIDFromHex(id.String())
+	if err != nil {
+		return fmt.Errorf("users.MongoRepository.RemoveCredential unable to build ObjectId from user's ID %s. %w", id, err)
+	}
+
+	res, err := r.col.UpdateOne(ctx,
+		bson.D{{Key: fieldID, Value: objID}, {Key: fieldCredentialID, Value: credentialID}},
+		bson.D{{Key: "$pull", Value: bson.D{{Key: fieldCredentials, Value: bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: "id", Value: credentialID}},
+			bson.D{{Key: "parentId", Value: credentialID}},
+		}}}}}}},
+	)
 	if err != nil {
-		return models.User{}, fmt.Errorf("users.MongoRepository.CreateOrUpdate unable to insert new user. %w", err)
+		return fmt.Errorf("users.MongoRepository.RemoveCredential unable to remove credential %s. %w", credentialID, err)
 	}
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("users.MongoRepository.RemoveCredential credential %s of user %s. %w", credentialID, id, ErrCredentialNotFound)
+	}
+	return nil
+}
+
+func (r MongoRepository) GetCredential(ctx context.Context, id models.UserID, credentialID string) (Credential, error) {
+	objID, err := primitive.ObjectIDFromHex(id.String())
+	if err != nil {
+		return Credential{}, fmt.Errorf("users.MongoRepository.GetCredential unable to build ObjectId from user's ID %s. %w", id, err)
+	}
+
+	var res entity
+	err = r.col.FindOne(ctx,
+		bson.D{{Key: fieldID, Value: objID}, {Key: fieldCredentialID, Value: credentialID}},
+		options.FindOne().SetProjection(bson.D{{Key: fieldCredentials, Value: 1}}),
+	).Decode(&res)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return Credential{}, fmt.Errorf("users.MongoRepository.GetCredential credential %s of user %s. %w", credentialID, id, ErrCredentialNotFound)
+	}
+	if err != nil {
+		return Credential{}, fmt.Errorf("users.MongoRepository.GetCredential unable to fetch user. %w", err)
+	}
+
+	for _, e := range res.Credentials {
+		if e.ID != credentialID {
+			continue
+		}
+		c, err := credentialToModel(e)
+		if err != nil {
+			return Credential{}, fmt.Errorf("users.MongoRepository.GetCredential unable to map entity to model. %w", err)
+		}
+		return c, nil
+	}
+	return Credential{}, fmt.Errorf("users.MongoRepository.GetCredential credential %s of user %s. %w", credentialID, id, ErrCredentialNotFound)
+}
+
+func (r MongoRepository) FindCredential(ctx context.Context, hash string) (models.UserID, Credential, error) {
 	var res entity
-	err = r.col.FindOne(ctx, bson.D{bson.E{Key: "_id", Value: insRes.InsertedID}}).Decode(&res)
+	err := r.col.FindOne(ctx, bson.D{{Key: fieldCredentialHash, Value: hash}}).Decode(&res)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return "", Credential{}, fmt.Errorf("users.MongoRepository.FindCredential. %w", ErrCredentialNotFound)
+	}
 	if err != nil {
-		return models.User{}, fmt.Errorf("users.MongoRepository.CreateOrUpdate unable to fetch newly created user %s. %w", insRes.InsertedID, err)
+		return "", Credential{}, fmt.Errorf("users.MongoRepository.FindCredential unable to fetch user. %w", err)
+	}
+
+	for _, e := range res.Credentials {
+		if e.Hash != hash {
+			continue
+		}
+		c, err := credentialToModel(e)
+		if err != nil {
+			return "", Credential{}, fmt.Errorf("users.MongoRepository.FindCredential unable to map entity to model. %w", err)
+		}
+		return models.UserID(res.ID.Hex()), c, nil
 	}
-	return entityToModel(res), nil
+	return "", Credential{}, fmt.Errorf("users.MongoRepository.FindCredential. %w", ErrCredentialNotFound)
 }
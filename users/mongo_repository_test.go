@@ -0,0 +1,47 @@
+package users_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/pavelpuchok/vocabforge/users"
+	"github.com/pavelpuchok/vocabforge/users/userstest"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TestMongoRepository runs against the MongoDB at VOCABFORGE_TEST_MONGO_URI, every test in
+// a database of its own. It's skipped when the variable isn't set.
+func TestMongoRepository(t *testing.T) {
+	t.Parallel()
+
+	uri := os.Getenv("VOCABFORGE_TEST_MONGO_URI")
+	if uri == "" {
+		t.Skip("VOCABFORGE_TEST_MONGO_URI is not set")
+	}
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = client.Disconnect(context.Background())
+	})
+
+	userstest.TestRepository(t, func(t *testing.T) users.Repository {
+		t.Helper()
+
+		db := client.Database("vocabforge_test_" + primitive.NewObjectID().Hex())
+		t.Cleanup(func() {
+			if err := db.Drop(context.Background()); err != nil {
+				t.Errorf("unable to drop test database. %s", err)
+			}
+		})
+		repo := users.NewMongoRepository(db)
+		if err := repo.EnsureIndexes(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		return repo
+	})
+}
@@ -0,0 +1,258 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pavelpuchok/vocabforge/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PostgresRepository keeps users in the users and credentials tables of a database opened
+// with postgresdb.Open.
+type PostgresRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresRepository(pool *pgxpool.Pool) PostgresRepository {
+	return PostgresRepository{
+		pool,
+	}
+}
+
+const postgresUserColumns = `id, display_name, native_language, target_languages, timezone, daily_goal, exercise_types, session_size`
+
+// postgresQuerier is either *pgxpool.Pool or pgx.Tx.
+type postgresQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// getPostgresUser fetches the user, forUpdate locks its row until the transaction ends.
+func getPostgresUser(ctx context.Context, q postgresQuerier, id models.UserID, forUpdate bool) (models.User, error) {
+	query := `SELECT ` + postgresUserColumns + ` FROM users WHERE id = $1`
+	if forUpdate {
+		query += ` FOR UPDATE`
+	}
+	var u models.User
+	var targetLangs, exerciseTypes []string
+	err := q.QueryRow(ctx, query, id).
+		Scan(&u.ID, &u.DisplayName, &u.NativeLanguage, &targetLangs, &u.Timezone, &u.DailyGoal, &exerciseTypes, &u.SessionSize)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.User{}, fmt.Errorf("user %s. %w", id, ErrUserNotFound)
+	}
+	if err != nil {
+		return models.User{}, fmt.Errorf("unable to fetch user %s. %w", id, err)
+	}
+
+	for _, l := range targetLangs {
+		u.TargetLanguages = append(u.TargetLanguages, models.Language(l))
+	}
+	for _, et := range exerciseTypes {
+		var t models.ExerciseType
+		if err := t.UnmarshalText(et); err != nil {
+			return models.User{}, fmt.Errorf("unable to unmarshal exercise type %s. %w", et, err)
+		}
+		u.ExerciseTypes = append(u.ExerciseTypes, t)
+	}
+	return u, nil
+}
+
+func postgresUserValues(u models.User) ([]any, error) {
+	// the array columns aren't nullable, so they get empty slices rather than nil ones
+	targetLangs := []string{}
+	for _, l := range u.TargetLanguages {
+		targetLangs = append(targetLangs, string(l))
+	}
+	types, err := marshalExerciseTypes(u.ExerciseTypes)
+	if err != nil {
+		return nil, err
+	}
+	if types == nil {
+		types = []string{}
+	}
+	return []any{u.ID, u.DisplayName, u.NativeLanguage, targetLangs, u.Timezone, u.DailyGoal, types, u.SessionSize}, nil
+}
+
+// postgresPlaceholders returns "$1, ..., $n".
+func postgresPlaceholders(n int) string {
+	placeholders := make([]string, n)
+	for i := range placeholders {
+		placeholders[i] = "$" + strconv.Itoa(i+1)
+	}
+	return strings.Join(placeholders, ", ")
+}
+
+func (r PostgresRepository) Create(ctx context.Context, profile models.User) (models.User, error) {
+	profile.ID = models.UserID(primitive.NewObjectID().Hex())
+	values, err := postgresUserValues(profile)
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.PostgresRepository.Create. %w", err)
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO users (`+postgresUserColumns+`) VALUES (`+postgresPlaceholders(len(values))+`)`, values...)
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.PostgresRepository.Create unable to insert new user. %w", err)
+	}
+
+	u, err := getPostgresUser(ctx, r.pool, profile.ID, false)
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.PostgresRepository.Create unable to fetch newly created user. %w", err)
+	}
+	return u, nil
+}
+
+func (r PostgresRepository) Get(ctx context.Context, id models.UserID) (models.User, error) {
+	u, err := getPostgresUser(ctx, r.pool, id, false)
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.PostgresRepository.Get. %w", err)
+	}
+	return u, nil
+}
+
+func (r PostgresRepository) Update(ctx context.Context, id models.UserID, patch UserPatch) (models.User, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.PostgresRepository.Update unable to begin transaction. %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	u, err := getPostgresUser(ctx, tx, id, true)
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.PostgresRepository.Update. %w", err)
+	}
+	if patch.DisplayName != nil {
+		u.DisplayName = *patch.DisplayName
+	}
+	if patch.NativeLanguage != nil {
+		u.NativeLanguage = *patch.NativeLanguage
+	}
+	if patch.TargetLanguages != nil {
+		u.TargetLanguages = patch.TargetLanguages
+	}
+	if patch.Timezone != nil {
+		u.Timezone = *patch.Timezone
+	}
+	if patch.DailyGoal != nil {
+		u.DailyGoal = *patch.DailyGoal
+	}
+	if patch.ExerciseTypes != nil {
+		u.ExerciseTypes = patch.ExerciseTypes
+	}
+	if patch.SessionSize != nil {
+		u.SessionSize = *patch.SessionSize
+	}
+
+	values, err := postgresUserValues(u)
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.PostgresRepository.Update. %w", err)
+	}
+	_, err = tx.Exec(ctx, `UPDATE users SET (`+postgresUserColumns+`) = (`+postgresPlaceholders(len(values))+`) WHERE id = $1`, values...)
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.PostgresRepository.Update unable to update user %s. %w", id, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return models.User{}, fmt.Errorf("users.PostgresRepository.Update unable to commit user %s. %w", id, err)
+	}
+	return u, nil
+}
+
+func (r PostgresRepository) AddCredential(ctx context.Context, id models.UserID, c Credential) error {
+	e, err := credentialFromModel(c)
+	if err != nil {
+		return fmt.Errorf("users.PostgresRepository.AddCredential unable to map credential to entity. %w", err)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("users.PostgresRepository.AddCredential unable to begin transaction. %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if _, err := getPostgresUser(ctx, tx, id, true); err != nil {
+		return fmt.Errorf("users.PostgresRepository.AddCredential. %w", err)
+	}
+
+	// drop expired sessions first, they'd pile up otherwise
+	_, err = tx.Exec(ctx, `DELETE FROM credentials WHERE user_id = $1 AND kind = $2 AND expires_at <= $3`,
+		id, credentialKindSession, c.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("users.PostgresRepository.AddCredential unable to remove expired sessions. %w", err)
+	}
+
+	var expiresAt pgtype.Timestamptz
+	if !e.ExpiresAt.IsZero() {
+		expiresAt = pgtype.Timestamptz{Time: e.ExpiresAt, Valid: true}
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO credentials (user_id, id, kind, name, scope, hash, created_at, expires_at, parent_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`, id, e.ID, e.Kind, e.Name, e.Scope, e.Hash, e.CreatedAt, expiresAt, e.ParentID)
+	if err != nil {
+		return fmt.Errorf("users.PostgresRepository.AddCredential unable to add credential. %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("users.PostgresRepository.AddCredential unable to commit credential. %w", err)
+	}
+	return nil
+}
+
+func (r PostgresRepository) RemoveCredential(ctx context.Context, id models.UserID, credentialID string) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM credentials WHERE user_id = $1 AND (id = $2 OR parent_id = $2)`, id, credentialID)
+	if err != nil {
+		return fmt.Errorf("users.PostgresRepository.RemoveCredential unable to remove credential %s. %w", credentialID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("users.PostgresRepository.RemoveCredential credential %s of user %s. %w", credentialID, id, ErrCredentialNotFound)
+	}
+	return nil
+}
+
+func (r PostgresRepository) GetCredential(ctx context.Context, id models.UserID, credentialID string) (Credential, error) {
+	var e credentialEntity
+	var expiresAt pgtype.Timestamptz
+	err := r.pool.QueryRow(ctx, `SELECT id, kind, name, scope, hash, created_at, expires_at, parent_id FROM credentials WHERE user_id = $1 AND id = $2`, id, credentialID).
+		Scan(&e.ID, &e.Kind, &e.Name, &e.Scope, &e.Hash, &e.CreatedAt, &expiresAt, &e.ParentID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Credential{}, fmt.Errorf("users.PostgresRepository.GetCredential credential %s of user %s. %w", credentialID, id, ErrCredentialNotFound)
+	}
+	if err != nil {
+		return Credential{}, fmt.Errorf("users.PostgresRepository.GetCredential unable to fetch credential. %w", err)
+	}
+
+	e.CreatedAt = e.CreatedAt.UTC()
+	if expiresAt.Valid {
+		e.ExpiresAt = expiresAt.Time.UTC()
+	}
+	c, err := credentialToModel(e)
+	if err != nil {
+		return Credential{}, fmt.Errorf("users.PostgresRepository.GetCredential unable to map entity to model. %w", err)
+	}
+	return c, nil
+}
+
+func (r PostgresRepository) FindCredential(ctx context.Context, hash string) (models.UserID, Credential, error) {
+	var userID models.UserID
+	var e credentialEntity
+	var expiresAt pgtype.Timestamptz
+	err := r.pool.QueryRow(ctx, `SELECT user_id, id, kind, name, scope, hash, created_at, expires_at, parent_id FROM credentials WHERE hash = $1`, hash).
+		Scan(&userID, &e.ID, &e.Kind, &e.Name, &e.Scope, &e.Hash, &e.CreatedAt, &expiresAt, &e.ParentID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", Credential{}, fmt.Errorf("users.PostgresRepository.FindCredential. %w", ErrCredentialNotFound)
+	}
+	if err != nil {
+		return "", Credential{}, fmt.Errorf("users.PostgresRepository.FindCredential unable to fetch credential. %w", err)
+	}
+
+	e.CreatedAt = e.CreatedAt.UTC()
+	if expiresAt.Valid {
+		e.ExpiresAt = expiresAt.Time.UTC()
+	}
+	c, err := credentialToModel(e)
+	if err != nil {
+		return "", Credential{}, fmt.Errorf("users.PostgresRepository.FindCredential unable to map entity to model. %w", err)
+	}
+	return userID, c, nil
+}
@@ -2,29 +2,109 @@ package users
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/pavelpuchok/vocabforge/models"
 )
 
+var (
+	ErrUserNotFound   = errors.New("user not found")
+	ErrInvalidProfile = errors.New("invalid user profile")
+)
+
 type Service struct {
 	repo Repository
+	now  func() time.Time
 }
 
 func NewService(repo Repository) Service {
 	return Service{
 		repo,
+		time.Now,
 	}
 }
 
 type Repository interface {
-	Create(ctx context.Context) (models.User, error)
+	// Create stores a new user with the given profile, the ID of profile is ignored.
+	Create(ctx context.Context, profile models.User) (models.User, error)
+	Get(ctx context.Context, id models.UserID) (models.User, error)
+	Update(ctx context.Context, id models.UserID, patch UserPatch) (models.User, error)
+	AddCredential(ctx context.Context, id models.UserID, c Credential) error
+	// RemoveCredential removes the credential and the sessions issued for it. It returns
+	// ErrCredentialNotFound if the user has no such credential.
+	RemoveCredential(ctx context.Context, id models.UserID, credentialID string) error
+	// GetCredential returns ErrCredentialNotFound if the user has no such credential.
+	GetCredential(ctx context.Context, id models.UserID, credentialID string) (Credential, error)
+	// FindCredential returns ErrCredentialNotFound if no user has a credential with the hash.
+	FindCredential(ctx context.Context, hash string) (models.UserID, Credential, error)
+}
+
+// UserPatch describes a partial profile update. Nil fields are left unchanged.
+type UserPatch struct {
+	DisplayName     *string
+	NativeLanguage  *models.Language
+	TargetLanguages []models.Language
+	Timezone        *string
+	DailyGoal       *int
+	ExerciseTypes   []models.ExerciseType
+	SessionSize     *int
 }
 
-func (s Service) Create(ctx context.Context) (models.User, error) {
-	u, err := s.repo.Create(ctx)
+func (s Service) Create(ctx context.Context, profile models.User) (models.User, error) {
+	if err := validateProfile(profile); err != nil {
+		return models.User{}, fmt.Errorf("users.Service.Create. %w", err)
+	}
+
+	u, err := s.repo.Create(ctx, profile)
 	if err != nil {
 		return u, fmt.Errorf("users.Service.Create failed. %w", err)
 	}
 	return u, nil
 }
+
+func (s Service) GetUser(ctx context.Context, id models.UserID) (models.User, error) {
+	u, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return u, fmt.Errorf("users.Service.GetUser failed. %w", err)
+	}
+	return u, nil
+}
+
+func (s Service) UpdateUser(ctx context.Context, id models.UserID, patch UserPatch) (models.User, error) {
+	var profile models.User
+	if patch.Timezone != nil {
+		profile.Timezone = *patch.Timezone
+	}
+	if patch.DailyGoal != nil {
+		profile.DailyGoal = *patch.DailyGoal
+	}
+	if patch.SessionSize != nil {
+		profile.SessionSize = *patch.SessionSize
+	}
+	if err := validateProfile(profile); err != nil {
+		return models.User{}, fmt.Errorf("users.Service.UpdateUser. %w", err)
+	}
+
+	u, err := s.repo.Update(ctx, id, patch)
+	if err != nil {
+		return u, fmt.Errorf("users.Service.UpdateUser failed. %w", err)
+	}
+	return u, nil
+}
+
+func validateProfile(u models.User) error {
+	if u.Timezone != "" {
+		if _, err := time.LoadLocation(u.Timezone); err != nil {
+			return fmt.Errorf("%w: unknown timezone %s", ErrInvalidProfile, u.Timezone)
+		}
+	}
+	if u.DailyGoal < 0 {
+		return fmt.Errorf("%w: negative daily goal %d", ErrInvalidProfile, u.DailyGoal)
+	}
+	if u.SessionSize < 0 {
+		return fmt.Errorf("%w: negative session size %d", ErrInvalidProfile, u.SessionSize)
+	}
+	return nil
+}
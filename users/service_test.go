@@ -0,0 +1,89 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pavelpuchok/vocabforge/models"
+)
+
+type fakeRepository struct {
+	Repository
+	patches     []UserPatch
+	credentials map[string]Credential
+	owners      map[string]models.UserID
+}
+
+func (r *fakeRepository) AddCredential(_ context.Context, id models.UserID, c Credential) error {
+	if r.credentials == nil {
+		r.credentials, r.owners = map[string]Credential{}, map[string]models.UserID{}
+	}
+	r.credentials[c.ID], r.owners[c.ID] = c, id
+	return nil
+}
+
+func (r *fakeRepository) RemoveCredential(_ context.Context, id models.UserID, credentialID string) error {
+	if r.owners[credentialID] != id {
+		return ErrCredentialNotFound
+	}
+	for id, c := range r.credentials {
+		if id == credentialID || c.ParentID == credentialID {
+			delete(r.credentials, id)
+		}
+	}
+	return nil
+}
+
+func (r *fakeRepository) GetCredential(_ context.Context, id models.UserID, credentialID string) (Credential, error) {
+	c, ok := r.credentials[credentialID]
+	if !ok || r.owners[credentialID] != id {
+		return Credential{}, ErrCredentialNotFound
+	}
+	return c, nil
+}
+
+func (r *fakeRepository) FindCredential(_ context.Context, hash string) (models.UserID, Credential, error) {
+	for id, c := range r.credentials {
+		if c.Hash == hash {
+			return r.owners[id], c, nil
+		}
+	}
+	return "", Credential{}, ErrCredentialNotFound
+}
+
+func (r *fakeRepository) Update(_ context.Context, id models.UserID, patch UserPatch) (models.User, error) {
+	r.patches = append(r.patches, patch)
+	return models.User{ID: id}, nil
+}
+
+func TestService_UpdateUser(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeRepository{}
+	s := NewService(repo)
+
+	tz, goal, size := "Europe/Berlin", 15, 20
+	if _, err := s.UpdateUser(context.Background(), "000000000000000000000001", UserPatch{Timezone: &tz, DailyGoal: &goal, SessionSize: &size}); err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := map[string]UserPatch{
+		"timezone":     {Timezone: ptr("Mars/Olympus")},
+		"daily goal":   {DailyGoal: ptr(-1)},
+		"session size": {SessionSize: ptr(-5)},
+	}
+	for name, patch := range invalid {
+		if _, err := s.UpdateUser(context.Background(), "000000000000000000000001", patch); !errors.Is(err, ErrInvalidProfile) {
+			t.Errorf("expected invalid profile error for %s, got %v", name, err)
+		}
+	}
+
+	if len(repo.patches) != 1 {
+		t.Errorf("expected invalid patches not to reach repository, got %d updates", len(repo.patches))
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
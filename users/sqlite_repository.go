@@ -0,0 +1,252 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SQLiteRepository keeps users in the users and credentials tables of a database opened
+// with sqlitedb.Open.
+type SQLiteRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteRepository(db *sql.DB) SQLiteRepository {
+	return SQLiteRepository{
+		db,
+	}
+}
+
+const sqliteUserColumns = `id, display_name, native_language, target_languages, timezone, daily_goal, exercise_types, session_size`
+
+// sqliteQuerier is either *sql.DB or *sql.Tx.
+type sqliteQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func getSQLiteUser(ctx context.Context, q sqliteQuerier, id models.UserID) (models.User, error) {
+	var u models.User
+	var targetLangs, exerciseTypes string
+	err := q.QueryRowContext(ctx, `SELECT `+sqliteUserColumns+` FROM users WHERE id = ?`, id).
+		Scan(&u.ID, &u.DisplayName, &u.NativeLanguage, &targetLangs, &u.Timezone, &u.DailyGoal, &exerciseTypes, &u.SessionSize)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.User{}, fmt.Errorf("user %s. %w", id, ErrUserNotFound)
+	}
+	if err != nil {
+		return models.User{}, fmt.Errorf("unable to fetch user %s. %w", id, err)
+	}
+
+	if err := json.Unmarshal([]byte(targetLangs), &u.TargetLanguages); err != nil {
+		return models.User{}, fmt.Errorf("unable to unmarshal target languages. %w", err)
+	}
+	var types []string
+	if err := json.Unmarshal([]byte(exerciseTypes), &types); err != nil {
+		return models.User{}, fmt.Errorf("unable to unmarshal exercise types. %w", err)
+	}
+	for _, et := range types {
+		var t models.ExerciseType
+		if err := t.UnmarshalText(et); err != nil {
+			return models.User{}, fmt.Errorf("unable to unmarshal exercise type %s. %w", et, err)
+		}
+		u.ExerciseTypes = append(u.ExerciseTypes, t)
+	}
+	return u, nil
+}
+
+func sqliteUserValues(u models.User) ([]any, error) {
+	targetLangs, err := json.Marshal(u.TargetLanguages)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal target languages. %w", err)
+	}
+	types, err := marshalExerciseTypes(u.ExerciseTypes)
+	if err != nil {
+		return nil, err
+	}
+	exerciseTypes, err := json.Marshal(types)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal exercise types. %w", err)
+	}
+	return []any{u.ID, u.DisplayName, u.NativeLanguage, string(targetLangs), u.Timezone, u.DailyGoal, string(exerciseTypes), u.SessionSize}, nil
+}
+
+func (r SQLiteRepository) Create(ctx context.Context, profile models.User) (models.User, error) {
+	profile.ID = models.UserID(primitive.NewObjectID().Hex())
+	values, err := sqliteUserValues(profile)
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.SQLiteRepository.Create. %w", err)
+	}
+	_, err = r.db.ExecContext(ctx, `INSERT INTO users (`+sqliteUserColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`, values...)
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.SQLiteRepository.Create unable to insert new user. %w", err)
+	}
+
+	u, err := getSQLiteUser(ctx, r.db, profile.ID)
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.SQLiteRepository.Create unable to fetch newly created user. %w", err)
+	}
+	return u, nil
+}
+
+func (r SQLiteRepository) Get(ctx context.Context, id models.UserID) (models.User, error) {
+	u, err := getSQLiteUser(ctx, r.db, id)
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.SQLiteRepository.Get. %w", err)
+	}
+	return u, nil
+}
+
+func (r SQLiteRepository) Update(ctx context.Context, id models.UserID, patch UserPatch) (models.User, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.SQLiteRepository.Update unable to begin transaction. %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	u, err := getSQLiteUser(ctx, tx, id)
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.SQLiteRepository.Update. %w", err)
+	}
+	if patch.DisplayName != nil {
+		u.DisplayName = *patch.DisplayName
+	}
+	if patch.NativeLanguage != nil {
+		u.NativeLanguage = *patch.NativeLanguage
+	}
+	if patch.TargetLanguages != nil {
+		u.TargetLanguages = patch.TargetLanguages
+	}
+	if patch.Timezone != nil {
+		u.Timezone = *patch.Timezone
+	}
+	if patch.DailyGoal != nil {
+		u.DailyGoal = *patch.DailyGoal
+	}
+	if patch.ExerciseTypes != nil {
+		u.ExerciseTypes = patch.ExerciseTypes
+	}
+	if patch.SessionSize != nil {
+		u.SessionSize = *patch.SessionSize
+	}
+
+	values, err := sqliteUserValues(u)
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.SQLiteRepository.Update. %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE users SET (`+sqliteUserColumns+`) = (?, ?, ?, ?, ?, ?, ?, ?) WHERE id = ?`, append(values, id)...)
+	if err != nil {
+		return models.User{}, fmt.Errorf("users.SQLiteRepository.Update unable to update user %s. %w", id, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return models.User{}, fmt.Errorf("users.SQLiteRepository.Update unable to commit user %s. %w", id, err)
+	}
+	return u, nil
+}
+
+func (r SQLiteRepository) AddCredential(ctx context.Context, id models.UserID, c Credential) error {
+	e, err := credentialFromModel(c)
+	if err != nil {
+		return fmt.Errorf("users.SQLiteRepository.AddCredential unable to map credential to entity. %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("users.SQLiteRepository.AddCredential unable to begin transaction. %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := getSQLiteUser(ctx, tx, id); err != nil {
+		return fmt.Errorf("users.SQLiteRepository.AddCredential. %w", err)
+	}
+
+	// drop expired sessions first, they'd pile up otherwise
+	_, err = tx.ExecContext(ctx, `DELETE FROM credentials WHERE user_id = ? AND kind = ? AND expires_at <= ?`,
+		id, credentialKindSession, c.CreatedAt.UnixMilli())
+	if err != nil {
+		return fmt.Errorf("users.SQLiteRepository.AddCredential unable to remove expired sessions. %w", err)
+	}
+
+	var expiresAt sql.NullInt64
+	if !e.ExpiresAt.IsZero() {
+		expiresAt = sql.NullInt64{Int64: e.ExpiresAt.UnixMilli(), Valid: true}
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO credentials (user_id, id, kind, name, scope, hash, created_at, expires_at, parent_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`, id, e.ID, e.Kind, e.Name, e.Scope, e.Hash, e.CreatedAt.UnixMilli(), expiresAt, e.ParentID)
+	if err != nil {
+		return fmt.Errorf("users.SQLiteRepository.AddCredential unable to add credential. %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("users.SQLiteRepository.AddCredential unable to commit credential. %w", err)
+	}
+	return nil
+}
+
+func (r SQLiteRepository) RemoveCredential(ctx context.Context, id models.UserID, credentialID string) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM credentials WHERE user_id = ? AND (id = ? OR parent_id = ?)`, id, credentialID, credentialID)
+	if err != nil {
+		return fmt.Errorf("users.SQLiteRepository.RemoveCredential unable to remove credential %s. %w", credentialID, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("users.SQLiteRepository.RemoveCredential unable to count removed credentials. %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("users.SQLiteRepository.RemoveCredential credential %s of user %s. %w", credentialID, id, ErrCredentialNotFound)
+	}
+	return nil
+}
+
+func (r SQLiteRepository) GetCredential(ctx context.Context, id models.UserID, credentialID string) (Credential, error) {
+	var e credentialEntity
+	var createdAt int64
+	var expiresAt sql.NullInt64
+	err := r.db.QueryRowContext(ctx, `SELECT id, kind, name, scope, hash, created_at, expires_at, parent_id FROM credentials WHERE user_id = ? AND id = ?`, id, credentialID).
+		Scan(&e.ID, &e.Kind, &e.Name, &e.Scope, &e.Hash, &createdAt, &expiresAt, &e.ParentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Credential{}, fmt.Errorf("users.SQLiteRepository.GetCredential credential %s of user %s. %w", credentialID, id, ErrCredentialNotFound)
+	}
+	if err != nil {
+		return Credential{}, fmt.Errorf("users.SQLiteRepository.GetCredential unable to fetch credential. %w", err)
+	}
+
+	e.CreatedAt = time.UnixMilli(createdAt).UTC()
+	if expiresAt.Valid {
+		e.ExpiresAt = time.UnixMilli(expiresAt.Int64).UTC()
+	}
+	c, err := credentialToModel(e)
+	if err != nil {
+		return Credential{}, fmt.Errorf("users.SQLiteRepository.GetCredential unable to map entity to model. %w", err)
+	}
+	return c, nil
+}
+
+func (r SQLiteRepository) FindCredential(ctx context.Context, hash string) (models.UserID, Credential, error) {
+	var userID models.UserID
+	var e credentialEntity
+	var createdAt int64
+	var expiresAt sql.NullInt64
+	err := r.db.QueryRowContext(ctx, `SELECT user_id, id, kind, name, scope, hash, created_at, expires_at, parent_id FROM credentials WHERE hash = ?`, hash).
+		Scan(&userID, &e.ID, &e.Kind, &e.Name, &e.Scope, &e.Hash, &createdAt, &expiresAt, &e.ParentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", Credential{}, fmt.Errorf("users.SQLiteRepository.FindCredential. %w", ErrCredentialNotFound)
+	}
+	if err != nil {
+		return "", Credential{}, fmt.Errorf("users.SQLiteRepository.FindCredential unable to fetch credential. %w", err)
+	}
+
+	e.CreatedAt = time.UnixMilli(createdAt).UTC()
+	if expiresAt.Valid {
+		e.ExpiresAt = time.UnixMilli(expiresAt.Int64).UTC()
+	}
+	c, err := credentialToModel(e)
+	if err != nil {
+		return "", Credential{}, fmt.Errorf("users.SQLiteRepository.FindCredential unable to map entity to model. %w", err)
+	}
+	return userID, c, nil
+}
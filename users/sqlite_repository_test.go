@@ -0,0 +1,26 @@
+package users_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/pavelpuchok/vocabforge/sqlitedb"
+	"github.com/pavelpuchok/vocabforge/users"
+	"github.com/pavelpuchok/vocabforge/users/userstest"
+)
+
+func TestSQLiteRepository(t *testing.T) {
+	t.Parallel()
+
+	userstest.TestRepository(t, func(t *testing.T) users.Repository {
+		t.Helper()
+
+		db, err := sqlitedb.Open(context.Background(), filepath.Join(t.TempDir(), "vocabforge.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { db.Close() })
+		return users.NewSQLiteRepository(db)
+	})
+}
@@ -0,0 +1,181 @@
+// Package userstest holds the conformance tests every users.Repository has to pass.
+package userstest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/users"
+)
+
+// newID returns a random ID in the form of MongoDB ObjectID, which all repositories accept.
+func newID() string {
+	b := make([]byte, 12) //nolint:mnd
+	// crypto/rand never fails on supported platforms
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// TestRepository runs the conformance tests, newRepository is called for every test and
+// has to return an empty repository.
+//
+//nolint:gocognit
+func TestRepository(t *testing.T, newRepository func(t *testing.T) users.Repository) {
+	t.Helper()
+
+	ctx := context.Background()
+	now := time.Date(2024, 9, 1, 10, 0, 0, 0, time.UTC)
+	profile := models.User{
+		ID:              models.UserID(newID()),
+		DisplayName:     "Pavel",
+		NativeLanguage:  "ru",
+		TargetLanguages: []models.Language{"en-US", "de"},
+		Timezone:        "Europe/Berlin",
+		DailyGoal:       10,
+		ExerciseTypes:   []models.ExerciseType{models.ClozeExercise},
+		SessionSize:     20,
+	}
+
+	t.Run("create, get and update user", func(t *testing.T) {
+		t.Parallel()
+		repo := newRepository(t)
+
+		created, err := repo.Create(ctx, profile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if created.ID == profile.ID || created.ID == "" {
+			t.Errorf("expected user to get a new ID, got %s", created.ID)
+		}
+		expected := profile
+		expected.ID = created.ID
+		if diff := cmp.Diff(expected, created, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("unexpected created user (-want +got):\n%s", diff)
+		}
+
+		got, err := repo.Get(ctx, created.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(expected, got, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("unexpected user (-want +got):\n%s", diff)
+		}
+
+		unchanged, err := repo.Update(ctx, created.ID, users.UserPatch{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(expected, unchanged, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("unexpected user after empty patch (-want +got):\n%s", diff)
+		}
+
+		timezone, goal := "Asia/Tokyo", 0
+		updated, err := repo.Update(ctx, created.ID, users.UserPatch{
+			Timezone:        &timezone,
+			DailyGoal:       &goal,
+			TargetLanguages: []models.Language{"ja"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected.Timezone, expected.DailyGoal, expected.TargetLanguages = "Asia/Tokyo", 0, []models.Language{"ja"}
+		if diff := cmp.Diff(expected, updated, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("unexpected updated user (-want +got):\n%s", diff)
+		}
+
+		if _, err := repo.Get(ctx, models.UserID(newID())); !errors.Is(err, users.ErrUserNotFound) {
+			t.Errorf("expected ErrUserNotFound, got %v", err)
+		}
+		if _, err := repo.Update(ctx, models.UserID(newID()), users.UserPatch{Timezone: &timezone}); !errors.Is(err, users.ErrUserNotFound) {
+			t.Errorf("expected ErrUserNotFound updating unknown user, got %v", err)
+		}
+	})
+
+	t.Run("credentials", func(t *testing.T) {
+		t.Parallel()
+		repo := newRepository(t)
+
+		created, err := repo.Create(ctx, profile)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		key := users.Credential{ID: "key", Kind: users.APIKey, Name: "laptop", Scope: users.ScopeAdmin, Hash: newID(), CreatedAt: now}
+		session := users.Credential{ID: "session", Kind: users.Session, Scope: users.ScopePractice, Hash: newID(), CreatedAt: now, ExpiresAt: now.Add(time.Hour), ParentID: key.ID}
+		for _, c := range []users.Credential{key, session} {
+			if err := repo.AddCredential(ctx, created.ID, c); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		userID, found, err := repo.FindCredential(ctx, session.Hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if userID != created.ID {
+			t.Errorf("expected credential of user %s, got %s", created.ID, userID)
+		}
+		if diff := cmp.Diff(session, found); diff != "" {
+			t.Errorf("unexpected credential (-want +got):\n%s", diff)
+		}
+		found, err = repo.GetCredential(ctx, created.ID, key.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(key, found); diff != "" {
+			t.Errorf("unexpected credential by ID (-want +got):\n%s", diff)
+		}
+		if _, err := repo.GetCredential(ctx, models.UserID(newID()), key.ID); !errors.Is(err, users.ErrCredentialNotFound) {
+			t.Errorf("expected ErrCredentialNotFound getting key of other user, got %v", err)
+		}
+
+		// adding a credential after the session expires drops the session
+		later := users.Credential{ID: "later", Kind: users.APIKey, Scope: users.ScopeReadOnly, Hash: newID(), CreatedAt: now.Add(2 * time.Hour)}
+		if err := repo.AddCredential(ctx, created.ID, later); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := repo.FindCredential(ctx, session.Hash); !errors.Is(err, users.ErrCredentialNotFound) {
+			t.Errorf("expected expired session to be dropped, got %v", err)
+		}
+		if _, _, err := repo.FindCredential(ctx, key.Hash); err != nil {
+			t.Errorf("expected API key to be kept, got %v", err)
+		}
+
+		// sessions are removed with the key they were issued for
+		child := users.Credential{ID: "child", Kind: users.Session, Scope: users.ScopeAdmin, Hash: newID(), CreatedAt: later.CreatedAt, ExpiresAt: later.CreatedAt.Add(time.Hour), ParentID: key.ID}
+		if err := repo.AddCredential(ctx, created.ID, child); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.RemoveCredential(ctx, created.ID, key.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := repo.FindCredential(ctx, key.Hash); !errors.Is(err, users.ErrCredentialNotFound) {
+			t.Errorf("expected removed key not to be found, got %v", err)
+		}
+		if _, err := repo.GetCredential(ctx, created.ID, key.ID); !errors.Is(err, users.ErrCredentialNotFound) {
+			t.Errorf("expected removed key not to be found by ID, got %v", err)
+		}
+		if _, _, err := repo.FindCredential(ctx, child.Hash); !errors.Is(err, users.ErrCredentialNotFound) {
+			t.Errorf("expected session of removed key not to be found, got %v", err)
+		}
+		if _, _, err := repo.FindCredential(ctx, later.Hash); err != nil {
+			t.Errorf("expected other key to be kept, got %v", err)
+		}
+		if err := repo.RemoveCredential(ctx, created.ID, key.ID); !errors.Is(err, users.ErrCredentialNotFound) {
+			t.Errorf("expected ErrCredentialNotFound removing key again, got %v", err)
+		}
+		if err := repo.RemoveCredential(ctx, models.UserID(newID()), later.ID); !errors.Is(err, users.ErrCredentialNotFound) {
+			t.Errorf("expected ErrCredentialNotFound removing key of other user, got %v", err)
+		}
+		if err := repo.AddCredential(ctx, models.UserID(newID()), key); !errors.Is(err, users.ErrUserNotFound) {
+			t.Errorf("expected ErrUserNotFound adding key to unknown user, got %v", err)
+		}
+	})
+}
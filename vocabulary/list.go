@@ -0,0 +1,221 @@
+package vocabulary
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+)
+
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 500
+)
+
+var (
+	ErrWordNotFound  = errors.New("word not found")
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrDuplicateWord is returned when the user has a word with the same spelling, regardless
+	// of case, in the language already. Archived words count too.
+	ErrDuplicateWord = errors.New("word already exists")
+)
+
+type SortOrder int
+
+const (
+	SortByCreatedAsc SortOrder = iota
+	SortByCreatedDesc
+	SortBySpellingAsc
+	SortBySpellingDesc
+	SortByDueAsc
+)
+
+func (o *SortOrder) String() string {
+	txt, err := o.MarshalText()
+	if err != nil {
+		return "unknown"
+	}
+	return txt
+}
+
+func (o *SortOrder) MarshalText() (string, error) {
+	switch *o {
+	case SortByCreatedAsc:
+		return "created", nil
+	case SortByCreatedDesc:
+		return "-created", nil
+	case SortBySpellingAsc:
+		return "spelling", nil
+	case SortBySpellingDesc:
+		return "-spelling", nil
+	case SortByDueAsc:
+		return "due", nil
+	default:
+		return "", fmt.Errorf("%d is unknown SortOrder", *o)
+	}
+}
+
+func (o *SortOrder) UnmarshalText(text string) error {
+	switch text {
+	case "created", "":
+		*o = SortByCreatedAsc
+	case "-created":
+		*o = SortByCreatedDesc
+	case "spelling":
+		*o = SortBySpellingAsc
+	case "-spelling":
+		*o = SortBySpellingDesc
+	case "due":
+		*o = SortByDueAsc
+	default:
+		return fmt.Errorf("%s is unknown SortOrder representation", text)
+	}
+	return nil
+}
+
+func SortOrderFromText(s string) (SortOrder, error) {
+	var o SortOrder
+	err := o.UnmarshalText(s)
+	if err != nil {
+		return 0, fmt.Errorf("vocabulary.SortOrderFromText invalid sort order string %s. %w", s, err)
+	}
+	return o, nil
+}
+
+func (o SortOrder) descending() bool {
+	return o == SortByCreatedDesc || o == SortBySpellingDesc
+}
+
+func (o SortOrder) bySpelling() bool {
+	return o == SortBySpellingAsc || o == SortBySpellingDesc
+}
+
+// ListFilter narrows down words returned by ListWords. Zero values mean "any".
+type ListFilter struct {
+	Language        models.Language
+	LearnStatus     *models.LearnStatus
+	LexicalCategory string
+	// Spelling keeps only words spelled exactly so, ignoring case. SpellingPrefix is ignored when it's set.
+	Spelling       string
+	SpellingPrefix string
+	// DueBefore keeps only words due for a review at the given time.
+	DueBefore       time.Time
+	IncludeArchived bool
+	Sort            SortOrder
+	// Cursor is an opaque value taken from WordsPage.NextCursor of the previous page.
+	Cursor string
+	Limit  int
+}
+
+type WordsPage struct {
+	Words []models.Word
+	// NextCursor is empty when there are no more words to fetch.
+	NextCursor string
+}
+
+// WordCounts are the numbers of words by learn status, and of the words due for a review.
+type WordCounts struct {
+	Pending    int
+	InProgress int
+	Learned    int
+	Due        int
+}
+
+// add counts n words of the status, due of them due for a review.
+func (c *WordCounts) add(status models.LearnStatus, n, due int) {
+	switch status {
+	case models.Pending:
+		c.Pending += n
+	case models.InProgress:
+		c.InProgress += n
+	case models.Learned:
+		c.Learned += n
+	}
+	c.Due += due
+}
+
+// cursor points to the last word of a page. Words are ordered by the sort key
+// with ID as a tie-breaker, so pagination stays stable while words are added.
+// Sort is the order the cursor was issued for, the page after the word differs in another one.
+type cursor struct {
+	ID       string     `json:"id"`
+	Spelling string     `json:"spelling,omitempty"`
+	Due      *time.Time `json:"due,omitempty"`
+	Sort     string     `json:"sort"`
+}
+
+func encodeCursor(w models.Word, order SortOrder) string {
+	c := cursor{ID: w.ID.String(), Sort: order.String()}
+	if order.bySpelling() {
+		c.Spelling = w.Spelling
+	}
+	if order == SortByDueAsc {
+		c.Due = &w.Schedule.Due
+	}
+	// cursor contains only strings, marshalling can't fail
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(s string, order SortOrder) (cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return cursor{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	if _, err := models.WordIDFromText(c.ID); err != nil {
+		return cursor{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	if c.Sort != order.String() {
+		return cursor{}, fmt.Errorf("%w: issued for %s sort order, not %s", ErrInvalidCursor, c.Sort, order.String())
+	}
+	return c, nil
+}
+
+func (c cursor) due() time.Time {
+	if c.Due == nil {
+		return time.Time{}
+	}
+	return *c.Due
+}
+
+func normalizeLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultListLimit
+	}
+	return min(limit, MaxListLimit)
+}
+
+// sqlListOrder returns the ORDER BY clause of the SQL repositories for the order.
+func sqlListOrder(order SortOrder) string {
+	direction := "ASC"
+	if order.descending() {
+		direction = "DESC"
+	}
+	switch {
+	case order.bySpelling():
+		return "spelling " + direction + ", id " + direction
+	case order == SortByDueAsc:
+		return "due " + direction + ", id " + direction
+	default:
+		return "id " + direction
+	}
+}
+
+// pageFromWords cuts the words fetched with limit+1 to the page and builds the next cursor.
+func pageFromWords(words []models.Word, limit int, order SortOrder) WordsPage {
+	if len(words) <= limit {
+		return WordsPage{Words: words}
+	}
+	words = words[:limit]
+	return WordsPage{
+		Words:      words,
+		NextCursor: encodeCursor(words[len(words)-1], order),
+	}
+}
@@ -0,0 +1,69 @@
+package vocabulary
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pavelpuchok/vocabforge/models"
+)
+
+func TestCursor(t *testing.T) {
+	t.Parallel()
+
+	w := models.Word{ID: "66f1a2b3c4d5e6f708091a2b", Spelling: "foo"}
+
+	actual, err := decodeCursor(encodeCursor(w, SortBySpellingDesc), SortBySpellingDesc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(cursor{ID: w.ID.String(), Spelling: "foo", Sort: "-spelling"}, actual); diff != "" {
+		t.Errorf("unexpected cursor (-want +got):\n%s", diff)
+	}
+
+	actual, err = decodeCursor(encodeCursor(w, SortByCreatedAsc), SortByCreatedAsc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(cursor{ID: w.ID.String(), Sort: "created"}, actual); diff != "" {
+		t.Errorf("unexpected cursor (-want +got):\n%s", diff)
+	}
+
+	// a cursor of one sort order doesn't point into a list of another one
+	if _, err := decodeCursor(encodeCursor(w, SortBySpellingDesc), SortBySpellingAsc); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("expected ErrInvalidCursor for another sort order, got %v", err)
+	}
+
+	for _, s := range []string{"!!!", "e30", "eyJpZCI6ImZvbyJ9"} {
+		if _, err := decodeCursor(s, SortByCreatedAsc); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("expected ErrInvalidCursor for %q, got %v", s, err)
+		}
+	}
+}
+
+func TestPageFromWords(t *testing.T) {
+	t.Parallel()
+
+	words := []models.Word{
+		{ID: "66f1a2b3c4d5e6f708091a21"},
+		{ID: "66f1a2b3c4d5e6f708091a22"},
+		{ID: "66f1a2b3c4d5e6f708091a23"},
+	}
+
+	page := pageFromWords(words, 3, SortByCreatedAsc)
+	if len(page.Words) != 3 || page.NextCursor != "" {
+		t.Errorf("unexpected last page %+v", page)
+	}
+
+	page = pageFromWords(words, 2, SortByCreatedAsc)
+	if len(page.Words) != 2 {
+		t.Errorf("unexpected page size %d", len(page.Words))
+	}
+	c, err := decodeCursor(page.NextCursor, SortByCreatedAsc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.ID != words[1].ID.String() {
+		t.Errorf("unexpected cursor ID %s", c.ID)
+	}
+}
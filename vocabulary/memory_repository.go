@@ -0,0 +1,323 @@
+package vocabulary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryRepository keeps words in memory, for experiments and tests. It's safe for
+// concurrent use. Words get ObjectID like IDs, so they are ordered by creation as in
+// MongoRepository.
+type MemoryRepository struct {
+	mu    sync.RWMutex
+	words map[models.WordID]models.Word
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		words: map[models.WordID]models.Word{},
+	}
+}
+
+func (r *MemoryRepository) AddWord(_ context.Context, userID models.UserID, spell, definition, lexicalCategory string, lang, definitionLang models.Language, exercises []models.SentenceExercise) (models.Word, error) {
+	w := models.Word{
+		ID:                 models.WordID(primitive.NewObjectID().Hex()),
+		UserID:             userID,
+		Spelling:           spell,
+		Definition:         definition,
+		Language:           lang,
+		DefinitionLanguage: definitionLang,
+		LearnStatus:        models.Pending,
+		LexicalCategory:    lexicalCategory,
+		Exercises:          slices.Clone(exercises),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.hasDuplicate(w) {
+		return models.Word{}, fmt.Errorf("vocabulary.MemoryRepository.AddWord word %s. %w", spell, ErrDuplicateWord)
+	}
+	r.words[w.ID] = w
+	return copyWord(w), nil
+}
+
+// RestoreWord stores the word with its learning state under a new ID.
+func (r *MemoryRepository) RestoreWord(_ context.Context, userID models.UserID, w models.Word) (models.Word, error) {
+	w = copyWord(w)
+	w.ID = models.WordID(primitive.NewObjectID().Hex())
+	w.UserID = userID
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.hasDuplicate(w) {
+		return models.Word{}, fmt.Errorf("vocabulary.MemoryRepository.RestoreWord word %s. %w", w.Spelling, ErrDuplicateWord)
+	}
+	r.words[w.ID] = w
+	return copyWord(w), nil
+}
+
+// hasDuplicate reports whether another word of the user has the spelling in the language,
+// the caller holds the lock.
+func (r *MemoryRepository) hasDuplicate(w models.Word) bool {
+	for _, other := range r.words {
+		if other.ID != w.ID && other.UserID == w.UserID && other.Language == w.Language && strings.EqualFold(other.Spelling, w.Spelling) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *MemoryRepository) GetWord(_ context.Context, userID models.UserID, wordID models.WordID) (models.Word, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	w, ok := r.words[wordID]
+	if !ok || w.UserID != userID {
+		return models.Word{}, fmt.Errorf("vocabulary.MemoryRepository.GetWord word %s. %w", wordID, ErrWordNotFound)
+	}
+	return copyWord(w), nil
+}
+
+func (r *MemoryRepository) ListWords(_ context.Context, userID models.UserID, filter ListFilter) (WordsPage, error) {
+	var after cursor
+	if filter.Cursor != "" {
+		c, err := decodeCursor(filter.Cursor, filter.Sort)
+		if err != nil {
+			return WordsPage{}, fmt.Errorf("vocabulary.MemoryRepository.ListWords. %w", err)
+		}
+		after = c
+	}
+
+	r.mu.RLock()
+	var words []models.Word
+	for _, w := range r.words {
+		if w.UserID == userID && matchesFilter(w, filter) && (filter.Cursor == "" || compareWords(w, after, filter.Sort) > 0) {
+			words = append(words, copyWord(w))
+		}
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(words, func(a, b models.Word) int {
+		return compareWords(a, cursor{ID: b.ID.String(), Spelling: b.Spelling, Due: &b.Schedule.Due}, filter.Sort)
+	})
+
+	limit := normalizeLimit(filter.Limit)
+	if len(words) > limit+1 {
+		words = words[:limit+1]
+	}
+	return pageFromWords(words, limit, filter.Sort), nil
+}
+
+func (r *MemoryRepository) CountWords(_ context.Context, userID models.UserID, filter ListFilter, dueAt time.Time) (WordCounts, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var counts WordCounts
+	for _, w := range r.words {
+		if w.UserID != userID || !matchesFilter(w, filter) {
+			continue
+		}
+		due := 0
+		if !w.Schedule.Due.After(dueAt) {
+			due = 1
+		}
+		counts.add(w.LearnStatus, 1, due)
+	}
+	return counts, nil
+}
+
+func matchesFilter(w models.Word, filter ListFilter) bool {
+	switch {
+	case filter.Language != "" && w.Language != filter.Language:
+		return false
+	case filter.LearnStatus != nil && w.LearnStatus != *filter.LearnStatus:
+		return false
+	case filter.LexicalCategory != "" && w.LexicalCategory != filter.LexicalCategory:
+		return false
+	case !filter.DueBefore.IsZero() && w.Schedule.Due.After(filter.DueBefore):
+		return false
+	case !filter.IncludeArchived && w.Archived:
+		return false
+	case filter.Spelling != "":
+		return strings.EqualFold(w.Spelling, filter.Spelling)
+	case filter.SpellingPrefix != "":
+		return strings.HasPrefix(w.Spelling, filter.SpellingPrefix)
+	default:
+		return true
+	}
+}
+
+// compareWords compares the word with the one the cursor points to in the order,
+// a positive result means the word comes after it.
+func compareWords(w models.Word, c cursor, order SortOrder) int {
+	var res int
+	switch {
+	case order.bySpelling():
+		res = strings.Compare(w.Spelling, c.Spelling)
+	case order == SortByDueAsc:
+		res = w.Schedule.Due.Compare(c.due())
+	}
+	if res == 0 {
+		res = strings.Compare(w.ID.String(), c.ID)
+	}
+	if order.descending() {
+		return -res
+	}
+	return res
+}
+
+func (r *MemoryRepository) UpdateWord(_ context.Context, userID models.UserID, wordID models.WordID, patch WordPatch) (models.Word, error) {
+	w, err := r.update(userID, wordID, func(w *models.Word) {
+		if patch.Spelling != nil {
+			w.Spelling = *patch.Spelling
+		}
+		if patch.Definition != nil {
+			w.Definition = *patch.Definition
+		}
+		if patch.LexicalCategory != nil {
+			w.LexicalCategory = *patch.LexicalCategory
+		}
+		if patch.Language != nil {
+			w.Language = *patch.Language
+		}
+		if patch.Exercises != nil {
+			w.Exercises = slices.Clone(patch.Exercises)
+		}
+		if patch.Tags != nil {
+			w.Tags = slices.Clone(patch.Tags)
+		}
+		if patch.Source != nil {
+			w.Source = *patch.Source
+		}
+	})
+	if err != nil {
+		return w, fmt.Errorf("vocabulary.MemoryRepository.UpdateWord. %w", err)
+	}
+	return w, nil
+}
+
+func (r *MemoryRepository) ArchiveWord(_ context.Context, userID models.UserID, wordID models.WordID) (models.Word, error) {
+	w, err := r.update(userID, wordID, func(w *models.Word) {
+		w.Archived = true
+	})
+	if err != nil {
+		return w, fmt.Errorf("vocabulary.MemoryRepository.ArchiveWord. %w", err)
+	}
+	return w, nil
+}
+
+func (r *MemoryRepository) UpdateSchedule(_ context.Context, userID models.UserID, wordID models.WordID, lastReview time.Time, status models.LearnStatus, schedule models.Schedule) (models.Word, error) {
+	changed := false
+	w, err := r.update(userID, wordID, func(w *models.Word) {
+		if !w.Schedule.LastReview.Equal(lastReview) {
+			changed = true
+			return
+		}
+		w.LearnStatus = status
+		w.Schedule = schedule
+	})
+	if err != nil {
+		return w, fmt.Errorf("vocabulary.MemoryRepository.UpdateSchedule. %w", err)
+	}
+	if changed {
+		return models.Word{}, fmt.Errorf("vocabulary.MemoryRepository.UpdateSchedule word %s. %w", wordID, ErrScheduleChanged)
+	}
+	return w, nil
+}
+
+func (r *MemoryRepository) RecordAnswer(_ context.Context, userID models.UserID, wordID models.WordID, exerciseIndex int, correct bool, status models.LearnStatus, schedule models.Schedule) (models.Word, error) {
+	w, err := r.update(userID, wordID, func(w *models.Word) {
+		w.LearnStatus = status
+		w.Schedule = schedule
+		if !correct {
+			return
+		}
+		if exerciseIndex >= 0 && exerciseIndex < len(w.Exercises) {
+			w.Exercises[exerciseIndex].Answered = true
+		}
+		w.AnsweredCount++
+	})
+	if err != nil {
+		return w, fmt.Errorf("vocabulary.MemoryRepository.RecordAnswer. %w", err)
+	}
+	return w, nil
+}
+
+func (r *MemoryRepository) DeleteWord(_ context.Context, userID models.UserID, wordID models.WordID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w, ok := r.words[wordID]
+	if !ok || w.UserID != userID {
+		return fmt.Errorf("vocabulary.MemoryRepository.DeleteWord word %s. %w", wordID, ErrWordNotFound)
+	}
+	delete(r.words, wordID)
+	return nil
+}
+
+// update applies fn to a copy of the word and stores the copy, so words handed out are never changed.
+func (r *MemoryRepository) update(userID models.UserID, wordID models.WordID, fn func(w *models.Word)) (models.Word, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w, ok := r.words[wordID]
+	if !ok || w.UserID != userID {
+		return models.Word{}, fmt.Errorf("word %s. %w", wordID, ErrWordNotFound)
+	}
+	w = copyWord(w)
+	fn(&w)
+	if r.hasDuplicate(w) {
+		return models.Word{}, fmt.Errorf("word %s. %w", wordID, ErrDuplicateWord)
+	}
+	r.words[wordID] = w
+	return copyWord(w), nil
+}
+
+// MarshalJSON writes all the words, ordered by ID, for a snapshot.
+func (r *MemoryRepository) MarshalJSON() ([]byte, error) {
+	r.mu.RLock()
+	words := make([]models.Word, 0, len(r.words))
+	for _, w := range r.words {
+		words = append(words, w)
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(words, func(a, b models.Word) int {
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+	b, err := json.Marshal(words)
+	if err != nil {
+		return nil, fmt.Errorf("vocabulary.MemoryRepository.MarshalJSON. %w", err)
+	}
+	return b, nil
+}
+
+// UnmarshalJSON replaces the words with the ones of a snapshot.
+func (r *MemoryRepository) UnmarshalJSON(b []byte) error {
+	var words []models.Word
+	if err := json.Unmarshal(b, &words); err != nil {
+		return fmt.Errorf("vocabulary.MemoryRepository.UnmarshalJSON. %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.words = make(map[models.WordID]models.Word, len(words))
+	for _, w := range words {
+		r.words[w.ID] = w
+	}
+	return nil
+}
+
+func copyWord(w models.Word) models.Word {
+	w.Exercises = slices.Clone(w.Exercises)
+	w.Tags = slices.Clone(w.Tags)
+	return w
+}
@@ -0,0 +1,16 @@
+package vocabulary_test
+
+import (
+	"testing"
+
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+	"github.com/pavelpuchok/vocabforge/vocabulary/vocabularytest"
+)
+
+func TestMemoryRepository(t *testing.T) {
+	t.Parallel()
+
+	vocabularytest.TestRepository(t, func(*testing.T) vocabulary.Repository {
+		return vocabulary.NewMemoryRepository()
+	})
+}
@@ -2,14 +2,22 @@ package vocabulary
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"time"
 
 	"github.com/pavelpuchok/vocabforge/models"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// MongoSchemaVersion is the version of the latest migration in package mongodb, new words are
+// written with it.
+const MongoSchemaVersion = 2
+
 type MongoRepository struct {
 	col *mongo.Collection
 }
@@ -21,16 +29,81 @@ func NewMongoRepository(db *mongo.Database) MongoRepository {
 	}
 }
 
+const (
+	fieldID              = "_id"
+	fieldUserID          = "userId"
+	fieldSpelling        = "spelling"
+	fieldLanguage        = "language"
+	fieldLearnStatus     = "learnstatus"
+	fieldLexicalCategory = "lexicalcategory"
+	fieldDefinition      = "definition"
+	fieldExercises       = "exercises"
+	fieldArchived        = "archived"
+	fieldSchedule        = "schedule"
+	fieldScheduleDue     = "schedule.due"
+	fieldAnsweredCount   = "answeredcount"
+	fieldTags            = "tags"
+	fieldSource          = "source"
+)
+
+// spellingCollation compares letters and diacritics but not case, spelling is looked up and
+// kept unique with it.
+var spellingCollation = &options.Collation{Locale: "en", Strength: 2}
+
+// EnsureIndexes creates the indexes of the vocabulary collection: a unique one on user, language
+// and spelling regardless of its case, one for scheduling reviews and a text one on spelling and
+// definition. Creating the unique index fails with ErrDuplicateWord if such words are there already.
+func (r MongoRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: fieldUserID, Value: 1}, {Key: fieldLanguage, Value: 1}, {Key: fieldSpelling, Value: 1}},
+			Options: options.Index().
+				SetName("userId_language_spelling").
+				SetUnique(true).
+				SetCollation(spellingCollation),
+		},
+		{
+			Keys:    bson.D{{Key: fieldUserID, Value: 1}, {Key: fieldLearnStatus, Value: 1}, {Key: fieldScheduleDue, Value: 1}},
+			Options: options.Index().SetName("userId_learnstatus_due"),
+		},
+		{
+			Keys: bson.D{{Key: fieldSpelling, Value: "text"}, {Key: fieldDefinition, Value: "text"}},
+			Options: options.Index().
+				SetName("spelling_definition_text").
+				// words of different languages share the index, so there is no stemming. The language
+				// field holds codes MongoDB doesn't know, it mustn't be taken as the text's language.
+				SetDefaultLanguage("none").
+				SetLanguageOverride("textLanguage"),
+		},
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("vocabulary.MongoRepository.EnsureIndexes remove duplicate words first. %w", ErrDuplicateWord)
+	}
+	if err != nil {
+		return fmt.Errorf("vocabulary.MongoRepository.EnsureIndexes unable to create indexes. %w", err)
+	}
+	return nil
+}
+
 type entity struct {
-	ID              primitive.ObjectID `bson:"_id,omitempty"`
-	UserID          primitive.ObjectID `bson:"userId,omitempty"`
-	Spelling        string
-	Definition      string
-	Language        string
-	LearnStatus     string
-	LexicalCategory string
-	AnsweredCount   uint
-	Exercises       []models.SentenceExercise
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	UserID     primitive.ObjectID `bson:"userId,omitempty"`
+	Spelling   string
+	Definition string
+	Language   string
+	// DefinitionLanguage is empty for words added before it was recorded
+	DefinitionLanguage string
+	LearnStatus        string
+	LexicalCategory    string
+	AnsweredCount      uint
+	Exercises          []models.SentenceExercise
+	Archived           bool
+	Schedule           models.Schedule
+	Tags               []string `bson:"tags,omitempty"`
+	Source             string   `bson:"source,omitempty"`
+	// SchemaVersion is the version of the latest migration the document went through, zero for
+	// documents added before migrations.
+	SchemaVersion int `bson:"schemaVersion"`
 }
 
 func entityToModel(e entity) (models.Word, error) {
@@ -42,20 +115,42 @@ func entityToModel(e entity) (models.Word, error) {
 	if err := lang.UnmarshalText(e.Language); err != nil {
 		return models.Word{}, fmt.Errorf("unable to unmarshal entity's language %s. %w", e.Language, err)
 	}
+	var definitionLang models.Language
+	if e.DefinitionLanguage != "" {
+		if err := definitionLang.UnmarshalText(e.DefinitionLanguage); err != nil {
+			return models.Word{}, fmt.Errorf("unable to unmarshal entity's definition language %s. %w", e.DefinitionLanguage, err)
+		}
+	}
 
 	return models.Word{
-		ID:              models.WordID(e.ID.Hex()),
-		UserID:          models.UserID(e.UserID.Hex()),
-		Spelling:        e.Spelling,
-		Definition:      e.Definition,
-		Language:        lang,
-		LearnStatus:     status,
-		LexicalCategory: e.LexicalCategory,
-		AnsweredCount:   e.AnsweredCount,
+		ID:                 models.WordID(e.ID.Hex()),
+		UserID:             models.UserID(e.UserID.Hex()),
+		Spelling:           e.Spelling,
+		Definition:         e.Definition,
+		Language:           lang,
+		DefinitionLanguage: definitionLang,
+		LearnStatus:        status,
+		LexicalCategory:    e.LexicalCategory,
+		AnsweredCount:      e.AnsweredCount,
+		Exercises:          e.Exercises,
+		Archived:           e.Archived,
+		Schedule:           e.Schedule,
+		Tags:               e.Tags,
+		Source:             e.Source,
 	}, nil
 }
 
-func (r MongoRepository) AddWord(ctx context.Context, userID models.UserID, spell, definition, lexicalCategory string, lang models.Language, exercises []models.SentenceExercise) (models.Word, error) {
+// marshalLanguage validates the language before it's written, so new words get canonical
+// languages only.
+func marshalLanguage(l models.Language) (string, error) {
+	lang, err := models.LanguageFromText(string(l))
+	if err != nil {
+		return "", err
+	}
+	return lang.MarshalText()
+}
+
+func (r MongoRepository) AddWord(ctx context.Context, userID models.UserID, spell, definition, lexicalCategory string, lang, definitionLang models.Language, exercises []models.SentenceExercise) (models.Word, error) {
 	userId, err := primitive.ObjectIDFromHex(userID.String())
 	if err != nil {
 		return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.AddWord unable to build ObjectId from user's ID %s. %w", userID, err)
@@ -64,25 +159,37 @@ func (r MongoRepository) AddWord(ctx context.Context, userID models.UserID, spel
 	defStatus := models.Pending
 	statusMarshalled, _ := defStatus.MarshalText()
 
-	langMarshalled, err := lang.MarshalText()
+	langMarshalled, err := marshalLanguage(lang)
 	if err != nil {
 		return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.AddWord unable to marhal language %v. %w", lang, err)
 	}
+	var definitionLangMarshalled string
+	if definitionLang != "" {
+		definitionLangMarshalled, err = marshalLanguage(definitionLang)
+		if err != nil {
+			return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.AddWord unable to marshal definition language %v. %w", definitionLang, err)
+		}
+	}
 
 	newEntity := entity{
-		UserID:          userId,
-		Spelling:        spell,
-		Definition:      definition,
-		Language:        langMarshalled,
-		LearnStatus:     statusMarshalled,
-		LexicalCategory: lexicalCategory,
-		AnsweredCount:   0,
-		Exercises:       exercises,
+		UserID:             userId,
+		Spelling:           spell,
+		Definition:         definition,
+		Language:           langMarshalled,
+		DefinitionLanguage: definitionLangMarshalled,
+		LearnStatus:        statusMarshalled,
+		LexicalCategory:    lexicalCategory,
+		AnsweredCount:      0,
+		Exercises:          exercises,
+		SchemaVersion:      MongoSchemaVersion,
 	}
 
 	insRes, err := r.col.InsertOne(ctx, newEntity)
+	if mongo.IsDuplicateKeyError(err) {
+		return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.AddWord word %s. %w", spell, ErrDuplicateWord)
+	}
 	if err != nil {
-		return models.Word{}, err
+		return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.AddWord unable to insert word. %w", err)
 	}
 
 	var insertedEntity entity
@@ -98,3 +205,408 @@ func (r MongoRepository) AddWord(ctx context.Context, userID models.UserID, spel
 
 	return m, nil
 }
+
+// RestoreWord inserts the word with its learning state under a new ID.
+func (r MongoRepository) RestoreWord(ctx context.Context, userID models.UserID, w models.Word) (models.Word, error) {
+	userId, err := primitive.ObjectIDFromHex(userID.String())
+	if err != nil {
+		return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.RestoreWord unable to build ObjectId from user's ID %s. %w", userID, err)
+	}
+
+	status, err := w.LearnStatus.MarshalText()
+	if err != nil {
+		return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.RestoreWord unable to marshal status. %w", err)
+	}
+	lang, err := marshalLanguage(w.Language)
+	if err != nil {
+		return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.RestoreWord unable to marshal language %v. %w", w.Language, err)
+	}
+	var definitionLang string
+	if w.DefinitionLanguage != "" {
+		definitionLang, err = marshalLanguage(w.DefinitionLanguage)
+		if err != nil {
+			return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.RestoreWord unable to marshal definition language %v. %w", w.DefinitionLanguage, err)
+		}
+	}
+
+	insRes, err := r.col.InsertOne(ctx, entity{
+		UserID:             userId,
+		Spelling:           w.Spelling,
+		Definition:         w.Definition,
+		Language:           lang,
+		DefinitionLanguage: definitionLang,
+		LearnStatus:        status,
+		LexicalCategory:    w.LexicalCategory,
+		AnsweredCount:      w.AnsweredCount,
+		Exercises:          w.Exercises,
+		Archived:           w.Archived,
+		Schedule:           w.Schedule,
+		Tags:               w.Tags,
+		Source:             w.Source,
+		SchemaVersion:      MongoSchemaVersion,
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.RestoreWord word %s. %w", w.Spelling, ErrDuplicateWord)
+	}
+	if err != nil {
+		return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.RestoreWord unable to insert word. %w", err)
+	}
+
+	id, _ := insRes.InsertedID.(primitive.ObjectID)
+	word, err := r.GetWord(ctx, userID, models.WordID(id.Hex()))
+	if err != nil {
+		return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.RestoreWord unable to fetch inserted word. %w", err)
+	}
+	return word, nil
+}
+
+func (r MongoRepository) GetWord(ctx context.Context, userID models.UserID, wordID models.WordID) (models.Word, error) {
+	filter, err := wordFilter(userID, wordID)
+	if err != nil {
+		return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.GetWord unable to build filter. %w", err)
+	}
+
+	var e entity
+	err = r.col.FindOne(ctx, filter).Decode(&e)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.GetWord word %s. %w", wordID, ErrWordNotFound)
+	}
+	if err != nil {
+		return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.GetWord unable to fetch word %s. %w", wordID, err)
+	}
+
+	m, err := entityToModel(e)
+	if err != nil {
+		return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.GetWord unable to map entity to model. %w", err)
+	}
+	return m, nil
+}
+
+func (r MongoRepository) ListWords(ctx context.Context, userID models.UserID, filter ListFilter) (WordsPage, error) {
+	query, err := listQuery(userID, filter)
+	if err != nil {
+		return WordsPage{}, fmt.Errorf("vocabulary.MongoRepository.ListWords unable to build query. %w", err)
+	}
+
+	limit := normalizeLimit(filter.Limit)
+	opts := options.Find().
+		SetSort(listSort(filter.Sort)).
+		SetLimit(int64(limit + 1))
+	if filter.Spelling != "" {
+		opts.SetCollation(spellingCollation)
+	}
+
+	cur, err := r.col.Find(ctx, query, opts)
+	if err != nil {
+		return WordsPage{}, fmt.Errorf("vocabulary.MongoRepository.ListWords unable to find words. %w", err)
+	}
+
+	var entities []entity
+	if err := cur.All(ctx, &entities); err != nil {
+		return WordsPage{}, fmt.Errorf("vocabulary.MongoRepository.ListWords unable to decode words. %w", err)
+	}
+
+	words := make([]models.Word, len(entities))
+	for i, e := range entities {
+		words[i], err = entityToModel(e)
+		if err != nil {
+			return WordsPage{}, fmt.Errorf("vocabulary.MongoRepository.ListWords unable to map entity to model. %w", err)
+		}
+	}
+
+	return pageFromWords(words, limit, filter.Sort), nil
+}
+
+func (r MongoRepository) CountWords(ctx context.Context, userID models.UserID, filter ListFilter, dueAt time.Time) (WordCounts, error) {
+	filter.Cursor = ""
+	query, err := listQuery(userID, filter)
+	if err != nil {
+		return WordCounts{}, fmt.Errorf("vocabulary.MongoRepository.CountWords unable to build query. %w", err)
+	}
+
+	opts := options.Aggregate()
+	if filter.Spelling != "" {
+		opts.SetCollation(spellingCollation)
+	}
+	// a missing due sorts before any time, words created before scheduling are due as with DueBefore
+	isDue := bson.D{{Key: "$lte", Value: bson.A{"$" + fieldScheduleDue, dueAt}}}
+	cur, err := r.col.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: query}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$" + fieldLearnStatus},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "due", Value: bson.D{{Key: "$sum", Value: bson.D{{Key: "$cond", Value: bson.A{isDue, 1, 0}}}}}},
+		}}},
+	}, opts)
+	if err != nil {
+		return WordCounts{}, fmt.Errorf("vocabulary.MongoRepository.CountWords unable to count words. %w", err)
+	}
+
+	var groups []struct {
+		LearnStatus string `bson:"_id"`
+		Count       int    `bson:"count"`
+		Due         int    `bson:"due"`
+	}
+	if err := cur.All(ctx, &groups); err != nil {
+		return WordCounts{}, fmt.Errorf("vocabulary.MongoRepository.CountWords unable to decode counts. %w", err)
+	}
+
+	var counts WordCounts
+	for _, g := range groups {
+		var status models.LearnStatus
+		if err := status.UnmarshalText(g.LearnStatus); err != nil {
+			return WordCounts{}, fmt.Errorf("vocabulary.MongoRepository.CountWords. %w", err)
+		}
+		counts.add(status, g.Count, g.Due)
+	}
+	return counts, nil
+}
+
+func listQuery(userID models.UserID, filter ListFilter) (bson.D, error) {
+	userId, err := primitive.ObjectIDFromHex(userID.String())
+	if err != nil {
+		return nil, fmt.Errorf("unable to build ObjectId from user's ID %s. %w", userID, err)
+	}
+
+	query := bson.D{{Key: fieldUserID, Value: userId}}
+	if filter.Language != "" {
+		lang, err := filter.Language.MarshalText()
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal language. %w", err)
+		}
+		query = append(query, bson.E{Key: fieldLanguage, Value: lang})
+	}
+	if filter.LearnStatus != nil {
+		status, err := filter.LearnStatus.MarshalText()
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal learn status. %w", err)
+		}
+		query = append(query, bson.E{Key: fieldLearnStatus, Value: status})
+	}
+	if filter.LexicalCategory != "" {
+		query = append(query, bson.E{Key: fieldLexicalCategory, Value: filter.LexicalCategory})
+	}
+	if !filter.DueBefore.IsZero() {
+		// $not matches documents created before scheduling was introduced as well
+		query = append(query, bson.E{Key: fieldScheduleDue, Value: bson.D{{Key: "$not", Value: bson.D{{Key: "$gt", Value: filter.DueBefore}}}}})
+	}
+	if !filter.IncludeArchived {
+		query = append(query, bson.E{Key: fieldArchived, Value: bson.D{{Key: "$ne", Value: true}}})
+	}
+	if filter.Spelling != "" {
+		query = append(query, bson.E{Key: fieldSpelling, Value: filter.Spelling})
+	} else if filter.SpellingPrefix != "" {
+		query = append(query, bson.E{Key: fieldSpelling, Value: primitive.Regex{Pattern: "^" + regexp.QuoteMeta(filter.SpellingPrefix)}})
+	}
+
+	if filter.Cursor == "" {
+		return query, nil
+	}
+
+	c, err := decodeCursor(filter.Cursor, filter.Sort)
+	if err != nil {
+		return nil, err
+	}
+	// decodeCursor validates ID, error is impossible here
+	afterID, _ := primitive.ObjectIDFromHex(c.ID)
+
+	op := "$gt"
+	if filter.Sort.descending() {
+		op = "$lt"
+	}
+
+	var sortField string
+	var sortValue any
+	switch {
+	case filter.Sort.bySpelling():
+		sortField, sortValue = fieldSpelling, c.Spelling
+	case filter.Sort == SortByDueAsc:
+		sortField, sortValue = fieldScheduleDue, c.due()
+	default:
+		return append(query, bson.E{Key: fieldID, Value: bson.D{{Key: op, Value: afterID}}}), nil
+	}
+
+	return append(query, bson.E{Key: "$or", Value: bson.A{
+		bson.D{{Key: sortField, Value: bson.D{{Key: op, Value: sortValue}}}},
+		bson.D{{Key: sortField, Value: sortValue}, {Key: fieldID, Value: bson.D{{Key: op, Value: afterID}}}},
+	}}), nil
+}
+
+func listSort(order SortOrder) bson.D {
+	direction := 1
+	if order.descending() {
+		direction = -1
+	}
+	switch {
+	case order.bySpelling():
+		return bson.D{{Key: fieldSpelling, Value: direction}, {Key: fieldID, Value: direction}}
+	case order == SortByDueAsc:
+		return bson.D{{Key: fieldScheduleDue, Value: direction}, {Key: fieldID, Value: direction}}
+	default:
+		return bson.D{{Key: fieldID, Value: direction}}
+	}
+}
+
+func (r MongoRepository) UpdateWord(ctx context.Context, userID models.UserID, wordID models.WordID, patch WordPatch) (models.Word, error) {
+	set := bson.D{}
+	if patch.Spelling != nil {
+		set = append(set, bson.E{Key: fieldSpelling, Value: *patch.Spelling})
+	}
+	if patch.Definition != nil {
+		set = append(set, bson.E{Key: fieldDefinition, Value: *patch.Definition})
+	}
+	if patch.LexicalCategory != nil {
+		set = append(set, bson.E{Key: fieldLexicalCategory, Value: *patch.LexicalCategory})
+	}
+	if patch.Language != nil {
+		lang, err := marshalLanguage(*patch.Language)
+		if err != nil {
+			return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.UpdateWord unable to marshal language %v. %w", patch.Language, err)
+		}
+		set = append(set, bson.E{Key: fieldLanguage, Value: lang})
+	}
+	if patch.Exercises != nil {
+		set = append(set, bson.E{Key: fieldExercises, Value: patch.Exercises})
+	}
+	if patch.Tags != nil {
+		set = append(set, bson.E{Key: fieldTags, Value: patch.Tags})
+	}
+	if patch.Source != nil {
+		set = append(set, bson.E{Key: fieldSource, Value: *patch.Source})
+	}
+
+	if len(set) == 0 {
+		return r.GetWord(ctx, userID, wordID)
+	}
+
+	w, err := r.updateOne(ctx, userID, wordID, bson.D{{Key: "$set", Value: set}})
+	if err != nil {
+		return w, fmt.Errorf("vocabulary.MongoRepository.UpdateWord unable to update word. %w", err)
+	}
+	return w, nil
+}
+
+func (r MongoRepository) ArchiveWord(ctx context.Context, userID models.UserID, wordID models.WordID) (models.Word, error) {
+	w, err := r.updateOne(ctx, userID, wordID, bson.D{{Key: "$set", Value: bson.D{{Key: fieldArchived, Value: true}}}})
+	if err != nil {
+		return w, fmt.Errorf("vocabulary.MongoRepository.ArchiveWord unable to archive word. %w", err)
+	}
+	return w, nil
+}
+
+func (r MongoRepository) UpdateSchedule(ctx context.Context, userID models.UserID, wordID models.WordID, lastReview time.Time, status models.LearnStatus, schedule models.Schedule) (models.Word, error) {
+	statusMarshalled, err := status.MarshalText()
+	if err != nil {
+		return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.UpdateSchedule unable to marshal status %v. %w", status, err)
+	}
+	filter, err := wordFilter(userID, wordID)
+	if err != nil {
+		return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.UpdateSchedule. %w", err)
+	}
+	// words added before scheduling have no schedule at all
+	var reviewed any = lastReview
+	if lastReview.IsZero() {
+		reviewed = bson.D{{Key: "$in", Value: bson.A{lastReview, nil}}}
+	}
+	filter = append(filter, bson.E{Key: fieldSchedule + ".lastreview", Value: reviewed})
+
+	var e entity
+	err = r.col.FindOneAndUpdate(ctx, filter, bson.D{{Key: "$set", Value: bson.D{
+		{Key: fieldLearnStatus, Value: statusMarshalled},
+		{Key: fieldSchedule, Value: schedule},
+	}}}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&e)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		if _, err := r.GetWord(ctx, userID, wordID); err != nil {
+			return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.UpdateSchedule. %w", err)
+		}
+		return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.UpdateSchedule word %s. %w", wordID, ErrScheduleChanged)
+	}
+	if err != nil {
+		return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.UpdateSchedule unable to update word %s. %w", wordID, err)
+	}
+
+	w, err := entityToModel(e)
+	if err != nil {
+		return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.UpdateSchedule unable to map entity to model. %w", err)
+	}
+	return w, nil
+}
+
+func (r MongoRepository) RecordAnswer(ctx context.Context, userID models.UserID, wordID models.WordID, exerciseIndex int, correct bool, status models.LearnStatus, schedule models.Schedule) (models.Word, error) {
+	statusMarshalled, err := status.MarshalText()
+	if err != nil {
+		return models.Word{}, fmt.Errorf("vocabulary.MongoRepository.RecordAnswer unable to marshal status %v. %w", status, err)
+	}
+
+	set := bson.D{
+		{Key: fieldLearnStatus, Value: statusMarshalled},
+		{Key: fieldSchedule, Value: schedule},
+	}
+	update := bson.D{}
+	if correct {
+		if exerciseIndex >= 0 {
+			set = append(set, bson.E{Key: fmt.Sprintf("%s.%d.answered", fieldExercises, exerciseIndex), Value: true})
+		}
+		update = append(update, bson.E{Key: "$inc", Value: bson.D{{Key: fieldAnsweredCount, Value: 1}}})
+	}
+	update = append(update, bson.E{Key: "$set", Value: set})
+
+	w, err := r.updateOne(ctx, userID, wordID, update)
+	if err != nil {
+		return w, fmt.Errorf("vocabulary.MongoRepository.RecordAnswer unable to update word. %w", err)
+	}
+	return w, nil
+}
+
+func (r MongoRepository) DeleteWord(ctx context.Context, userID models.UserID, wordID models.WordID) error {
+	filter, err := wordFilter(userID, wordID)
+	if err != nil {
+		return fmt.Errorf("vocabulary.MongoRepository.DeleteWord unable to build filter. %w", err)
+	}
+
+	res, err := r.col.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("vocabulary.MongoRepository.DeleteWord unable to delete word %s. %w", wordID, err)
+	}
+	if res.DeletedCount == 0 {
+		return fmt.Errorf("vocabulary.MongoRepository.DeleteWord word %s. %w", wordID, ErrWordNotFound)
+	}
+	return nil
+}
+
+func (r MongoRepository) updateOne(ctx context.Context, userID models.UserID, wordID models.WordID, update bson.D) (models.Word, error) {
+	filter, err := wordFilter(userID, wordID)
+	if err != nil {
+		return models.Word{}, err
+	}
+
+	var e entity
+	err = r.col.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&e)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return models.Word{}, fmt.Errorf("word %s. %w", wordID, ErrWordNotFound)
+	}
+	if mongo.IsDuplicateKeyError(err) {
+		return models.Word{}, fmt.Errorf("word %s. %w", wordID, ErrDuplicateWord)
+	}
+	if err != nil {
+		return models.Word{}, fmt.Errorf("unable to update word %s. %w", wordID, err)
+	}
+
+	m, err := entityToModel(e)
+	if err != nil {
+		return models.Word{}, fmt.Errorf("unable to map entity to model. %w", err)
+	}
+	return m, nil
+}
+
+func wordFilter(userID models.UserID, wordID models.WordID) (bson.D, error) {
+	userId, err := primitive.ObjectIDFromHex(userID.String())
+	if err != nil {
+		return nil, fmt.Errorf("unable to build ObjectId from user's ID %s. %w", userID, err)
+	}
+	id, err := primitive.ObjectIDFromHex(wordID.String())
+	if err != nil {
+		return nil, fmt.Errorf("unable to build ObjectId from word's ID %s. %w", wordID, err)
+	}
+	return bson.D{{Key: fieldID, Value: id}, {Key: fieldUserID, Value: userId}}, nil
+}
@@ -0,0 +1,416 @@
+package vocabulary
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pavelpuchok/vocabforge/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PostgresRepository keeps words in the words table of a database opened with postgresdb.Open,
+// exercises are kept in a JSONB column. Words get ObjectID like IDs, so they are ordered by
+// creation as in MongoRepository.
+type PostgresRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresRepository(pool *pgxpool.Pool) PostgresRepository {
+	return PostgresRepository{
+		pool,
+	}
+}
+
+const postgresWordColumns = `id, user_id, spelling, definition, language, definition_language, learn_status,
+	lexical_category, answered_count, exercises, archived, tags, source,
+	ease, stability, difficulty, interval, due, last_review, repetitions, lapses`
+
+// postgresQuerier is either *pgxpool.Pool or pgx.Tx.
+type postgresQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+func scanPostgresWord(row pgx.Row) (models.Word, error) {
+	var w models.Word
+	var status string
+	var exercises []byte
+	var interval int64
+	err := row.Scan(&w.ID, &w.UserID, &w.Spelling, &w.Definition, &w.Language, &w.DefinitionLanguage, &status,
+		&w.LexicalCategory, &w.AnsweredCount, &exercises, &w.Archived, &w.Tags, &w.Source,
+		&w.Schedule.Ease, &w.Schedule.Stability, &w.Schedule.Difficulty, &interval, &w.Schedule.Due, &w.Schedule.LastReview, &w.Schedule.Repetitions, &w.Schedule.Lapses)
+	if err != nil {
+		return models.Word{}, err
+	}
+
+	if err := w.LearnStatus.UnmarshalText(status); err != nil {
+		return models.Word{}, fmt.Errorf("unable to unmarshal status %s. %w", status, err)
+	}
+	if err := json.Unmarshal(exercises, &w.Exercises); err != nil {
+		return models.Word{}, fmt.Errorf("unable to unmarshal exercises. %w", err)
+	}
+	w.Schedule.Interval = time.Duration(interval)
+	w.Schedule.Due = w.Schedule.Due.UTC()
+	w.Schedule.LastReview = w.Schedule.LastReview.UTC()
+	return w, nil
+}
+
+// postgresWordValues returns the values of postgresWordColumns, with spelling_key last.
+func postgresWordValues(w models.Word) ([]any, error) {
+	status, err := w.LearnStatus.MarshalText()
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal status. %w", err)
+	}
+	exercises, err := json.Marshal(w.Exercises)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal exercises. %w", err)
+	}
+	// a nil slice is sent as NULL, the column isn't nullable
+	tags := w.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return []any{
+		w.ID, w.UserID, w.Spelling, w.Definition, w.Language, w.DefinitionLanguage, status,
+		w.LexicalCategory, w.AnsweredCount, exercises, w.Archived, tags, w.Source,
+		w.Schedule.Ease, w.Schedule.Stability, w.Schedule.Difficulty, int64(w.Schedule.Interval),
+		w.Schedule.Due, w.Schedule.LastReview, w.Schedule.Repetitions, w.Schedule.Lapses,
+		strings.ToLower(w.Spelling),
+	}, nil
+}
+
+// postgresPlaceholders returns "$from, ..., $from+n-1".
+func postgresPlaceholders(from, n int) string {
+	placeholders := make([]string, n)
+	for i := range placeholders {
+		placeholders[i] = "$" + strconv.Itoa(from+i)
+	}
+	return strings.Join(placeholders, ", ")
+}
+
+func (r PostgresRepository) insert(ctx context.Context, w models.Word) (models.Word, error) {
+	w.ID = models.WordID(primitive.NewObjectID().Hex())
+	values, err := postgresWordValues(w)
+	if err != nil {
+		return models.Word{}, err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO words (`+postgresWordColumns+`, spelling_key)
+		VALUES (`+postgresPlaceholders(1, len(values))+`)`, values...)
+	if isPostgresUniqueViolation(err) {
+		return models.Word{}, fmt.Errorf("word %s. %w", w.Spelling, ErrDuplicateWord)
+	}
+	if err != nil {
+		return models.Word{}, fmt.Errorf("unable to insert word. %w", err)
+	}
+	return r.GetWord(ctx, w.UserID, w.ID)
+}
+
+func (r PostgresRepository) AddWord(ctx context.Context, userID models.UserID, spell, definition, lexicalCategory string, lang, definitionLang models.Language, exercises []models.SentenceExercise) (models.Word, error) {
+	w, err := r.insert(ctx, models.Word{
+		UserID:             userID,
+		Spelling:           spell,
+		Definition:         definition,
+		Language:           lang,
+		DefinitionLanguage: definitionLang,
+		LearnStatus:        models.Pending,
+		LexicalCategory:    lexicalCategory,
+		Exercises:          exercises,
+	})
+	if err != nil {
+		return models.Word{}, fmt.Errorf("vocabulary.PostgresRepository.AddWord. %w", err)
+	}
+	return w, nil
+}
+
+// RestoreWord inserts the word with its learning state under a new ID.
+func (r PostgresRepository) RestoreWord(ctx context.Context, userID models.UserID, w models.Word) (models.Word, error) {
+	w.UserID = userID
+	restored, err := r.insert(ctx, w)
+	if err != nil {
+		return models.Word{}, fmt.Errorf("vocabulary.PostgresRepository.RestoreWord. %w", err)
+	}
+	return restored, nil
+}
+
+func (r PostgresRepository) GetWord(ctx context.Context, userID models.UserID, wordID models.WordID) (models.Word, error) {
+	w, err := getPostgresWord(ctx, r.pool, userID, wordID, false)
+	if err != nil {
+		return models.Word{}, fmt.Errorf("vocabulary.PostgresRepository.GetWord. %w", err)
+	}
+	return w, nil
+}
+
+// getPostgresWord fetches the word, forUpdate locks its row until the transaction ends.
+func getPostgresWord(ctx context.Context, q postgresQuerier, userID models.UserID, wordID models.WordID, forUpdate bool) (models.Word, error) {
+	query := `SELECT ` + postgresWordColumns + ` FROM words WHERE id = $1 AND user_id = $2`
+	if forUpdate {
+		query += ` FOR UPDATE`
+	}
+	w, err := scanPostgresWord(q.QueryRow(ctx, query, wordID, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Word{}, fmt.Errorf("word %s. %w", wordID, ErrWordNotFound)
+	}
+	if err != nil {
+		return models.Word{}, fmt.Errorf("unable to fetch word %s. %w", wordID, err)
+	}
+	return w, nil
+}
+
+func (r PostgresRepository) ListWords(ctx context.Context, userID models.UserID, filter ListFilter) (WordsPage, error) {
+	where, args, err := postgresListWhere(userID, filter)
+	if err != nil {
+		return WordsPage{}, fmt.Errorf("vocabulary.PostgresRepository.ListWords unable to build query. %w", err)
+	}
+
+	limit := normalizeLimit(filter.Limit)
+	args = append(args, limit+1)
+	rows, err := r.pool.Query(ctx, `SELECT `+postgresWordColumns+` FROM words WHERE `+where+
+		` ORDER BY `+sqlListOrder(filter.Sort)+` LIMIT $`+strconv.Itoa(len(args)), args...)
+	if err != nil {
+		return WordsPage{}, fmt.Errorf("vocabulary.PostgresRepository.ListWords unable to find words. %w", err)
+	}
+	defer rows.Close()
+
+	var words []models.Word
+	for rows.Next() {
+		w, err := scanPostgresWord(rows)
+		if err != nil {
+			return WordsPage{}, fmt.Errorf("vocabulary.PostgresRepository.ListWords unable to scan word. %w", err)
+		}
+		words = append(words, w)
+	}
+	if err := rows.Err(); err != nil {
+		return WordsPage{}, fmt.Errorf("vocabulary.PostgresRepository.ListWords unable to read words. %w", err)
+	}
+
+	return pageFromWords(words, limit, filter.Sort), nil
+}
+
+func (r PostgresRepository) CountWords(ctx context.Context, userID models.UserID, filter ListFilter, dueAt time.Time) (WordCounts, error) {
+	filter.Cursor = ""
+	where, args, err := postgresListWhere(userID, filter)
+	if err != nil {
+		return WordCounts{}, fmt.Errorf("vocabulary.PostgresRepository.CountWords unable to build query. %w", err)
+	}
+
+	args = append(args, dueAt)
+	rows, err := r.pool.Query(ctx, `SELECT learn_status, COUNT(*), COUNT(*) FILTER (WHERE due <= $`+strconv.Itoa(len(args))+`)
+		FROM words WHERE `+where+` GROUP BY learn_status`, args...)
+	if err != nil {
+		return WordCounts{}, fmt.Errorf("vocabulary.PostgresRepository.CountWords unable to count words. %w", err)
+	}
+	defer rows.Close()
+
+	var counts WordCounts
+	for rows.Next() {
+		var status models.LearnStatus
+		var statusText string
+		var n, due int
+		if err := rows.Scan(&statusText, &n, &due); err != nil {
+			return WordCounts{}, fmt.Errorf("vocabulary.PostgresRepository.CountWords unable to scan counts. %w", err)
+		}
+		if err := status.UnmarshalText(statusText); err != nil {
+			return WordCounts{}, fmt.Errorf("vocabulary.PostgresRepository.CountWords. %w", err)
+		}
+		counts.add(status, n, due)
+	}
+	if err := rows.Err(); err != nil {
+		return WordCounts{}, fmt.Errorf("vocabulary.PostgresRepository.CountWords unable to read counts. %w", err)
+	}
+	return counts, nil
+}
+
+func postgresListWhere(userID models.UserID, filter ListFilter) (string, []any, error) {
+	var conds []string
+	var args []any
+	// arg adds v to the args and returns its placeholder
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	conds = append(conds, "user_id = "+arg(userID))
+	if filter.Language != "" {
+		conds = append(conds, "language = "+arg(filter.Language))
+	}
+	if filter.LearnStatus != nil {
+		status, err := filter.LearnStatus.MarshalText()
+		if err != nil {
+			return "", nil, fmt.Errorf("unable to marshal learn status. %w", err)
+		}
+		conds = append(conds, "learn_status = "+arg(status))
+	}
+	if filter.LexicalCategory != "" {
+		conds = append(conds, "lexical_category = "+arg(filter.LexicalCategory))
+	}
+	if !filter.DueBefore.IsZero() {
+		conds = append(conds, "due <= "+arg(filter.DueBefore))
+	}
+	if !filter.IncludeArchived {
+		conds = append(conds, "NOT archived")
+	}
+	if filter.Spelling != "" {
+		conds = append(conds, "spelling_key = "+arg(strings.ToLower(filter.Spelling)))
+	} else if filter.SpellingPrefix != "" {
+		conds = append(conds, "starts_with(spelling, "+arg(filter.SpellingPrefix)+")")
+	}
+
+	if filter.Cursor != "" {
+		c, err := decodeCursor(filter.Cursor, filter.Sort)
+		if err != nil {
+			return "", nil, err
+		}
+
+		op := ">"
+		if filter.Sort.descending() {
+			op = "<"
+		}
+		switch {
+		case filter.Sort.bySpelling():
+			spelling, id := arg(c.Spelling), arg(c.ID)
+			conds = append(conds, fmt.Sprintf("(spelling %s %s OR (spelling = %s AND id %s %s))", op, spelling, spelling, op, id))
+		case filter.Sort == SortByDueAsc:
+			due, id := arg(c.due()), arg(c.ID)
+			conds = append(conds, fmt.Sprintf("(due %s %s OR (due = %s AND id %s %s))", op, due, due, op, id))
+		default:
+			conds = append(conds, "id "+op+" "+arg(c.ID))
+		}
+	}
+	return strings.Join(conds, " AND "), args, nil
+}
+
+func (r PostgresRepository) UpdateWord(ctx context.Context, userID models.UserID, wordID models.WordID, patch WordPatch) (models.Word, error) {
+	w, err := r.update(ctx, userID, wordID, func(w *models.Word) {
+		if patch.Spelling != nil {
+			w.Spelling = *patch.Spelling
+		}
+		if patch.Definition != nil {
+			w.Definition = *patch.Definition
+		}
+		if patch.LexicalCategory != nil {
+			w.LexicalCategory = *patch.LexicalCategory
+		}
+		if patch.Language != nil {
+			w.Language = *patch.Language
+		}
+		if patch.Exercises != nil {
+			w.Exercises = patch.Exercises
+		}
+		if patch.Tags != nil {
+			w.Tags = patch.Tags
+		}
+		if patch.Source != nil {
+			w.Source = *patch.Source
+		}
+	})
+	if err != nil {
+		return w, fmt.Errorf("vocabulary.PostgresRepository.UpdateWord. %w", err)
+	}
+	return w, nil
+}
+
+func (r PostgresRepository) ArchiveWord(ctx context.Context, userID models.UserID, wordID models.WordID) (models.Word, error) {
+	w, err := r.update(ctx, userID, wordID, func(w *models.Word) {
+		w.Archived = true
+	})
+	if err != nil {
+		return w, fmt.Errorf("vocabulary.PostgresRepository.ArchiveWord. %w", err)
+	}
+	return w, nil
+}
+
+func (r PostgresRepository) UpdateSchedule(ctx context.Context, userID models.UserID, wordID models.WordID, lastReview time.Time, status models.LearnStatus, schedule models.Schedule) (models.Word, error) {
+	changed := false
+	w, err := r.update(ctx, userID, wordID, func(w *models.Word) {
+		if !w.Schedule.LastReview.Equal(lastReview) {
+			changed = true
+			return
+		}
+		w.LearnStatus = status
+		w.Schedule = schedule
+	})
+	if err != nil {
+		return w, fmt.Errorf("vocabulary.PostgresRepository.UpdateSchedule. %w", err)
+	}
+	if changed {
+		return models.Word{}, fmt.Errorf("vocabulary.PostgresRepository.UpdateSchedule word %s. %w", wordID, ErrScheduleChanged)
+	}
+	return w, nil
+}
+
+func (r PostgresRepository) RecordAnswer(ctx context.Context, userID models.UserID, wordID models.WordID, exerciseIndex int, correct bool, status models.LearnStatus, schedule models.Schedule) (models.Word, error) {
+	w, err := r.update(ctx, userID, wordID, func(w *models.Word) {
+		w.LearnStatus = status
+		w.Schedule = schedule
+		if !correct {
+			return
+		}
+		if exerciseIndex >= 0 && exerciseIndex < len(w.Exercises) {
+			w.Exercises[exerciseIndex].Answered = true
+		}
+		w.AnsweredCount++
+	})
+	if err != nil {
+		return w, fmt.Errorf("vocabulary.PostgresRepository.RecordAnswer. %w", err)
+	}
+	return w, nil
+}
+
+func (r PostgresRepository) DeleteWord(ctx context.Context, userID models.UserID, wordID models.WordID) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM words WHERE id = $1 AND user_id = $2`, wordID, userID)
+	if err != nil {
+		return fmt.Errorf("vocabulary.PostgresRepository.DeleteWord unable to delete word %s. %w", wordID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("vocabulary.PostgresRepository.DeleteWord word %s. %w", wordID, ErrWordNotFound)
+	}
+	return nil
+}
+
+// update reads the word, applies fn and writes the word back within a transaction. The row
+// stays locked in between, so concurrent updates of the word don't overwrite each other.
+func (r PostgresRepository) update(ctx context.Context, userID models.UserID, wordID models.WordID, fn func(w *models.Word)) (models.Word, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return models.Word{}, fmt.Errorf("unable to begin transaction. %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	w, err := getPostgresWord(ctx, tx, userID, wordID, true)
+	if err != nil {
+		return models.Word{}, err
+	}
+	fn(&w)
+
+	values, err := postgresWordValues(w)
+	if err != nil {
+		return models.Word{}, err
+	}
+	_, err = tx.Exec(ctx, `UPDATE words SET (`+postgresWordColumns+`, spelling_key)
+		= (`+postgresPlaceholders(1, len(values))+`) WHERE id = $`+strconv.Itoa(len(values)+1), append(values, wordID)...)
+	if isPostgresUniqueViolation(err) {
+		return models.Word{}, fmt.Errorf("word %s. %w", wordID, ErrDuplicateWord)
+	}
+	if err != nil {
+		return models.Word{}, fmt.Errorf("unable to update word %s. %w", wordID, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return models.Word{}, fmt.Errorf("unable to commit word %s. %w", wordID, err)
+	}
+	return w, nil
+}
+
+// isPostgresUniqueViolation reports whether err is caused by the unique index on the spelling.
+func isPostgresUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	// 23505 is unique_violation
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
@@ -0,0 +1,28 @@
+package vocabulary_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pavelpuchok/vocabforge/postgresdb"
+	"github.com/pavelpuchok/vocabforge/postgresdb/postgresdbtest"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+	"github.com/pavelpuchok/vocabforge/vocabulary/vocabularytest"
+)
+
+// TestPostgresRepository runs against the PostgreSQL at VOCABFORGE_TEST_POSTGRES_URL, every
+// test in a schema of its own. It's skipped when the variable isn't set.
+func TestPostgresRepository(t *testing.T) {
+	t.Parallel()
+
+	vocabularytest.TestRepository(t, func(t *testing.T) vocabulary.Repository {
+		t.Helper()
+
+		pool, err := postgresdb.Open(context.Background(), postgresdbtest.URL(t), postgresdb.PoolConfig{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(pool.Close)
+		return vocabulary.NewPostgresRepository(pool)
+	})
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/sashabaranov/go-openai"
+	"github.com/pavelpuchok/vocabforge/models"
 	"github.com/sashabaranov/go-openai/jsonschema"
 )
 
+const schemaName = "word_learning"
+
 type AIGenerator struct {
-	client         *openai.Client
+	provider       Provider
 	promptProvider PromptProvider
 	schema         *jsonschema.Definition
 }
@@ -22,55 +24,49 @@ type Sentence struct {
 	Text string `json:"text"`
 }
 
+// Request describes sentences to generate for a word.
+type Request struct {
+	Spelling        string
+	Definition      string
+	LexicalCategory string
+	// Language is the language of the word and sentences.
+	Language models.Language
+	// DefinitionLanguage is the language of the definition, the user's native one.
+	DefinitionLanguage models.Language
+	Count              int
+}
+
 type PromptProvider interface {
-	Prompt(spelling, definition, lexicalCategory string, sentencesCount int) (string, error)
+	Prompt(req Request) (string, error)
 }
 
-func NewAIGenerator(apiToken string, promptProvider PromptProvider) (AIGenerator, error) {
+func NewAIGenerator(provider Provider, promptProvider PromptProvider) (AIGenerator, error) {
 	// generate response schema
 	schema, err := jsonschema.GenerateSchemaForType(aiResponse{})
 	if err != nil {
 		return AIGenerator{}, fmt.Errorf("sentences.NewAIGenerator unable to generate response schema. %w", err)
 	}
 
-	client := openai.NewClient(apiToken)
-
 	return AIGenerator{
-		client:         client,
+		provider:       provider,
 		schema:         schema,
 		promptProvider: promptProvider,
 	}, nil
 }
 
-func (g AIGenerator) Generate(ctx context.Context, spelling, definition, lexicalCategory string, sentencesCount int) ([]Sentence, error) {
-	prompt, err := g.promptProvider.Prompt(spelling, definition, lexicalCategory, sentencesCount)
+func (g AIGenerator) Generate(ctx context.Context, req Request) ([]Sentence, error) {
+	prompt, err := g.promptProvider.Prompt(req)
 	if err != nil {
 		return nil, fmt.Errorf("sentences.AIGenerator.Generate unable to generate prompt. %w", err)
 	}
 
-	response, err := g.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
-		Model: openai.GPT4oMini,
-		Messages: []openai.ChatCompletionMessage{
-			{
-				Role:    openai.ChatMessageRoleUser,
-				Content: prompt,
-			},
-		},
-		ResponseFormat: &openai.ChatCompletionResponseFormat{
-			Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
-			JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
-				Name:   "word_learning",
-				Schema: g.schema,
-				Strict: true,
-			},
-		},
-	})
+	content, err := g.provider.Complete(ctx, prompt, schemaName, g.schema)
 	if err != nil {
-		return nil, fmt.Errorf("sentences.AIGenerator.Generate unable to make ChatGPT request. %w", err)
+		return nil, fmt.Errorf("sentences.AIGenerator.Generate unable to complete prompt. %w", err)
 	}
 
 	var result aiResponse
-	err = g.schema.Unmarshal(response.Choices[0].Message.Content, &result)
+	err = g.schema.Unmarshal(content, &result)
 	if err != nil {
 		return nil, fmt.Errorf("sentences.AIGenerator.Generate unable to unmarshal response. %w", err)
 	}
@@ -1,16 +1,21 @@
 package sentences
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"strings"
 	"text/template"
 )
 
-const promptTemplateText = `Generate {{.SentencesCount}} exercises for learning the word '{{.Spelling}}'.
-Word: '{{.Spelling}}'. Definition: '{{.Definition}}'. Lexical Category: {{.LexicalCategory}}.
+const promptTemplateText = `Generate {{.SentencesCount}} exercises for learning the {{with .Language}}{{.}} {{end}}word '{{.Spelling}}'.
+Word: '{{.Spelling}}'. Definition{{with .DefinitionLanguage}} (in {{.}}){{end}}: '{{.Definition}}'. Lexical Category: {{.LexicalCategory}}.
 
 Instructions:
 - Each sentence should use the word '{{.Spelling}}'.
+{{- with .Language}}
+- Write every sentence in {{.}}.
+{{- end}}
 - Format each sentence with the word '{{.Spelling}}' prefixed with <% and postfixed with %>.
 - Ensure the sentences are varied and cover different tenses if applicable.`
 
@@ -19,6 +24,9 @@ type promptTemplateCtx struct {
 	Spelling        string
 	Definition      string
 	LexicalCategory string
+	// Language and DefinitionLanguage are English names of the languages, empty if unknown
+	Language           string
+	DefinitionLanguage string
 }
 
 type AIPromptProvider struct {
@@ -36,16 +44,30 @@ func NewAIPromptProvider() (AIPromptProvider, error) {
 	}, nil
 }
 
-func (p AIPromptProvider) Prompt(spelling, definition, lexicalCategory string, sentencesCount int) (string, error) {
+func (p AIPromptProvider) Prompt(req Request) (string, error) {
+	tplCtx := promptTemplateCtx{
+		SentencesCount:  req.Count,
+		Spelling:        req.Spelling,
+		Definition:      req.Definition,
+		LexicalCategory: req.LexicalCategory,
+	}
+	if req.Language != "" {
+		tplCtx.Language = req.Language.Name()
+	}
+	if req.DefinitionLanguage != "" {
+		tplCtx.DefinitionLanguage = req.DefinitionLanguage.Name()
+	}
+
 	sb := strings.Builder{}
-	err := p.tpl.Execute(&sb, promptTemplateCtx{
-		SentencesCount:  sentencesCount,
-		Spelling:        spelling,
-		Definition:      definition,
-		LexicalCategory: lexicalCategory,
-	})
+	err := p.tpl.Execute(&sb, tplCtx)
 	if err != nil {
 		return "", fmt.Errorf("vocabulary.AIPromptProvider.Prompt unable to render template. %w", err)
 	}
 	return sb.String(), nil
 }
+
+// Version identifies the prompt template, so sentences generated by another template can be told apart.
+func (p AIPromptProvider) Version() string {
+	sum := sha256.Sum256([]byte(promptTemplateText))
+	return hex.EncodeToString(sum[:8])
+}
@@ -14,7 +14,7 @@ func TestAIPromptProvider_Prompt(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	actual, err := p.Prompt("foo", "bar", "adverb", 123)
+	actual, err := p.Prompt(Request{Spelling: "foo", Definition: "bar", LexicalCategory: "adverb", Count: 123})
 	if err != nil {
 		t.Error(err)
 	}
@@ -25,6 +25,24 @@ Word: 'foo'. Definition: 'bar'. Lexical Category: adverb.
 Instructions:
 - Each sentence should use the word 'foo'.
 - Format each sentence with the word 'foo' prefixed with <% and postfixed with %>.
+- Ensure the sentences are varied and cover different tenses if applicable.`
+
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("unexpected prompt (-want +got):\n%s", diff)
+	}
+
+	actual, err = p.Prompt(Request{Spelling: "Haus", Definition: "house", LexicalCategory: "noun", Language: "de-AT", DefinitionLanguage: "en", Count: 2})
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected = `Generate 2 exercises for learning the Austrian German word 'Haus'.
+Word: 'Haus'. Definition (in English): 'house'. Lexical Category: noun.
+
+Instructions:
+- Each sentence should use the word 'Haus'.
+- Write every sentence in Austrian German.
+- Format each sentence with the word 'Haus' prefixed with <% and postfixed with %>.
 - Ensure the sentences are varied and cover different tenses if applicable.`
 
 	if diff := cmp.Diff(expected, actual); diff != "" {
@@ -0,0 +1,166 @@
+package sentences
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+const (
+	anthropicDefaultBaseURL   = "https://api.anthropic.com"
+	anthropicDefaultModel     = "claude-3-5-haiku-latest"
+	anthropicDefaultMaxTokens = 4096
+	anthropicVersion          = "2023-06-01"
+
+	anthropicStopReasonRefusal = "refusal"
+)
+
+// AnthropicProvider talks to Anthropic Messages API. Structured output is achieved
+// by forcing the model to call a tool whose input schema is the response schema.
+type AnthropicProvider struct {
+	client      *http.Client
+	baseURL     string
+	apiToken    string
+	model       string
+	temperature *float32
+	maxTokens   int
+}
+
+func NewAnthropicProvider(cfg ProviderConfig) AnthropicProvider {
+	p := AnthropicProvider{
+		client:      &http.Client{},
+		baseURL:     strings.TrimSuffix(cfg.BaseURL, "/"),
+		apiToken:    cfg.APIToken,
+		model:       cfg.Model,
+		temperature: cfg.Temperature,
+		maxTokens:   cfg.MaxTokens,
+	}
+	if p.baseURL == "" {
+		p.baseURL = anthropicDefaultBaseURL
+	}
+	if p.model == "" {
+		p.model = anthropicDefaultModel
+	}
+	if p.maxTokens == 0 {
+		p.maxTokens = anthropicDefaultMaxTokens
+	}
+	return p
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicTool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema *jsonschema.Definition `json:"input_schema"` //nolint:tagliatelle
+}
+
+type anthropicToolChoice struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type anthropicRequest struct {
+	Model       string              `json:"model"`
+	MaxTokens   int                 `json:"max_tokens"` //nolint:tagliatelle
+	Temperature *float32            `json:"temperature,omitempty"`
+	Messages    []anthropicMessage  `json:"messages"`
+	Tools       []anthropicTool     `json:"tools"`
+	ToolChoice  anthropicToolChoice `json:"tool_choice"` //nolint:tagliatelle
+}
+
+type anthropicContentBlock struct {
+	Type  string          `json:"type"`
+	Name  string          `json:"name"`
+	Input json.RawMessage `json:"input"`
+}
+
+type anthropicResponse struct {
+	Content    []anthropicContentBlock `json:"content"`
+	StopReason string                  `json:"stop_reason"` //nolint:tagliatelle
+}
+
+type anthropicErrorResponse struct {
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (p AnthropicProvider) Complete(ctx context.Context, prompt string, schemaName string, schema *jsonschema.Definition) (string, error) {
+	body, err := json.Marshal(anthropicRequest{
+		Model:       p.model,
+		MaxTokens:   p.maxTokens,
+		Temperature: p.temperature,
+		Messages:    []anthropicMessage{{Role: "user", Content: prompt}},
+		Tools: []anthropicTool{{
+			Name:        schemaName,
+			Description: "Record the generated response.",
+			InputSchema: schema,
+		}},
+		ToolChoice: anthropicToolChoice{Type: "tool", Name: schemaName},
+	})
+	if err != nil {
+		return "", fmt.Errorf("sentences.AnthropicProvider.Complete unable to marshal request. %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/v1/messages", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("sentences.AnthropicProvider.Complete unable to build request. %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Api-Key", p.apiToken)
+	req.Header.Set("Anthropic-Version", anthropicVersion)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("sentences.AnthropicProvider.Complete unable to make messages request. %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("sentences.AnthropicProvider.Complete unable to read response. %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp anthropicErrorResponse
+		reqErr := fmt.Errorf("request failed with status %d", resp.StatusCode)
+		if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.Error.Message != "" {
+			reqErr = fmt.Errorf("request failed with status %d: %s: %s", resp.StatusCode, errResp.Error.Type, errResp.Error.Message)
+		}
+		err := classifyHTTPError(resp.StatusCode, errResp.Error.Type, parseRetryAfter(resp.Header, time.Now()), reqErr)
+		return "", fmt.Errorf("sentences.AnthropicProvider.Complete unable to make messages request. %w", err)
+	}
+
+	var result anthropicResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", fmt.Errorf("sentences.AnthropicProvider.Complete unable to unmarshal response. %w", err)
+	}
+
+	for _, block := range result.Content {
+		if block.Type == "tool_use" && block.Name == schemaName {
+			return string(block.Input), nil
+		}
+	}
+
+	if result.StopReason == anthropicStopReasonRefusal {
+		return "", fmt.Errorf("sentences.AnthropicProvider.Complete. %w", &ProviderError{
+			Kind: ErrContentRefused,
+			Err:  errors.New("model stopped with refusal"),
+		})
+	}
+
+	return "", errors.New("sentences.AnthropicProvider.Complete response has no tool use, stop reason " + result.StopReason)
+}
@@ -0,0 +1,63 @@
+package sentences
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+func TestAnthropicProvider_Complete(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/messages" || r.Header.Get("X-Api-Key") != "token" || r.Header.Get("Anthropic-Version") == "" {
+			t.Errorf("unexpected request %s %v", r.URL.Path, r.Header)
+		}
+
+		var req anthropicRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		if req.Model != "claude-test" || req.MaxTokens != anthropicDefaultMaxTokens || req.ToolChoice.Name != "word_learning" || req.Messages[0].Content != "prompt" {
+			t.Errorf("unexpected request body %+v", req)
+		}
+		// zero temperature is sent rather than left to the API's default
+		if req.Temperature == nil || *req.Temperature != 0 {
+			t.Errorf("expected zero temperature, got %v", req.Temperature)
+		}
+
+		_, _ = w.Write([]byte(`{"content":[{"type":"text","text":"hi"},{"type":"tool_use","name":"word_learning","input":{"sentences":[{"text":"a <%b%>"}]}}],"stop_reason":"tool_use"}`))
+	}))
+	defer srv.Close()
+
+	p := NewAnthropicProvider(ProviderConfig{BaseURL: srv.URL, APIToken: "token", Model: "claude-test", Temperature: new(float32)})
+	actual, err := p.Complete(context.Background(), "prompt", "word_learning", &jsonschema.Definition{Type: jsonschema.Object})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"sentences":[{"text":"a <%b%>"}]}`
+	if actual != expected {
+		t.Errorf("unexpected content %s", actual)
+	}
+}
+
+func TestAnthropicProvider_CompleteError(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"type":"error","error":{"type":"invalid_request_error","message":"bad model"}}`))
+	}))
+	defer srv.Close()
+
+	p := NewAnthropicProvider(ProviderConfig{BaseURL: srv.URL})
+	_, err := p.Complete(context.Background(), "prompt", "word_learning", &jsonschema.Definition{Type: jsonschema.Object})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
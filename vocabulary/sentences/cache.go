@@ -0,0 +1,111 @@
+package sentences
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+const (
+	CacheNone  = "none"
+	CacheMongo = "mongo"
+	CacheFile  = "file"
+)
+
+var ErrCacheMiss = errors.New("cache miss")
+
+// CacheStore keeps generated sentences by key until they expire.
+type CacheStore interface {
+	// Get returns ErrCacheMiss when there is no live entry for the key.
+	Get(ctx context.Context, key string, now time.Time) ([]Sentence, error)
+	Put(ctx context.Context, key string, sentences []Sentence, expiresAt time.Time) error
+	// Purge removes expired entries or, when expiredOnly is false, all of them.
+	Purge(ctx context.Context, expiredOnly bool, now time.Time) (int, error)
+}
+
+// CachingGenerator serves sentences generated earlier for the same request and prompt template.
+// The cache is only an optimisation: when the store fails, the failure is logged and the
+// sentences are generated as if there was no cache.
+type CachingGenerator struct {
+	// Logger receives the store failures, they aren't logged when it's nil.
+	Logger *slog.Logger
+
+	next          Generator
+	store         CacheStore
+	promptVersion string
+	ttl           time.Duration
+	now           func() time.Time
+}
+
+func NewCachingGenerator(next Generator, store CacheStore, promptVersion string, ttl time.Duration) CachingGenerator {
+	return CachingGenerator{
+		next:          next,
+		store:         store,
+		promptVersion: promptVersion,
+		ttl:           ttl,
+		now:           time.Now,
+	}
+}
+
+func (g CachingGenerator) Generate(ctx context.Context, req Request) ([]Sentence, error) {
+	key := cacheKey(req, g.promptVersion)
+
+	cached, err := g.store.Get(ctx, key, g.now())
+	if err == nil {
+		return cached, nil
+	}
+	if !errors.Is(err, ErrCacheMiss) {
+		g.warn(ctx, "sentences: unable to read cache", err)
+	}
+
+	generated, err := g.next.Generate(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("sentences.CachingGenerator.Generate unable to generate sentences. %w", err)
+	}
+
+	if err := g.store.Put(ctx, key, generated, g.now().Add(g.ttl)); err != nil {
+		g.warn(ctx, "sentences: unable to write cache", err)
+	}
+	return generated, nil
+}
+
+func (g CachingGenerator) warn(ctx context.Context, msg string, err error) {
+	if g.Logger != nil {
+		g.Logger.WarnContext(ctx, msg, slog.String("err", err.Error()))
+	}
+}
+
+type cacheKeyFields struct {
+	Spelling        string `json:"spelling"`
+	Definition      string `json:"definition"`
+	LexicalCategory string `json:"lexicalCategory"`
+	Language        string `json:"language"`
+	DefinitionLang  string `json:"definitionLanguage"`
+	Count           int    `json:"count"`
+	PromptVersion   string `json:"promptVersion"`
+}
+
+func cacheKey(req Request, promptVersion string) string {
+	// marshalling of strings and ints can't fail
+	b, _ := json.Marshal(cacheKeyFields{
+		Spelling:        normalizeCacheField(req.Spelling),
+		Definition:      normalizeCacheField(req.Definition),
+		LexicalCategory: normalizeCacheField(req.LexicalCategory),
+		Language:        string(req.Language),
+		DefinitionLang:  string(req.DefinitionLanguage),
+		Count:           req.Count,
+		PromptVersion:   promptVersion,
+	})
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func normalizeCacheField(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
@@ -0,0 +1,155 @@
+package sentences
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCachingGenerator_Generate(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewFileCacheStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	next := &flakyGenerator{}
+	g := NewCachingGenerator(next, store, "v1", time.Hour)
+	g.now = func() time.Time { return now }
+
+	req := Request{Spelling: "Run", Definition: "to move  fast", Language: "en_US", Count: 1}
+	first, err := g.Generate(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// spelling and definition are compared ignoring case and extra spaces
+	req.Spelling, req.Definition = "run", "To move fast"
+	second, err := g.Generate(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.calls != 1 {
+		t.Errorf("expected cached result, got %d calls", next.calls)
+	}
+	if diff := cmp.Diff(first, second); diff != "" {
+		t.Errorf("unexpected cached sentences (-want +got):\n%s", diff)
+	}
+
+	req.Count = 2
+	if _, err := g.Generate(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+	if next.calls != 2 {
+		t.Errorf("expected count to be part of the key, got %d calls", next.calls)
+	}
+
+	g.promptVersion = "v2"
+	if _, err := g.Generate(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+	if next.calls != 3 {
+		t.Errorf("expected prompt version to be part of the key, got %d calls", next.calls)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, err := g.Generate(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+	if next.calls != 4 {
+		t.Errorf("expected expired entry to be generated again, got %d calls", next.calls)
+	}
+}
+
+type failingCacheStore struct {
+	err error
+}
+
+func (s failingCacheStore) Get(_ context.Context, _ string, _ time.Time) ([]Sentence, error) {
+	return nil, s.err
+}
+
+func (s failingCacheStore) Put(_ context.Context, _ string, _ []Sentence, _ time.Time) error {
+	return s.err
+}
+
+func (s failingCacheStore) Purge(_ context.Context, _ bool, _ time.Time) (int, error) {
+	return 0, s.err
+}
+
+func TestCachingGenerator_Generate_FailingStore(t *testing.T) {
+	t.Parallel()
+
+	next := &flakyGenerator{}
+	g := NewCachingGenerator(next, failingCacheStore{err: errors.New("disk is full")}, "v1", time.Hour)
+	var logs bytes.Buffer
+	g.Logger = slog.New(slog.NewTextHandler(&logs, nil))
+
+	for range 2 {
+		got, err := g.Generate(context.Background(), Request{Spelling: "run", Count: 1})
+		if err != nil {
+			t.Fatalf("expected store failures to be ignored, got %v", err)
+		}
+		if diff := cmp.Diff([]Sentence{{Text: "ok"}}, got); diff != "" {
+			t.Errorf("unexpected sentences (-want +got):\n%s", diff)
+		}
+	}
+	if next.calls != 2 {
+		t.Errorf("expected every request to be generated, got %d calls", next.calls)
+	}
+	if n := strings.Count(logs.String(), "disk is full"); n != 4 {
+		t.Errorf("expected every store failure to be logged, got %d in:\n%s", n, logs.String())
+	}
+
+	next.errs = []error{errors.New("boom")}
+	if _, err := g.Generate(context.Background(), Request{Spelling: "run", Count: 1}); err == nil {
+		t.Error("expected generator error to be returned")
+	}
+}
+
+func TestFileCacheStore_Purge(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewFileCacheStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	sentences := []Sentence{{Text: "I <%run%> daily."}}
+	if err := store.Put(ctx, "expired", sentences, now.Add(-time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(ctx, "live", sentences, now.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Get(ctx, "expired", now); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("expected cache miss for expired entry, got %v", err)
+	}
+
+	purged, err := store.Purge(ctx, true, now)
+	if err != nil || purged != 1 {
+		t.Fatalf("expected 1 expired entry purged, got %d, %v", purged, err)
+	}
+	if got, err := store.Get(ctx, "live", now); err != nil || len(got) != 1 {
+		t.Errorf("expected live entry to stay, got %v, %v", got, err)
+	}
+
+	purged, err = store.Purge(ctx, false, now)
+	if err != nil || purged != 1 {
+		t.Fatalf("expected 1 entry purged, got %d, %v", purged, err)
+	}
+	if _, err := store.Get(ctx, "live", now); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("expected cache miss after purge, got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package sentences
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrRateLimited         = errors.New("AI provider rate limit reached")
+	ErrQuotaExhausted      = errors.New("AI provider quota exhausted")
+	ErrContentRefused      = errors.New("AI provider refused to generate content")
+	ErrProviderUnavailable = errors.New("AI provider is unavailable")
+)
+
+// ProviderError is a failed provider call classified by Kind, one of the errors above.
+type ProviderError struct {
+	Kind       error
+	StatusCode int
+	// RetryAfter is the delay requested by the provider, zero if unknown.
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *ProviderError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("%s (status %d): %s", e.Kind, e.StatusCode, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", e.Kind, e.Err)
+}
+
+func (e *ProviderError) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// Retryable reports whether the call may succeed if repeated later.
+func (e *ProviderError) Retryable() bool {
+	return errors.Is(e.Kind, ErrRateLimited) || errors.Is(e.Kind, ErrProviderUnavailable)
+}
+
+// classifyHTTPError wraps err into ProviderError when status or provider error code
+// tells a recognised failure. Unrecognised errors are returned as is.
+func classifyHTTPError(status int, code string, retryAfter time.Duration, err error) error {
+	var kind error
+	switch {
+	case quotaErrorCodes[code]:
+		kind = ErrQuotaExhausted
+	case status == http.StatusTooManyRequests:
+		kind = ErrRateLimited
+	case status >= http.StatusInternalServerError:
+		kind = ErrProviderUnavailable
+	default:
+		return err
+	}
+	return &ProviderError{
+		Kind:       kind,
+		StatusCode: status,
+		RetryAfter: retryAfter,
+		Err:        err,
+	}
+}
+
+var quotaErrorCodes = map[string]bool{
+	"insufficient_quota": true,
+	"billing_error":      true,
+}
+
+// parseRetryAfter parses Retry-After header value, given in seconds or as an HTTP date.
+func parseRetryAfter(h http.Header, now time.Time) time.Duration {
+	if ms, err := strconv.Atoi(h.Get("Retry-After-Ms")); err == nil && ms > 0 {
+		return time.Duration(ms) * time.Millisecond
+	}
+
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0)
+	}
+	return 0
+}
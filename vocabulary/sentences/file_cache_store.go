@@ -0,0 +1,115 @@
+package sentences
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const cacheFileExt = ".json"
+
+// FileCacheStore keeps every entry in its own JSON file within a directory, for offline use.
+type FileCacheStore struct {
+	dir string
+}
+
+func NewFileCacheStore(dir string) (FileCacheStore, error) {
+	//nolint:mnd
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return FileCacheStore{}, fmt.Errorf("sentences.NewFileCacheStore unable to create directory %s. %w", dir, err)
+	}
+	return FileCacheStore{dir}, nil
+}
+
+type cacheFile struct {
+	Sentences []Sentence `json:"sentences"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+}
+
+func (s FileCacheStore) Get(_ context.Context, key string, now time.Time) ([]Sentence, error) {
+	entry, err := s.read(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrCacheMiss
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sentences.FileCacheStore.Get unable to read entry %s. %w", key, err)
+	}
+	if !entry.ExpiresAt.After(now) {
+		return nil, ErrCacheMiss
+	}
+	return entry.Sentences, nil
+}
+
+func (s FileCacheStore) Put(_ context.Context, key string, sentences []Sentence, expiresAt time.Time) error {
+	b, err := json.Marshal(cacheFile{Sentences: sentences, ExpiresAt: expiresAt})
+	if err != nil {
+		return fmt.Errorf("sentences.FileCacheStore.Put unable to marshal entry %s. %w", key, err)
+	}
+
+	// write to a temporary file first, so readers never see a partially written entry
+	tmp, err := os.CreateTemp(s.dir, key+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("sentences.FileCacheStore.Put unable to create temporary file. %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sentences.FileCacheStore.Put unable to write entry %s. %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("sentences.FileCacheStore.Put unable to close entry %s. %w", key, err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		return fmt.Errorf("sentences.FileCacheStore.Put unable to save entry %s. %w", key, err)
+	}
+	return nil
+}
+
+func (s FileCacheStore) Purge(_ context.Context, expiredOnly bool, now time.Time) (int, error) {
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		return 0, fmt.Errorf("sentences.FileCacheStore.Purge unable to read directory %s. %w", s.dir, err)
+	}
+
+	purged := 0
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), cacheFileExt) {
+			continue
+		}
+		p := filepath.Join(s.dir, f.Name())
+		if expiredOnly {
+			entry, err := s.read(p)
+			if err == nil && entry.ExpiresAt.After(now) {
+				continue
+			}
+		}
+		if err := os.Remove(p); err != nil {
+			return purged, fmt.Errorf("sentences.FileCacheStore.Purge unable to remove %s. %w", p, err)
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+func (s FileCacheStore) path(key string) string {
+	return filepath.Join(s.dir, key+cacheFileExt)
+}
+
+func (s FileCacheStore) read(path string) (cacheFile, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return cacheFile{}, err
+	}
+	var entry cacheFile
+	if err := json.Unmarshal(b, &entry); err != nil {
+		return cacheFile{}, err
+	}
+	return entry, nil
+}
@@ -0,0 +1,80 @@
+package sentences
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoCacheStore struct {
+	col *mongo.Collection
+}
+
+func NewMongoCacheStore(db *mongo.Database) MongoCacheStore {
+	col := db.Collection("sentences_cache")
+	return MongoCacheStore{
+		col,
+	}
+}
+
+type cacheEntity struct {
+	Key       string     `bson:"_id"`
+	Sentences []Sentence `bson:"sentences"`
+	CreatedAt time.Time  `bson:"createdAt"`
+	ExpiresAt time.Time  `bson:"expiresAt"`
+}
+
+// EnsureIndexes creates TTL index, so MongoDB removes expired entries on its own.
+func (s MongoCacheStore) EnsureIndexes(ctx context.Context) error {
+	_, err := s.col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		return fmt.Errorf("sentences.MongoCacheStore.EnsureIndexes unable to create TTL index. %w", err)
+	}
+	return nil
+}
+
+func (s MongoCacheStore) Get(ctx context.Context, key string, now time.Time) ([]Sentence, error) {
+	var e cacheEntity
+	// TTL monitor runs periodically, so expired entries may still be there
+	err := s.col.FindOne(ctx, bson.D{{Key: "_id", Value: key}, {Key: "expiresAt", Value: bson.D{{Key: "$gt", Value: now}}}}).Decode(&e)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrCacheMiss
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sentences.MongoCacheStore.Get unable to fetch entry %s. %w", key, err)
+	}
+	return e.Sentences, nil
+}
+
+func (s MongoCacheStore) Put(ctx context.Context, key string, sentences []Sentence, expiresAt time.Time) error {
+	_, err := s.col.ReplaceOne(ctx, bson.D{{Key: "_id", Value: key}}, cacheEntity{
+		Key:       key,
+		Sentences: sentences,
+		CreatedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	}, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("sentences.MongoCacheStore.Put unable to upsert entry %s. %w", key, err)
+	}
+	return nil
+}
+
+func (s MongoCacheStore) Purge(ctx context.Context, expiredOnly bool, now time.Time) (int, error) {
+	filter := bson.D{}
+	if expiredOnly {
+		filter = bson.D{{Key: "expiresAt", Value: bson.D{{Key: "$lte", Value: now}}}}
+	}
+	res, err := s.col.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("sentences.MongoCacheStore.Purge unable to delete entries. %w", err)
+	}
+	return int(res.DeletedCount), nil
+}
@@ -0,0 +1,155 @@
+package sentences
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+// OpenAIProvider talks to OpenAI Chat Completions API or any server compatible with it.
+type OpenAIProvider struct {
+	client      *openai.Client
+	model       string
+	temperature *float32
+	maxTokens   int
+	// strictSchema enables structured outputs. Compatible servers often support
+	// JSON mode only, so the schema is passed within the prompt instead.
+	strictSchema bool
+}
+
+func NewOpenAIProvider(cfg ProviderConfig) OpenAIProvider {
+	return newOpenAIProvider(cfg, true)
+}
+
+// NewOpenAICompatibleProvider creates a provider for OpenAI-compatible servers, like llama.cpp or Ollama.
+func NewOpenAICompatibleProvider(cfg ProviderConfig) OpenAIProvider {
+	return newOpenAIProvider(cfg, false)
+}
+
+func newOpenAIProvider(cfg ProviderConfig, strictSchema bool) OpenAIProvider {
+	clientCfg := openai.DefaultConfig(cfg.APIToken)
+	if cfg.BaseURL != "" {
+		clientCfg.BaseURL = cfg.BaseURL
+	}
+	clientCfg.HTTPClient = &http.Client{Transport: retryAfterTransport{http.DefaultTransport}}
+
+	model := cfg.Model
+	if model == "" {
+		model = openai.GPT4oMini
+	}
+
+	return OpenAIProvider{
+		client:       openai.NewClientWithConfig(clientCfg),
+		model:        model,
+		temperature:  cfg.Temperature,
+		maxTokens:    cfg.MaxTokens,
+		strictSchema: strictSchema,
+	}
+}
+
+func (p OpenAIProvider) Complete(ctx context.Context, prompt string, schemaName string, schema *jsonschema.Definition) (string, error) {
+	req := openai.ChatCompletionRequest{
+		Model:     p.model,
+		MaxTokens: p.maxTokens,
+		Messages: []openai.ChatCompletionMessage{
+			{
+				Role:    openai.ChatMessageRoleUser,
+				Content: prompt,
+			},
+		},
+	}
+
+	if p.temperature != nil {
+		req.Temperature = *p.temperature
+		// the client omits zero temperature, so the API would use its default one instead
+		if req.Temperature == 0 {
+			req.Temperature = math.SmallestNonzeroFloat32
+		}
+	}
+
+	if p.strictSchema {
+		req.ResponseFormat = &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
+			JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
+				Name:   schemaName,
+				Schema: schema,
+				Strict: true,
+			},
+		}
+	} else {
+		schemaJSON, err := json.Marshal(schema)
+		if err != nil {
+			return "", fmt.Errorf("sentences.OpenAIProvider.Complete unable to marshal schema. %w", err)
+		}
+		req.ResponseFormat = &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONObject,
+		}
+		req.Messages = append([]openai.ChatCompletionMessage{
+			{
+				Role:    openai.ChatMessageRoleSystem,
+				Content: "Respond with a JSON object only, matching this JSON schema: " + string(schemaJSON),
+			},
+		}, req.Messages...)
+	}
+
+	var retryAfter time.Duration
+	response, err := p.client.CreateChatCompletion(context.WithValue(ctx, retryAfterKey{}, &retryAfter), req)
+	if err != nil {
+		return "", fmt.Errorf("sentences.OpenAIProvider.Complete unable to make chat completion request. %w", classifyOpenAIError(err, retryAfter))
+	}
+
+	if len(response.Choices) == 0 {
+		return "", errors.New("sentences.OpenAIProvider.Complete response has no choices")
+	}
+
+	choice := response.Choices[0]
+	if choice.Message.Refusal != "" || choice.FinishReason == openai.FinishReasonContentFilter {
+		return "", fmt.Errorf("sentences.OpenAIProvider.Complete. %w", &ProviderError{
+			Kind: ErrContentRefused,
+			Err:  fmt.Errorf("finish reason %s: %s", choice.FinishReason, choice.Message.Refusal),
+		})
+	}
+
+	return choice.Message.Content, nil
+}
+
+func classifyOpenAIError(err error, retryAfter time.Duration) error {
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		code, _ := apiErr.Code.(string)
+		return classifyHTTPError(apiErr.HTTPStatusCode, code, retryAfter, err)
+	}
+
+	var reqErr *openai.RequestError
+	if errors.As(err, &reqErr) {
+		return classifyHTTPError(reqErr.HTTPStatusCode, "", retryAfter, err)
+	}
+
+	return err
+}
+
+type retryAfterKey struct{}
+
+// retryAfterTransport exposes Retry-After header of a response through the request context,
+// as the OpenAI client doesn't return response headers along with errors.
+type retryAfterTransport struct {
+	next http.RoundTripper
+}
+
+func (t retryAfterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	if retryAfter, ok := req.Context().Value(retryAfterKey{}).(*time.Duration); ok {
+		*retryAfter = parseRetryAfter(resp.Header, time.Now())
+	}
+	return resp, nil
+}
@@ -0,0 +1,110 @@
+package sentences
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+func TestOpenAIProvider_Complete(t *testing.T) {
+	t.Parallel()
+
+	zero := float32(0)
+	tests := []struct {
+		name        string
+		newProvider func(cfg ProviderConfig) OpenAIProvider
+		temperature *float32
+		// expected checks the request body the server got.
+		expected func(t *testing.T, req map[string]any)
+	}{
+		{
+			name:        "structured output with zero temperature",
+			newProvider: NewOpenAIProvider,
+			temperature: &zero,
+			expected: func(t *testing.T, req map[string]any) {
+				t.Helper()
+				format, _ := req["response_format"].(map[string]any)
+				if format["type"] != string(openai.ChatCompletionResponseFormatTypeJSONSchema) {
+					t.Errorf("unexpected response format %v", req["response_format"])
+				}
+				if temperature, ok := req["temperature"].(float64); !ok || temperature > 1e-6 {
+					t.Errorf("expected temperature close to zero, got %v", req["temperature"])
+				}
+				if messages, _ := req["messages"].([]any); len(messages) != 1 {
+					t.Errorf("unexpected messages %v", req["messages"])
+				}
+			},
+		},
+		{
+			name:        "compatible server with default temperature",
+			newProvider: NewOpenAICompatibleProvider,
+			expected: func(t *testing.T, req map[string]any) {
+				t.Helper()
+				format, _ := req["response_format"].(map[string]any)
+				if format["type"] != string(openai.ChatCompletionResponseFormatTypeJSONObject) {
+					t.Errorf("unexpected response format %v", req["response_format"])
+				}
+				if _, ok := req["temperature"]; ok {
+					t.Errorf("expected no temperature, got %v", req["temperature"])
+				}
+				// the schema is passed within the prompt
+				if messages, _ := req["messages"].([]any); len(messages) != 2 {
+					t.Errorf("unexpected messages %v", req["messages"])
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/chat/completions" || r.Header.Get("Authorization") != "Bearer token" {
+					t.Errorf("unexpected request %s %v", r.URL.Path, r.Header)
+				}
+
+				var req map[string]any
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Error(err)
+				}
+				if req["model"] != "gpt-test" {
+					t.Errorf("unexpected model %v", req["model"])
+				}
+				tt.expected(t, req)
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"choices":[{"index":0,"message":{"role":"assistant","content":"{\"sentences\":[]}"}}]}`))
+			}))
+			defer srv.Close()
+
+			p := tt.newProvider(ProviderConfig{BaseURL: srv.URL + "/v1", APIToken: "token", Model: "gpt-test", Temperature: tt.temperature})
+			actual, err := p.Complete(context.Background(), "prompt", "word_learning", &jsonschema.Definition{Type: jsonschema.Object})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual != `{"sentences":[]}` {
+				t.Errorf("unexpected content %s", actual)
+			}
+		})
+	}
+}
+
+func TestOpenAIProvider_CompleteError(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"choices":[]}`))
+	}))
+	defer srv.Close()
+
+	p := NewOpenAIProvider(ProviderConfig{BaseURL: srv.URL})
+	if _, err := p.Complete(context.Background(), "prompt", "word_learning", &jsonschema.Definition{Type: jsonschema.Object}); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,46 @@
+package sentences
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+const (
+	ProviderOpenAI           = "openai"
+	ProviderOpenAICompatible = "openai-compatible"
+	ProviderAnthropic        = "anthropic"
+)
+
+// Provider sends a prompt to a language model and returns its answer as a JSON document
+// matching the schema.
+type Provider interface {
+	Complete(ctx context.Context, prompt string, schemaName string, schema *jsonschema.Definition) (string, error)
+}
+
+// ProviderConfig configures a Provider. Zero values fall back to the provider's defaults.
+type ProviderConfig struct {
+	Model    string
+	BaseURL  string
+	APIToken string
+	// Temperature is nil to keep the provider's default, zero is a valid temperature.
+	Temperature *float32
+	MaxTokens   int
+}
+
+func NewProvider(name string, cfg ProviderConfig) (Provider, error) {
+	switch name {
+	case ProviderOpenAI:
+		return NewOpenAIProvider(cfg), nil
+	case ProviderOpenAICompatible:
+		if cfg.BaseURL == "" {
+			return nil, fmt.Errorf("sentences.NewProvider %s provider requires base URL", name)
+		}
+		return NewOpenAICompatibleProvider(cfg), nil
+	case ProviderAnthropic:
+		return NewAnthropicProvider(cfg), nil
+	default:
+		return nil, fmt.Errorf("sentences.NewProvider unknown provider %s", name)
+	}
+}
@@ -0,0 +1,159 @@
+package sentences
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("AI provider circuit breaker is open")
+
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+// delay returns exponential backoff with full jitter for the given retry attempt, starting from 0.
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	backoff := p.MaxDelay
+	if attempt < 32 && p.BaseDelay<<attempt < p.MaxDelay { //nolint:mnd
+		backoff = p.BaseDelay << attempt
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	return rand.N(backoff) + 1
+}
+
+// CircuitBreaker stops calls to a provider after threshold consecutive failures
+// and lets a single trial call through once cooldown has passed.
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openedAt  time.Time
+	now       func() time.Time
+}
+
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// Allow reports whether a call may be made now.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.threshold <= 0 || b.failures < b.threshold {
+		return true
+	}
+	if b.now().Sub(b.openedAt) >= b.cooldown {
+		// half-open: the next failure opens the circuit for another cooldown
+		b.openedAt = b.now()
+		return true
+	}
+	return false
+}
+
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+}
+
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.failures == b.threshold {
+		b.openedAt = b.now()
+	}
+}
+
+// ResilientGenerator retries transient provider and transport failures with exponential
+// backoff, honoring the delay requested by the provider, and guards calls with a circuit breaker.
+type ResilientGenerator struct {
+	next    Generator
+	policy  RetryPolicy
+	breaker *CircuitBreaker
+	sleep   func(ctx context.Context, d time.Duration) error
+}
+
+func NewResilientGenerator(next Generator, policy RetryPolicy, breaker *CircuitBreaker) ResilientGenerator {
+	return ResilientGenerator{
+		next:    next,
+		policy:  policy,
+		breaker: breaker,
+		sleep:   sleep,
+	}
+}
+
+func (g ResilientGenerator) Generate(ctx context.Context, req Request) ([]Sentence, error) {
+	for attempt := 0; ; attempt++ {
+		if !g.breaker.Allow() {
+			return nil, fmt.Errorf("sentences.ResilientGenerator.Generate. %w", ErrCircuitOpen)
+		}
+
+		res, err := g.next.Generate(ctx, req)
+		if err == nil {
+			g.breaker.Success()
+			return res, nil
+		}
+
+		retryAfter, ok := retryable(ctx, err)
+		if !ok {
+			return nil, fmt.Errorf("sentences.ResilientGenerator.Generate unable to generate sentences. %w", err)
+		}
+		g.breaker.Failure()
+
+		if attempt >= g.policy.MaxRetries {
+			return nil, fmt.Errorf("sentences.ResilientGenerator.Generate gave up after %d retries. %w", attempt, err)
+		}
+
+		delay := max(retryAfter, g.policy.delay(attempt))
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return nil, fmt.Errorf("sentences.ResilientGenerator.Generate retry delay %s exceeds deadline. %w", delay, err)
+		}
+
+		if err := g.sleep(ctx, delay); err != nil {
+			return nil, fmt.Errorf("sentences.ResilientGenerator.Generate interrupted while waiting for retry. %w", err)
+		}
+	}
+}
+
+// retryable reports whether the failed call may succeed if repeated later, and the delay the
+// provider requested. Besides provider errors telling so, transport failures with no response,
+// for ex: a reset connection, a DNS error or the client's timeout, are retried unless ctx is done.
+func retryable(ctx context.Context, err error) (time.Duration, bool) {
+	var providerErr *ProviderError
+	if errors.As(err, &providerErr) {
+		return providerErr.RetryAfter, providerErr.Retryable()
+	}
+	if ctx.Err() != nil {
+		return 0, false
+	}
+	var netErr net.Error
+	return 0, errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,165 @@
+package sentences
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+type flakyGenerator struct {
+	errs  []error
+	calls int
+}
+
+func (g *flakyGenerator) Generate(_ context.Context, _ Request) ([]Sentence, error) {
+	g.calls++
+	if len(g.errs) > 0 {
+		err := g.errs[0]
+		g.errs = g.errs[1:]
+		return nil, err
+	}
+	return []Sentence{{Text: "ok"}}, nil
+}
+
+func TestResilientGenerator_Generate(t *testing.T) {
+	t.Parallel()
+
+	flaky := &flakyGenerator{errs: []error{
+		&ProviderError{Kind: ErrRateLimited, RetryAfter: 7 * time.Second, Err: errors.New("slow down")},
+		&ProviderError{Kind: ErrProviderUnavailable, Err: errors.New("bad gateway")},
+	}}
+
+	var delays []time.Duration
+	g := NewResilientGenerator(flaky, RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: time.Second}, NewCircuitBreaker(5, time.Minute))
+	g.sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+
+	res, err := g.Generate(context.Background(), Request{Spelling: "a", Count: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 1 || flaky.calls != 3 {
+		t.Errorf("unexpected result %v after %d calls", res, flaky.calls)
+	}
+	if len(delays) != 2 || delays[0] != 7*time.Second || delays[1] > time.Second {
+		t.Errorf("unexpected delays %v", delays)
+	}
+
+	flaky = &flakyGenerator{errs: []error{&ProviderError{Kind: ErrQuotaExhausted, Err: errors.New("no money")}}}
+	g.next = flaky
+	if _, err := g.Generate(context.Background(), Request{Spelling: "a", Count: 1}); !errors.Is(err, ErrQuotaExhausted) || flaky.calls != 1 {
+		t.Errorf("expected quota error without retries, got %v after %d calls", err, flaky.calls)
+	}
+}
+
+func TestResilientGenerator_Generate_TransportErrors(t *testing.T) {
+	t.Parallel()
+
+	flaky := &flakyGenerator{errs: []error{
+		&url.Error{Op: "Post", URL: "https://api.example.com", Err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}},
+		&url.Error{Op: "Post", URL: "https://api.example.com", Err: &net.DNSError{Err: "no such host", Name: "api.example.com"}},
+		// the client's timeout wraps context.DeadlineExceeded, but the caller's context isn't done
+		&url.Error{Op: "Post", URL: "https://api.example.com", Err: context.DeadlineExceeded},
+	}}
+	breaker := NewCircuitBreaker(3, time.Minute)
+	g := NewResilientGenerator(flaky, RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second}, breaker)
+	g.sleep = func(context.Context, time.Duration) error { return nil }
+
+	if _, err := g.Generate(context.Background(), Request{Spelling: "a", Count: 1}); !errors.Is(err, ErrCircuitOpen) || flaky.calls != 3 {
+		t.Errorf("expected transport errors to open the circuit, got %v after %d calls", err, flaky.calls)
+	}
+
+	breaker.Success()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	flaky = &flakyGenerator{errs: []error{&url.Error{Op: "Post", URL: "https://api.example.com", Err: context.Canceled}}}
+	g.next = flaky
+	if _, err := g.Generate(ctx, Request{Spelling: "a", Count: 1}); !errors.Is(err, context.Canceled) || flaky.calls != 1 {
+		t.Errorf("expected canceled call without retries, got %v after %d calls", err, flaky.calls)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	b := NewCircuitBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	b.Failure()
+	if !b.Allow() {
+		t.Error("expected circuit to be closed below threshold")
+	}
+	b.Failure()
+	if b.Allow() {
+		t.Error("expected circuit to be open")
+	}
+
+	now = now.Add(time.Minute)
+	if !b.Allow() {
+		t.Error("expected trial call after cooldown")
+	}
+	if b.Allow() {
+		t.Error("expected a single trial call")
+	}
+
+	b.Success()
+	if !b.Allow() {
+		t.Error("expected circuit to be closed after success")
+	}
+}
+
+func TestOpenAIProvider_CompleteErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		status     int
+		header     http.Header
+		body       string
+		kind       error
+		retryAfter time.Duration
+	}{
+		{http.StatusTooManyRequests, http.Header{"Retry-After": {"12"}}, `{"error":{"message":"slow down","type":"requests","code":"rate_limit_exceeded"}}`, ErrRateLimited, 12 * time.Second},
+		{http.StatusTooManyRequests, nil, `{"error":{"message":"pay","type":"insufficient_quota","code":"insufficient_quota"}}`, ErrQuotaExhausted, 0},
+		{http.StatusBadGateway, nil, `bad gateway`, ErrProviderUnavailable, 0},
+		{http.StatusOK, nil, `{"choices":[{"message":{"role":"assistant","refusal":"no"},"finish_reason":"stop"}]}`, ErrContentRefused, 0},
+	}
+
+	for _, c := range cases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			for k, v := range c.header {
+				w.Header()[k] = v
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(c.status)
+			_, _ = w.Write([]byte(c.body))
+		}))
+
+		p := NewOpenAIProvider(ProviderConfig{BaseURL: srv.URL, APIToken: "token"})
+		_, err := p.Complete(context.Background(), "prompt", "word_learning", &jsonschema.Definition{Type: jsonschema.Object})
+		srv.Close()
+
+		var providerErr *ProviderError
+		if !errors.As(err, &providerErr) {
+			t.Errorf("expected ProviderError for status %d, got %v", c.status, err)
+			continue
+		}
+		if !errors.Is(err, c.kind) {
+			t.Errorf("expected %v for status %d, got %v", c.kind, c.status, err)
+		}
+		if diff := cmp.Diff(c.retryAfter, providerErr.RetryAfter); diff != "" {
+			t.Errorf("unexpected retry after (-want +got):\n%s", diff)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package sentences
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pavelpuchok/vocabforge/levenshtein"
+	"github.com/pavelpuchok/vocabforge/practice/cloze"
+)
+
+const (
+	DefaultMaxSentenceLength = 200
+	DefaultMaxAttempts       = 3
+	// maxStemCut is the number of trailing letters of the word an inflection may replace,
+	// for ex: study and studied, hablar and hablo.
+	maxStemCut = 2
+	// minStem is the shortest stem an inflection has to keep, words shorter than it are kept
+	// whole, for ex: go and goes.
+	minStem = 3
+	// maxInflectionEdits bounds the distance between the word and its inflection, so a longer
+	// word merely starting with the same letters isn't taken for it.
+	maxInflectionEdits = 4
+)
+
+var (
+	ErrInvalidMarkup        = errors.New("sentence must have exactly one marked word")
+	ErrImplausibleForm      = errors.New("marked word is not a form of the spelling")
+	ErrDuplicateSentence    = errors.New("duplicate sentence")
+	ErrSentenceTooLong      = errors.New("sentence is too long")
+	ErrNotEnoughSentences   = errors.New("not enough valid sentences generated")
+	errNoSentencesRequested = errors.New("no sentences requested")
+	errInvalidMaxAttempts   = errors.New("max attempts must be positive")
+)
+
+// Generator is implemented by sentence generators which ValidatingGenerator can wrap.
+type Generator interface {
+	Generate(ctx context.Context, req Request) ([]Sentence, error)
+}
+
+type Validator struct {
+	maxLength int
+}
+
+func NewValidator(maxLength int) Validator {
+	if maxLength <= 0 {
+		maxLength = DefaultMaxSentenceLength
+	}
+	return Validator{maxLength}
+}
+
+// Validate checks the sentence generated for spelling. Accepted holds the sentences
+// already validated, so duplicates are rejected.
+func (v Validator) Validate(spelling string, s Sentence, accepted []Sentence) error {
+	if utf8.RuneCountInString(s.Text) > v.maxLength {
+		return ErrSentenceTooLong
+	}
+
+	c, err := cloze.Parse(s.Text)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidMarkup, err)
+	}
+
+	if !plausibleForm(spelling, c.Answer) {
+		return fmt.Errorf("%w: %s", ErrImplausibleForm, c.Answer)
+	}
+
+	key := sentenceKey(c.Text())
+	for _, a := range accepted {
+		if ac, err := cloze.Parse(a.Text); err == nil && sentenceKey(ac.Text()) == key {
+			return ErrDuplicateSentence
+		}
+	}
+
+	return nil
+}
+
+// ValidatingGenerator drops invalid sentences produced by the wrapped generator and
+// requests the missing ones again, up to maxAttempts requests in total.
+type ValidatingGenerator struct {
+	next        Generator
+	validator   Validator
+	maxAttempts int
+}
+
+func NewValidatingGenerator(next Generator, validator Validator, maxAttempts int) (ValidatingGenerator, error) {
+	if maxAttempts <= 0 {
+		return ValidatingGenerator{}, fmt.Errorf("sentences.NewValidatingGenerator %d attempts. %w", maxAttempts, errInvalidMaxAttempts)
+	}
+	return ValidatingGenerator{
+		next:        next,
+		validator:   validator,
+		maxAttempts: maxAttempts,
+	}, nil
+}
+
+func (g ValidatingGenerator) Generate(ctx context.Context, req Request) ([]Sentence, error) {
+	sentencesCount := req.Count
+	if sentencesCount <= 0 {
+		return nil, fmt.Errorf("sentences.ValidatingGenerator.Generate. %w", errNoSentencesRequested)
+	}
+
+	accepted := make([]Sentence, 0, sentencesCount)
+	var rejections []error
+
+	for attempt := 0; attempt < g.maxAttempts && len(accepted) < sentencesCount; attempt++ {
+		missing := req
+		missing.Count = sentencesCount - len(accepted)
+		generated, err := g.next.Generate(ctx, missing)
+		if err != nil {
+			return nil, fmt.Errorf("sentences.ValidatingGenerator.Generate unable to generate sentences. %w", err)
+		}
+
+		for _, s := range generated {
+			if len(accepted) == sentencesCount {
+				break
+			}
+			if err := g.validator.Validate(req.Spelling, s, accepted); err != nil {
+				rejections = append(rejections, fmt.Errorf("%q: %w", s.Text, err))
+				continue
+			}
+			accepted = append(accepted, s)
+		}
+	}
+
+	if len(accepted) < sentencesCount {
+		return nil, fmt.Errorf("sentences.ValidatingGenerator.Generate got %d of %d sentences after %d attempts. %w",
+			len(accepted), sentencesCount, g.maxAttempts, errors.Join(append([]error{ErrNotEnoughSentences}, rejections...)...))
+	}
+
+	return accepted, nil
+}
+
+// plausibleForm reports whether form looks like an inflection of spelling. Every word of
+// a phrase has to be a plausible form of the corresponding word of the form.
+func plausibleForm(spelling, form string) bool {
+	spellWords := strings.Fields(strings.ToLower(spelling))
+	formWords := strings.Fields(strings.ToLower(form))
+	if len(spellWords) != len(formWords) {
+		return false
+	}
+
+	for i := range spellWords {
+		if !plausibleWordForm(spellWords[i], formWords[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// plausibleWordForm accepts regular inflections, which keep the stem and change a short
+// ending, for ex: study and studied, inflections changing vowels only, for ex: give and gave,
+// and the irregular ones listed in irregularForms.
+func plausibleWordForm(spelling, form string) bool {
+	if irregular(spelling, form) {
+		return true
+	}
+
+	spell, f := []rune(spelling), []rune(form)
+	shared := 0
+	for shared < len(spell) && shared < len(f) && spell[shared] == f[shared] {
+		shared++
+	}
+	keepsStem := shared >= min(len(spell), minStem) && shared >= len(spell)-maxStemCut
+	if keepsStem && levenshtein.Distance(spelling, form) <= maxInflectionEdits {
+		return true
+	}
+
+	consonants := consonantSkeleton(spelling)
+	return consonants != "" && consonants == consonantSkeleton(form)
+}
+
+// consonantSkeleton is the word without vowels.
+func consonantSkeleton(s string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune("aeiouy", r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// irregularForms groups English words with their forms which neither keep the stem nor
+// differ in vowels only. A word may be in several groups, for ex: better is a form of both
+// good and well.
+var irregularForms = [][]string{
+	{"be", "am", "is", "are", "was", "were", "been"},
+	{"go", "went", "gone"},
+	{"do", "did", "done"},
+	{"have", "has", "had"},
+	{"make", "made"},
+	{"say", "said"},
+	{"see", "saw", "seen"},
+	{"lie", "lay", "lain"},
+	{"buy", "bought"},
+	{"bring", "brought"},
+	{"think", "thought"},
+	{"fight", "fought"},
+	{"catch", "caught"},
+	{"teach", "taught"},
+	{"seek", "sought"},
+	{"feel", "felt"},
+	{"keep", "kept"},
+	{"leave", "left"},
+	{"tell", "told"},
+	{"sell", "sold"},
+	{"stand", "stood"},
+	{"can", "could"},
+	{"will", "would"},
+	{"shall", "should"},
+	{"may", "might"},
+	{"good", "better", "best"},
+	{"well", "better", "best"},
+	{"bad", "worse", "worst"},
+	{"badly", "worse", "worst"},
+	{"many", "more", "most"},
+	{"much", "more", "most"},
+	{"little", "less", "least"},
+	{"far", "farther", "farthest", "further", "furthest"},
+	{"person", "people"},
+	{"child", "children"},
+	{"mouse", "mice"},
+	{"louse", "lice"},
+}
+
+// irregular reports whether spelling and form are in the same group of irregularForms.
+func irregular(spelling, form string) bool {
+	for _, group := range irregularForms {
+		if slices.Contains(group, spelling) && slices.Contains(group, form) {
+			return true
+		}
+	}
+	return false
+}
+
+func sentenceKey(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
@@ -0,0 +1,104 @@
+package sentences
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidator_Validate(t *testing.T) {
+	t.Parallel()
+
+	v := NewValidator(40)
+	accepted := []Sentence{{Text: "She <%ran%> home."}}
+
+	cases := map[string]error{
+		"They <%run%> every day.":                     nil,
+		"He was <%running%> late.":                    nil,
+		"No markup at all.":                           ErrInvalidMarkup,
+		"<%Run%>, <%run%>!":                           ErrInvalidMarkup,
+		"She <%walked%> home.":                        ErrImplausibleForm,
+		"she  <% ran %> home.":                        ErrDuplicateSentence,
+		"This <%run%> is way too long to be accepted": ErrSentenceTooLong,
+	}
+
+	for text, expected := range cases {
+		err := v.Validate("run", Sentence{Text: text}, accepted)
+		if !errors.Is(err, expected) {
+			t.Errorf("Validate(%q): want %v, got %v", text, expected, err)
+		}
+	}
+}
+
+func TestPlausibleForm(t *testing.T) {
+	t.Parallel()
+
+	plausible := [][2]string{{"study", "studied"}, {"think", "thought"}, {"give up", "gave up"}, {"Run", "RUNS"},
+		{"go", "went"}, {"be", "was"}, {"be", "is"}, {"good", "better"}, {"eat", "ate"}, {"see", "saw"}, {"go on", "went on"},
+		{"catch", "caught"}, {"hablar", "hablo"}, {"go", "goes"}, {"sing", "sung"},
+	}
+	for _, c := range plausible {
+		if !plausibleForm(c[0], c[1]) {
+			t.Errorf("expected %q to be a plausible form of %q", c[1], c[0])
+		}
+	}
+
+	implausible := [][2]string{{"run", "walk"}, {"give up", "gave"}, {"cat", "dog"}, {"go", "was"}, {"good", "worse"},
+		{"cat", "car"}, {"cat", "catastrophe"}, {"run", "rubbish"},
+	}
+	for _, c := range implausible {
+		if plausibleForm(c[0], c[1]) {
+			t.Errorf("expected %q to be an implausible form of %q", c[1], c[0])
+		}
+	}
+}
+
+type stubGenerator struct {
+	responses [][]Sentence
+	requested []int
+}
+
+func (g *stubGenerator) Generate(_ context.Context, req Request) ([]Sentence, error) {
+	g.requested = append(g.requested, req.Count)
+	if len(g.responses) == 0 {
+		return nil, nil
+	}
+	r := g.responses[0]
+	g.responses = g.responses[1:]
+	return r, nil
+}
+
+func TestValidatingGenerator_Generate(t *testing.T) {
+	t.Parallel()
+
+	stub := &stubGenerator{responses: [][]Sentence{
+		{{Text: "I <%run%>."}, {Text: "no markup"}, {Text: "I <%run%>."}},
+		{{Text: "We <%ran%>."}, {Text: "They <%run%>."}},
+	}}
+
+	g, err := NewValidatingGenerator(stub, NewValidator(0), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := g.Generate(context.Background(), Request{Spelling: "run", Definition: "move fast", LexicalCategory: "verb", Count: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Sentence{{Text: "I <%run%>."}, {Text: "We <%ran%>."}, {Text: "They <%run%>."}}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("unexpected sentences (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]int{3, 2}, stub.requested); diff != "" {
+		t.Errorf("unexpected requested counts (-want +got):\n%s", diff)
+	}
+
+	_, err = g.Generate(context.Background(), Request{Spelling: "run", Definition: "move fast", LexicalCategory: "verb", Count: 1})
+	if !errors.Is(err, ErrNotEnoughSentences) || !strings.Contains(err.Error(), "after 3 attempts") {
+		t.Errorf("expected ErrNotEnoughSentences, got %v", err)
+	}
+}
@@ -2,7 +2,9 @@ package vocabulary
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/pavelpuchok/vocabforge/models"
 	"github.com/pavelpuchok/vocabforge/vocabulary/sentences"
@@ -15,7 +17,7 @@ type Service struct {
 }
 
 type SentencesGenerator interface {
-	Generate(ctx context.Context, spell, definition, lexicalCategory string, sentencesCount int) ([]sentences.Sentence, error)
+	Generate(ctx context.Context, req sentences.Request) ([]sentences.Sentence, error)
 }
 
 func NewService(repo Repository, sentences SentencesGenerator, sentencesCount int) Service {
@@ -27,29 +29,238 @@ func NewService(repo Repository, sentences SentencesGenerator, sentencesCount in
 }
 
 type Repository interface {
-	AddWord(ctx context.Context, userID models.UserID, spell, definition, lexicalCategory string, lang models.Language, exercises []models.SentenceExercise) (models.Word, error)
+	AddWord(ctx context.Context, userID models.UserID, spell, definition, lexicalCategory string, lang, definitionLang models.Language, exercises []models.SentenceExercise) (models.Word, error)
+	GetWord(ctx context.Context, userID models.UserID, wordID models.WordID) (models.Word, error)
+	ListWords(ctx context.Context, userID models.UserID, filter ListFilter) (WordsPage, error)
+	// CountWords counts the words matching the filter by learn status, and the ones of them due for a
+	// review at dueAt. Sort, Cursor and Limit of the filter are ignored.
+	CountWords(ctx context.Context, userID models.UserID, filter ListFilter, dueAt time.Time) (WordCounts, error)
+	UpdateWord(ctx context.Context, userID models.UserID, wordID models.WordID, patch WordPatch) (models.Word, error)
+	ArchiveWord(ctx context.Context, userID models.UserID, wordID models.WordID) (models.Word, error)
+	DeleteWord(ctx context.Context, userID models.UserID, wordID models.WordID) error
+	// UpdateSchedule sets the word's learn status and schedule if the word was last reviewed at
+	// lastReview still, so a schedule computed from a stale one doesn't overwrite a concurrent
+	// review. Otherwise it returns ErrScheduleChanged.
+	UpdateSchedule(ctx context.Context, userID models.UserID, wordID models.WordID, lastReview time.Time, status models.LearnStatus, schedule models.Schedule) (models.Word, error)
+	RecordAnswer(ctx context.Context, userID models.UserID, wordID models.WordID, exerciseIndex int, correct bool, status models.LearnStatus, schedule models.Schedule) (models.Word, error)
+	// RestoreWord stores the word with its learning state under a new ID, the word's ID and UserID are ignored.
+	RestoreWord(ctx context.Context, userID models.UserID, w models.Word) (models.Word, error)
 }
 
-func (s Service) AddWord(ctx context.Context, userID models.UserID, spell, definition, lexicalCategory string, lang models.Language, exercises []models.SentenceExercise) (models.Word, error) {
-	if len(exercises) == 0 {
-		sentences, err := s.sentences.Generate(ctx, spell, definition, lexicalCategory, s.defaultSentencesCount)
+// WordPatch describes a partial word update. Nil fields are left unchanged.
+type WordPatch struct {
+	Spelling        *string
+	Definition      *string
+	LexicalCategory *string
+	Language        *models.Language
+	Exercises       []models.SentenceExercise
+	// Tags replace the word's tags, an empty non-nil slice removes them all.
+	Tags   []string
+	Source *string
+}
+
+var (
+	ErrInvalidPatch = errors.New("invalid word patch")
+	// ErrScheduleChanged is returned when the word was reviewed since its schedule was read.
+	ErrScheduleChanged = errors.New("word schedule changed concurrently")
+	// ErrMissingDefinition is returned when exercises are to be generated for a word without definition.
+	ErrMissingDefinition = errors.New("word has no definition to generate exercises for")
+)
+
+// AddWord stores the word with the given exercises or, when there are none, with generated
+// ones. Words without definition, for ex: imported from a Kindle, get no generated exercises.
+func (s Service) AddWord(ctx context.Context, userID models.UserID, spell, definition, lexicalCategory string, lang, definitionLang models.Language, exercises []models.SentenceExercise) (models.Word, error) {
+	if len(exercises) == 0 && definition != "" {
+		generated, err := s.generateExercises(ctx, spell, definition, lexicalCategory, lang, definitionLang)
 		if err != nil {
 			return models.Word{}, fmt.Errorf("vocabulary.Service.AddWord unable to generate exercises. %w", err)
 		}
+		exercises = generated
+	}
 
-		exercises = make([]models.SentenceExercise, len(sentences))
+	word, err := s.repository.AddWord(ctx, userID, spell, definition, lexicalCategory, lang, definitionLang, exercises)
+	if err != nil {
+		return word, fmt.Errorf("vocabulary.Service.AddWord unable to add word. %w", err)
+	}
+	return word, nil
+}
 
-		for i, ss := range sentences {
-			exercises[i] = models.SentenceExercise{
-				Sentence: ss.Text,
-				Answered: false,
-			}
+// ImportWord stores the word with its tags, source and learning state in a single write, so
+// an imported word is never left without them. Exercises are generated as by AddWord.
+func (s Service) ImportWord(ctx context.Context, userID models.UserID, w models.Word) (models.Word, error) {
+	if len(w.Exercises) == 0 && w.Definition != "" {
+		generated, err := s.generateExercises(ctx, w.Spelling, w.Definition, w.LexicalCategory, w.Language, w.DefinitionLanguage)
+		if err != nil {
+			return models.Word{}, fmt.Errorf("vocabulary.Service.ImportWord unable to generate exercises. %w", err)
 		}
+		w.Exercises = generated
 	}
 
-	word, err := s.repository.AddWord(ctx, userID, spell, definition, lexicalCategory, lang, exercises)
+	word, err := s.repository.RestoreWord(ctx, userID, w)
 	if err != nil {
-		return word, fmt.Errorf("vocabulary.Service.AddWord unable to add word. %w", err)
+		return word, fmt.Errorf("vocabulary.Service.ImportWord unable to add word. %w", err)
+	}
+	return word, nil
+}
+
+func (s Service) GetWord(ctx context.Context, userID models.UserID, wordID models.WordID) (models.Word, error) {
+	word, err := s.repository.GetWord(ctx, userID, wordID)
+	if err != nil {
+		return word, fmt.Errorf("vocabulary.Service.GetWord unable to get word. %w", err)
+	}
+	return word, nil
+}
+
+func (s Service) ListWords(ctx context.Context, userID models.UserID, filter ListFilter) (WordsPage, error) {
+	filter.Limit = normalizeLimit(filter.Limit)
+	page, err := s.repository.ListWords(ctx, userID, filter)
+	if err != nil {
+		return page, fmt.Errorf("vocabulary.Service.ListWords unable to list words. %w", err)
+	}
+	return page, nil
+}
+
+// CountWords counts the words matching the filter by learn status, and the ones of them due
+// for a review at dueAt.
+func (s Service) CountWords(ctx context.Context, userID models.UserID, filter ListFilter, dueAt time.Time) (WordCounts, error) {
+	counts, err := s.repository.CountWords(ctx, userID, filter, dueAt)
+	if err != nil {
+		return counts, fmt.Errorf("vocabulary.Service.CountWords unable to count words. %w", err)
+	}
+	return counts, nil
+}
+
+// UpdateWord applies patch to the word. When regenerateExercises is set and the patch
+// changes spelling or definition, stored exercises are replaced with freshly generated ones.
+func (s Service) UpdateWord(ctx context.Context, userID models.UserID, wordID models.WordID, patch WordPatch, regenerateExercises bool) (models.Word, error) {
+	if patch.Spelling != nil && *patch.Spelling == "" {
+		return models.Word{}, fmt.Errorf("vocabulary.Service.UpdateWord empty spelling. %w", ErrInvalidPatch)
+	}
+	if patch.Definition != nil && *patch.Definition == "" {
+		return models.Word{}, fmt.Errorf("vocabulary.Service.UpdateWord empty definition. %w", ErrInvalidPatch)
+	}
+
+	if regenerateExercises && patch.Exercises == nil && (patch.Spelling != nil || patch.Definition != nil) {
+		word, err := s.repository.GetWord(ctx, userID, wordID)
+		if err != nil {
+			return models.Word{}, fmt.Errorf("vocabulary.Service.UpdateWord unable to get word. %w", err)
+		}
+
+		spell, definition, lexicalCategory, lang := word.Spelling, word.Definition, word.LexicalCategory, word.Language
+		if patch.Spelling != nil {
+			spell = *patch.Spelling
+		}
+		if patch.Definition != nil {
+			definition = *patch.Definition
+		}
+		if patch.LexicalCategory != nil {
+			lexicalCategory = *patch.LexicalCategory
+		}
+		if patch.Language != nil {
+			lang = *patch.Language
+		}
+
+		exercises, err := s.generateExercises(ctx, spell, definition, lexicalCategory, lang, word.DefinitionLanguage)
+		if err != nil {
+			return models.Word{}, fmt.Errorf("vocabulary.Service.UpdateWord unable to generate exercises. %w", err)
+		}
+		patch.Exercises = exercises
+	}
+
+	word, err := s.repository.UpdateWord(ctx, userID, wordID, patch)
+	if err != nil {
+		return word, fmt.Errorf("vocabulary.Service.UpdateWord unable to update word. %w", err)
+	}
+	return word, nil
+}
+
+// RegenerateExercises replaces the word's exercises with freshly generated ones.
+func (s Service) RegenerateExercises(ctx context.Context, userID models.UserID, wordID models.WordID) (models.Word, error) {
+	word, err := s.repository.GetWord(ctx, userID, wordID)
+	if err != nil {
+		return models.Word{}, fmt.Errorf("vocabulary.Service.RegenerateExercises unable to get word. %w", err)
+	}
+
+	exercises, err := s.generateExercises(ctx, word.Spelling, word.Definition, word.LexicalCategory, word.Language, word.DefinitionLanguage)
+	if err != nil {
+		return models.Word{}, fmt.Errorf("vocabulary.Service.RegenerateExercises unable to generate exercises. %w", err)
+	}
+
+	word, err = s.repository.UpdateWord(ctx, userID, wordID, WordPatch{Exercises: exercises})
+	if err != nil {
+		return word, fmt.Errorf("vocabulary.Service.RegenerateExercises unable to update word. %w", err)
+	}
+	return word, nil
+}
+
+func (s Service) ArchiveWord(ctx context.Context, userID models.UserID, wordID models.WordID) (models.Word, error) {
+	word, err := s.repository.ArchiveWord(ctx, userID, wordID)
+	if err != nil {
+		return word, fmt.Errorf("vocabulary.Service.ArchiveWord unable to archive word. %w", err)
+	}
+	return word, nil
+}
+
+// DeleteWord removes the word together with its exercises.
+func (s Service) DeleteWord(ctx context.Context, userID models.UserID, wordID models.WordID) error {
+	err := s.repository.DeleteWord(ctx, userID, wordID)
+	if err != nil {
+		return fmt.Errorf("vocabulary.Service.DeleteWord unable to delete word. %w", err)
+	}
+	return nil
+}
+
+func (s Service) UpdateSchedule(ctx context.Context, userID models.UserID, wordID models.WordID, lastReview time.Time, status models.LearnStatus, schedule models.Schedule) (models.Word, error) {
+	word, err := s.repository.UpdateSchedule(ctx, userID, wordID, lastReview, status, schedule)
+	if err != nil {
+		return word, fmt.Errorf("vocabulary.Service.UpdateSchedule unable to update schedule. %w", err)
+	}
+	return word, nil
+}
+
+// RecordAnswer stores the outcome of an exercise. A correct answer marks the exercise
+// answered and bumps the word's answered count. Negative exerciseIndex means
+// the answer wasn't given on a stored exercise.
+func (s Service) RecordAnswer(ctx context.Context, userID models.UserID, wordID models.WordID, exerciseIndex int, correct bool, status models.LearnStatus, schedule models.Schedule) (models.Word, error) {
+	word, err := s.repository.RecordAnswer(ctx, userID, wordID, exerciseIndex, correct, status, schedule)
+	if err != nil {
+		return word, fmt.Errorf("vocabulary.Service.RecordAnswer unable to record answer. %w", err)
+	}
+	return word, nil
+}
+
+// RestoreWord adds the word as it is, with the learning state, for ex: from a backup.
+func (s Service) RestoreWord(ctx context.Context, userID models.UserID, w models.Word) (models.Word, error) {
+	word, err := s.repository.RestoreWord(ctx, userID, w)
+	if err != nil {
+		return word, fmt.Errorf("vocabulary.Service.RestoreWord unable to restore word. %w", err)
 	}
 	return word, nil
 }
+
+func (s Service) generateExercises(ctx context.Context, spell, definition, lexicalCategory string, lang, definitionLang models.Language) ([]models.SentenceExercise, error) {
+	// sentences generated without the meaning may use any other meaning of the word
+	if definition == "" {
+		return nil, ErrMissingDefinition
+	}
+	generated, err := s.sentences.Generate(ctx, sentences.Request{
+		Spelling:           spell,
+		Definition:         definition,
+		LexicalCategory:    lexicalCategory,
+		Language:           lang,
+		DefinitionLanguage: definitionLang,
+		Count:              s.defaultSentencesCount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	exercises := make([]models.SentenceExercise, len(generated))
+	for i, ss := range generated {
+		exercises[i] = models.SentenceExercise{
+			Sentence: ss.Text,
+			Answered: false,
+		}
+	}
+	return exercises, nil
+}
@@ -0,0 +1,205 @@
+package vocabulary_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+	"github.com/pavelpuchok/vocabforge/vocabulary/sentences"
+)
+
+const testUserID = "000000000000000000000001"
+
+type fakeGenerator struct {
+	requests []sentences.Request
+}
+
+func (f *fakeGenerator) Generate(_ context.Context, req sentences.Request) ([]sentences.Sentence, error) {
+	f.requests = append(f.requests, req)
+	res := make([]sentences.Sentence, req.Count)
+	for i := range res {
+		res[i] = sentences.Sentence{Text: "<%" + req.Spelling + "%> " + req.Definition}
+	}
+	return res, nil
+}
+
+// fakeRepository keeps a single word, the methods the tests don't call aren't implemented.
+type fakeRepository struct {
+	vocabulary.Repository
+	word models.Word
+}
+
+// AddWord replaces the kept word.
+func (f *fakeRepository) AddWord(_ context.Context, userID models.UserID, spell, definition, lexicalCategory string, lang, definitionLang models.Language, exercises []models.SentenceExercise) (models.Word, error) {
+	f.word = models.Word{
+		ID:                 "66f1a2b3c4d5e6f708091a2b",
+		UserID:             userID,
+		Spelling:           spell,
+		Definition:         definition,
+		LexicalCategory:    lexicalCategory,
+		Language:           lang,
+		DefinitionLanguage: definitionLang,
+		Exercises:          exercises,
+	}
+	return f.word, nil
+}
+
+func (f *fakeRepository) GetWord(_ context.Context, _ models.UserID, wordID models.WordID) (models.Word, error) {
+	if wordID != f.word.ID {
+		return models.Word{}, vocabulary.ErrWordNotFound
+	}
+	return f.word, nil
+}
+
+func (f *fakeRepository) UpdateWord(_ context.Context, _ models.UserID, wordID models.WordID, patch vocabulary.WordPatch) (models.Word, error) {
+	if wordID != f.word.ID {
+		return models.Word{}, vocabulary.ErrWordNotFound
+	}
+	if patch.Spelling != nil {
+		f.word.Spelling = *patch.Spelling
+	}
+	if patch.Definition != nil {
+		f.word.Definition = *patch.Definition
+	}
+	if patch.LexicalCategory != nil {
+		f.word.LexicalCategory = *patch.LexicalCategory
+	}
+	if patch.Exercises != nil {
+		f.word.Exercises = patch.Exercises
+	}
+	return f.word, nil
+}
+
+func TestService_AddWord(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	gen := &fakeGenerator{}
+	s := vocabulary.NewService(&fakeRepository{}, gen, 2)
+
+	word, err := s.AddWord(ctx, testUserID, "run", "move fast", "verb", "en", "en", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []models.SentenceExercise{{Sentence: "<%run%> move fast"}, {Sentence: "<%run%> move fast"}}
+	if diff := cmp.Diff(expected, word.Exercises); diff != "" {
+		t.Errorf("unexpected exercises (-want +got):\n%s", diff)
+	}
+
+	// words without definition, for ex: from a Kindle, are added without exercises
+	word, err = s.AddWord(ctx, testUserID, "walk", "", "", "en", "en", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(word.Exercises) != 0 || len(gen.requests) != 1 {
+		t.Errorf("expected no exercises to be generated, got %+v and %d requests", word.Exercises, len(gen.requests))
+	}
+	if _, err := s.RegenerateExercises(ctx, testUserID, word.ID); !errors.Is(err, vocabulary.ErrMissingDefinition) {
+		t.Errorf("expected ErrMissingDefinition, got %v", err)
+	}
+}
+
+func TestService_UpdateWord(t *testing.T) {
+	t.Parallel()
+
+	spelling := "sprint"
+	definition := "run fast"
+	category := "noun"
+	stored := []models.SentenceExercise{{Sentence: "She <%ran%> home."}}
+	explicit := []models.SentenceExercise{{Sentence: "They <%sprint%> daily."}}
+	tests := []struct {
+		name       string
+		patch      vocabulary.WordPatch
+		regenerate bool
+		expected   []models.SentenceExercise
+		// request is the expected generator request, nil if nothing is to be generated.
+		request *sentences.Request
+	}{
+		{
+			name:       "regenerate on spelling change",
+			patch:      vocabulary.WordPatch{Spelling: &spelling, LexicalCategory: &category},
+			regenerate: true,
+			expected:   []models.SentenceExercise{{Sentence: "<%sprint%> move fast"}},
+			request:    &sentences.Request{Spelling: "sprint", Definition: "move fast", LexicalCategory: "noun", Count: 1},
+		},
+		{
+			name:       "regenerate on definition change",
+			patch:      vocabulary.WordPatch{Definition: &definition},
+			regenerate: true,
+			expected:   []models.SentenceExercise{{Sentence: "<%run%> run fast"}},
+			request:    &sentences.Request{Spelling: "run", Definition: "run fast", LexicalCategory: "verb", Count: 1},
+		},
+		{
+			name:     "no regenerate without the flag",
+			patch:    vocabulary.WordPatch{Spelling: &spelling},
+			expected: stored,
+		},
+		{
+			name:       "no regenerate without spelling or definition change",
+			patch:      vocabulary.WordPatch{LexicalCategory: &category},
+			regenerate: true,
+			expected:   stored,
+		},
+		{
+			name:       "explicit exercises win",
+			patch:      vocabulary.WordPatch{Spelling: &spelling, Exercises: explicit},
+			regenerate: true,
+			expected:   explicit,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gen := &fakeGenerator{}
+			repo := &fakeRepository{word: models.Word{
+				ID:              "66f1a2b3c4d5e6f708091a2b",
+				Spelling:        "run",
+				Definition:      "move fast",
+				LexicalCategory: "verb",
+				Exercises:       stored,
+			}}
+			s := vocabulary.NewService(repo, gen, 1)
+
+			word, err := s.UpdateWord(context.Background(), testUserID, repo.word.ID, tt.patch, tt.regenerate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.expected, word.Exercises); diff != "" {
+				t.Errorf("unexpected exercises (-want +got):\n%s", diff)
+			}
+
+			var expectedRequests []sentences.Request
+			if tt.request != nil {
+				expectedRequests = append(expectedRequests, *tt.request)
+			}
+			if diff := cmp.Diff(expectedRequests, gen.requests); diff != "" {
+				t.Errorf("unexpected generator requests (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestService_UpdateWord_Errors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := &fakeRepository{word: models.Word{ID: "66f1a2b3c4d5e6f708091a2b", Spelling: "run", Definition: "move fast"}}
+	s := vocabulary.NewService(repo, &fakeGenerator{}, 1)
+
+	empty := ""
+	for _, patch := range []vocabulary.WordPatch{{Spelling: &empty}, {Definition: &empty}} {
+		if _, err := s.UpdateWord(ctx, testUserID, repo.word.ID, patch, true); !errors.Is(err, vocabulary.ErrInvalidPatch) {
+			t.Errorf("expected ErrInvalidPatch for %+v, got %v", patch, err)
+		}
+	}
+
+	spelling := "sprint"
+	_, err := s.UpdateWord(ctx, testUserID, "66f1a2b3c4d5e6f708091a2c", vocabulary.WordPatch{Spelling: &spelling}, true)
+	if !errors.Is(err, vocabulary.ErrWordNotFound) {
+		t.Errorf("expected ErrWordNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,399 @@
+package vocabulary
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pavelpuchok/vocabforge/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// SQLiteRepository keeps words in the words table of a database opened with sqlitedb.Open.
+// Words get ObjectID like IDs, so they are ordered by creation as in MongoRepository.
+type SQLiteRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteRepository(db *sql.DB) SQLiteRepository {
+	return SQLiteRepository{
+		db,
+	}
+}
+
+const sqliteWordColumns = `id, user_id, spelling, definition, language, definition_language, learn_status,
+	lexical_category, answered_count, exercises, archived, tags, source,
+	ease, stability, difficulty, interval, due, last_review, repetitions, lapses`
+
+// sqliteQuerier is either *sql.DB or *sql.Tx.
+type sqliteQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type sqliteScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSQLiteWord(row sqliteScanner) (models.Word, error) {
+	var w models.Word
+	var status, exercises, tags string
+	var interval, due, lastReview int64
+	err := row.Scan(&w.ID, &w.UserID, &w.Spelling, &w.Definition, &w.Language, &w.DefinitionLanguage, &status,
+		&w.LexicalCategory, &w.AnsweredCount, &exercises, &w.Archived, &tags, &w.Source,
+		&w.Schedule.Ease, &w.Schedule.Stability, &w.Schedule.Difficulty, &interval, &due, &lastReview, &w.Schedule.Repetitions, &w.Schedule.Lapses)
+	if err != nil {
+		return models.Word{}, err
+	}
+
+	if err := w.LearnStatus.UnmarshalText(status); err != nil {
+		return models.Word{}, fmt.Errorf("unable to unmarshal status %s. %w", status, err)
+	}
+	if err := json.Unmarshal([]byte(exercises), &w.Exercises); err != nil {
+		return models.Word{}, fmt.Errorf("unable to unmarshal exercises. %w", err)
+	}
+	if err := json.Unmarshal([]byte(tags), &w.Tags); err != nil {
+		return models.Word{}, fmt.Errorf("unable to unmarshal tags. %w", err)
+	}
+	w.Schedule.Interval = time.Duration(interval)
+	w.Schedule.Due = time.UnixMilli(due).UTC()
+	w.Schedule.LastReview = time.UnixMilli(lastReview).UTC()
+	return w, nil
+}
+
+// sqliteWordValues returns the values of sqliteWordColumns, with spelling_key last.
+func sqliteWordValues(w models.Word) ([]any, error) {
+	status, err := w.LearnStatus.MarshalText()
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal status. %w", err)
+	}
+	exercises, err := json.Marshal(w.Exercises)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal exercises. %w", err)
+	}
+	tags, err := json.Marshal(w.Tags)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal tags. %w", err)
+	}
+	return []any{
+		w.ID, w.UserID, w.Spelling, w.Definition, w.Language, w.DefinitionLanguage, status,
+		w.LexicalCategory, w.AnsweredCount, string(exercises), w.Archived, string(tags), w.Source,
+		w.Schedule.Ease, w.Schedule.Stability, w.Schedule.Difficulty, int64(w.Schedule.Interval),
+		w.Schedule.Due.UnixMilli(), w.Schedule.LastReview.UnixMilli(), w.Schedule.Repetitions, w.Schedule.Lapses,
+		strings.ToLower(w.Spelling),
+	}, nil
+}
+
+func (r SQLiteRepository) insert(ctx context.Context, w models.Word) (models.Word, error) {
+	w.ID = models.WordID(primitive.NewObjectID().Hex())
+	values, err := sqliteWordValues(w)
+	if err != nil {
+		return models.Word{}, err
+	}
+	_, err = r.db.ExecContext(ctx, `INSERT INTO words (`+sqliteWordColumns+`, spelling_key)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, values...)
+	if isSQLiteUniqueViolation(err) {
+		return models.Word{}, fmt.Errorf("word %s. %w", w.Spelling, ErrDuplicateWord)
+	}
+	if err != nil {
+		return models.Word{}, fmt.Errorf("unable to insert word. %w", err)
+	}
+	return r.GetWord(ctx, w.UserID, w.ID)
+}
+
+func (r SQLiteRepository) AddWord(ctx context.Context, userID models.UserID, spell, definition, lexicalCategory string, lang, definitionLang models.Language, exercises []models.SentenceExercise) (models.Word, error) {
+	w, err := r.insert(ctx, models.Word{
+		UserID:             userID,
+		Spelling:           spell,
+		Definition:         definition,
+		Language:           lang,
+		DefinitionLanguage: definitionLang,
+		LearnStatus:        models.Pending,
+		LexicalCategory:    lexicalCategory,
+		Exercises:          exercises,
+	})
+	if err != nil {
+		return models.Word{}, fmt.Errorf("vocabulary.SQLiteRepository.AddWord. %w", err)
+	}
+	return w, nil
+}
+
+// RestoreWord inserts the word with its learning state under a new ID.
+func (r SQLiteRepository) RestoreWord(ctx context.Context, userID models.UserID, w models.Word) (models.Word, error) {
+	w.UserID = userID
+	restored, err := r.insert(ctx, w)
+	if err != nil {
+		return models.Word{}, fmt.Errorf("vocabulary.SQLiteRepository.RestoreWord. %w", err)
+	}
+	return restored, nil
+}
+
+func (r SQLiteRepository) GetWord(ctx context.Context, userID models.UserID, wordID models.WordID) (models.Word, error) {
+	w, err := getSQLiteWord(ctx, r.db, userID, wordID)
+	if err != nil {
+		return models.Word{}, fmt.Errorf("vocabulary.SQLiteRepository.GetWord. %w", err)
+	}
+	return w, nil
+}
+
+func getSQLiteWord(ctx context.Context, q sqliteQuerier, userID models.UserID, wordID models.WordID) (models.Word, error) {
+	row := q.QueryRowContext(ctx, `SELECT `+sqliteWordColumns+` FROM words WHERE id = ? AND user_id = ?`, wordID, userID)
+	w, err := scanSQLiteWord(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Word{}, fmt.Errorf("word %s. %w", wordID, ErrWordNotFound)
+	}
+	if err != nil {
+		return models.Word{}, fmt.Errorf("unable to fetch word %s. %w", wordID, err)
+	}
+	return w, nil
+}
+
+func (r SQLiteRepository) ListWords(ctx context.Context, userID models.UserID, filter ListFilter) (WordsPage, error) {
+	where, args, err := sqliteListWhere(userID, filter)
+	if err != nil {
+		return WordsPage{}, fmt.Errorf("vocabulary.SQLiteRepository.ListWords unable to build query. %w", err)
+	}
+
+	limit := normalizeLimit(filter.Limit)
+	rows, err := r.db.QueryContext(ctx, `SELECT `+sqliteWordColumns+` FROM words WHERE `+where+
+		` ORDER BY `+sqlListOrder(filter.Sort)+` LIMIT ?`, append(args, limit+1)...)
+	if err != nil {
+		return WordsPage{}, fmt.Errorf("vocabulary.SQLiteRepository.ListWords unable to find words. %w", err)
+	}
+	defer rows.Close()
+
+	var words []models.Word
+	for rows.Next() {
+		w, err := scanSQLiteWord(rows)
+		if err != nil {
+			return WordsPage{}, fmt.Errorf("vocabulary.SQLiteRepository.ListWords unable to scan word. %w", err)
+		}
+		words = append(words, w)
+	}
+	if err := rows.Err(); err != nil {
+		return WordsPage{}, fmt.Errorf("vocabulary.SQLiteRepository.ListWords unable to read words. %w", err)
+	}
+
+	return pageFromWords(words, limit, filter.Sort), nil
+}
+
+func (r SQLiteRepository) CountWords(ctx context.Context, userID models.UserID, filter ListFilter, dueAt time.Time) (WordCounts, error) {
+	filter.Cursor = ""
+	where, args, err := sqliteListWhere(userID, filter)
+	if err != nil {
+		return WordCounts{}, fmt.Errorf("vocabulary.SQLiteRepository.CountWords unable to build query. %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT learn_status, COUNT(*), SUM(CASE WHEN due <= ? THEN 1 ELSE 0 END)
+		FROM words WHERE `+where+` GROUP BY learn_status`, append([]any{dueAt.UnixMilli()}, args...)...)
+	if err != nil {
+		return WordCounts{}, fmt.Errorf("vocabulary.SQLiteRepository.CountWords unable to count words. %w", err)
+	}
+	defer rows.Close()
+
+	var counts WordCounts
+	for rows.Next() {
+		var status models.LearnStatus
+		var statusText string
+		var n, due int
+		if err := rows.Scan(&statusText, &n, &due); err != nil {
+			return WordCounts{}, fmt.Errorf("vocabulary.SQLiteRepository.CountWords unable to scan counts. %w", err)
+		}
+		if err := status.UnmarshalText(statusText); err != nil {
+			return WordCounts{}, fmt.Errorf("vocabulary.SQLiteRepository.CountWords. %w", err)
+		}
+		counts.add(status, n, due)
+	}
+	if err := rows.Err(); err != nil {
+		return WordCounts{}, fmt.Errorf("vocabulary.SQLiteRepository.CountWords unable to read counts. %w", err)
+	}
+	return counts, nil
+}
+
+func sqliteListWhere(userID models.UserID, filter ListFilter) (string, []any, error) {
+	conds := []string{"user_id = ?"}
+	args := []any{userID}
+	if filter.Language != "" {
+		conds, args = append(conds, "language = ?"), append(args, filter.Language)
+	}
+	if filter.LearnStatus != nil {
+		status, err := filter.LearnStatus.MarshalText()
+		if err != nil {
+			return "", nil, fmt.Errorf("unable to marshal learn status. %w", err)
+		}
+		conds, args = append(conds, "learn_status = ?"), append(args, status)
+	}
+	if filter.LexicalCategory != "" {
+		conds, args = append(conds, "lexical_category = ?"), append(args, filter.LexicalCategory)
+	}
+	if !filter.DueBefore.IsZero() {
+		conds, args = append(conds, "due <= ?"), append(args, filter.DueBefore.UnixMilli())
+	}
+	if !filter.IncludeArchived {
+		conds = append(conds, "NOT archived")
+	}
+	if filter.Spelling != "" {
+		conds, args = append(conds, "spelling_key = ?"), append(args, strings.ToLower(filter.Spelling))
+	} else if filter.SpellingPrefix != "" {
+		conds, args = append(conds, "substr(spelling, 1, length(?)) = ?"), append(args, filter.SpellingPrefix, filter.SpellingPrefix)
+	}
+
+	if filter.Cursor != "" {
+		c, err := decodeCursor(filter.Cursor, filter.Sort)
+		if err != nil {
+			return "", nil, err
+		}
+
+		op := ">"
+		if filter.Sort.descending() {
+			op = "<"
+		}
+		switch {
+		case filter.Sort.bySpelling():
+			conds = append(conds, fmt.Sprintf("(spelling %s ? OR (spelling = ? AND id %s ?))", op, op))
+			args = append(args, c.Spelling, c.Spelling, c.ID)
+		case filter.Sort == SortByDueAsc:
+			conds = append(conds, fmt.Sprintf("(due %s ? OR (due = ? AND id %s ?))", op, op))
+			args = append(args, c.due().UnixMilli(), c.due().UnixMilli(), c.ID)
+		default:
+			conds, args = append(conds, "id "+op+" ?"), append(args, c.ID)
+		}
+	}
+	return strings.Join(conds, " AND "), args, nil
+}
+
+func (r SQLiteRepository) UpdateWord(ctx context.Context, userID models.UserID, wordID models.WordID, patch WordPatch) (models.Word, error) {
+	w, err := r.update(ctx, userID, wordID, func(w *models.Word) {
+		if patch.Spelling != nil {
+			w.Spelling = *patch.Spelling
+		}
+		if patch.Definition != nil {
+			w.Definition = *patch.Definition
+		}
+		if patch.LexicalCategory != nil {
+			w.LexicalCategory = *patch.LexicalCategory
+		}
+		if patch.Language != nil {
+			w.Language = *patch.Language
+		}
+		if patch.Exercises != nil {
+			w.Exercises = patch.Exercises
+		}
+		if patch.Tags != nil {
+			w.Tags = patch.Tags
+		}
+		if patch.Source != nil {
+			w.Source = *patch.Source
+		}
+	})
+	if err != nil {
+		return w, fmt.Errorf("vocabulary.SQLiteRepository.UpdateWord. %w", err)
+	}
+	return w, nil
+}
+
+func (r SQLiteRepository) ArchiveWord(ctx context.Context, userID models.UserID, wordID models.WordID) (models.Word, error) {
+	w, err := r.update(ctx, userID, wordID, func(w *models.Word) {
+		w.Archived = true
+	})
+	if err != nil {
+		return w, fmt.Errorf("vocabulary.SQLiteRepository.ArchiveWord. %w", err)
+	}
+	return w, nil
+}
+
+func (r SQLiteRepository) UpdateSchedule(ctx context.Context, userID models.UserID, wordID models.WordID, lastReview time.Time, status models.LearnStatus, schedule models.Schedule) (models.Word, error) {
+	changed := false
+	w, err := r.update(ctx, userID, wordID, func(w *models.Word) {
+		if !w.Schedule.LastReview.Equal(lastReview) {
+			changed = true
+			return
+		}
+		w.LearnStatus = status
+		w.Schedule = schedule
+	})
+	if err != nil {
+		return w, fmt.Errorf("vocabulary.SQLiteRepository.UpdateSchedule. %w", err)
+	}
+	if changed {
+		return models.Word{}, fmt.Errorf("vocabulary.SQLiteRepository.UpdateSchedule word %s. %w", wordID, ErrScheduleChanged)
+	}
+	return w, nil
+}
+
+func (r SQLiteRepository) RecordAnswer(ctx context.Context, userID models.UserID, wordID models.WordID, exerciseIndex int, correct bool, status models.LearnStatus, schedule models.Schedule) (models.Word, error) {
+	w, err := r.update(ctx, userID, wordID, func(w *models.Word) {
+		w.LearnStatus = status
+		w.Schedule = schedule
+		if !correct {
+			return
+		}
+		if exerciseIndex >= 0 && exerciseIndex < len(w.Exercises) {
+			w.Exercises[exerciseIndex].Answered = true
+		}
+		w.AnsweredCount++
+	})
+	if err != nil {
+		return w, fmt.Errorf("vocabulary.SQLiteRepository.RecordAnswer. %w", err)
+	}
+	return w, nil
+}
+
+func (r SQLiteRepository) DeleteWord(ctx context.Context, userID models.UserID, wordID models.WordID) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM words WHERE id = ? AND user_id = ?`, wordID, userID)
+	if err != nil {
+		return fmt.Errorf("vocabulary.SQLiteRepository.DeleteWord unable to delete word %s. %w", wordID, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("vocabulary.SQLiteRepository.DeleteWord unable to count deleted words. %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("vocabulary.SQLiteRepository.DeleteWord word %s. %w", wordID, ErrWordNotFound)
+	}
+	return nil
+}
+
+// update reads the word, applies fn and writes the word back within a transaction.
+func (r SQLiteRepository) update(ctx context.Context, userID models.UserID, wordID models.WordID, fn func(w *models.Word)) (models.Word, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Word{}, fmt.Errorf("unable to begin transaction. %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	w, err := getSQLiteWord(ctx, tx, userID, wordID)
+	if err != nil {
+		return models.Word{}, err
+	}
+	fn(&w)
+
+	values, err := sqliteWordValues(w)
+	if err != nil {
+		return models.Word{}, err
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE words SET (`+sqliteWordColumns+`, spelling_key)
+		= (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) WHERE id = ?`, append(values, wordID)...)
+	if isSQLiteUniqueViolation(err) {
+		return models.Word{}, fmt.Errorf("word %s. %w", wordID, ErrDuplicateWord)
+	}
+	if err != nil {
+		return models.Word{}, fmt.Errorf("unable to update word %s. %w", wordID, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return models.Word{}, fmt.Errorf("unable to commit word %s. %w", wordID, err)
+	}
+	return w, nil
+}
+
+// isSQLiteUniqueViolation reports whether err is caused by the unique index on the spelling.
+func isSQLiteUniqueViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+}
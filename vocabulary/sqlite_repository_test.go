@@ -0,0 +1,26 @@
+package vocabulary_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/pavelpuchok/vocabforge/sqlitedb"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+	"github.com/pavelpuchok/vocabforge/vocabulary/vocabularytest"
+)
+
+func TestSQLiteRepository(t *testing.T) {
+	t.Parallel()
+
+	vocabularytest.TestRepository(t, func(t *testing.T) vocabulary.Repository {
+		t.Helper()
+
+		db, err := sqlitedb.Open(context.Background(), filepath.Join(t.TempDir(), "vocabforge.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { db.Close() })
+		return vocabulary.NewSQLiteRepository(db)
+	})
+}
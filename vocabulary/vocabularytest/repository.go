@@ -0,0 +1,440 @@
+// Package vocabularytest holds the conformance tests every vocabulary.Repository has to pass.
+package vocabularytest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pavelpuchok/vocabforge/models"
+	"github.com/pavelpuchok/vocabforge/vocabulary"
+)
+
+// newID returns a random ID in the form of MongoDB ObjectID, which all repositories accept.
+func newID() string {
+	b := make([]byte, 12) //nolint:mnd
+	// crypto/rand never fails on supported platforms
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// TestRepository runs the conformance tests, newRepository is called for every test and
+// has to return an empty repository.
+//
+//nolint:gocognit,maintidx
+func TestRepository(t *testing.T, newRepository func(t *testing.T) vocabulary.Repository) {
+	t.Helper()
+
+	ctx := context.Background()
+	now := time.Date(2024, 9, 1, 10, 0, 0, 0, time.UTC)
+	exercises := []models.SentenceExercise{{Sentence: "She <%runs%> home."}, {Sentence: "They <%run%> fast."}}
+
+	t.Run("add and get word", func(t *testing.T) {
+		t.Parallel()
+		repo := newRepository(t)
+		userID := models.UserID(newID())
+
+		added, err := repo.AddWord(ctx, userID, "run", "бежать", "verb", "en-US", "ru", exercises)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := models.Word{
+			ID:                 added.ID,
+			UserID:             userID,
+			Spelling:           "run",
+			Definition:         "бежать",
+			LexicalCategory:    "verb",
+			Language:           "en-US",
+			DefinitionLanguage: "ru",
+			LearnStatus:        models.Pending,
+			Exercises:          exercises,
+		}
+		if diff := cmp.Diff(expected, added, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("unexpected added word (-want +got):\n%s", diff)
+		}
+
+		got, err := repo.GetWord(ctx, userID, added.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(expected, got, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("unexpected word (-want +got):\n%s", diff)
+		}
+
+		if _, err := repo.GetWord(ctx, models.UserID(newID()), added.ID); !errors.Is(err, vocabulary.ErrWordNotFound) {
+			t.Errorf("expected ErrWordNotFound for word of other user, got %v", err)
+		}
+		if _, err := repo.GetWord(ctx, userID, models.WordID(newID())); !errors.Is(err, vocabulary.ErrWordNotFound) {
+			t.Errorf("expected ErrWordNotFound for unknown word, got %v", err)
+		}
+	})
+
+	t.Run("update word", func(t *testing.T) {
+		t.Parallel()
+		repo := newRepository(t)
+		userID := models.UserID(newID())
+
+		added, err := repo.AddWord(ctx, userID, "run", "бежать", "verb", "en-US", "ru", exercises)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		unchanged, err := repo.UpdateWord(ctx, userID, added.ID, vocabulary.WordPatch{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(added, unchanged, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("unexpected word after empty patch (-want +got):\n%s", diff)
+		}
+
+		spelling, lang, source := "walk", models.Language("en-GB"), "Der Process"
+		updated, err := repo.UpdateWord(ctx, userID, added.ID, vocabulary.WordPatch{
+			Spelling:  &spelling,
+			Language:  &lang,
+			Exercises: exercises[:1],
+			Tags:      []string{"kafka", "verbs"},
+			Source:    &source,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := added
+		expected.Spelling, expected.Language, expected.Exercises = "walk", "en-GB", exercises[:1]
+		expected.Tags, expected.Source = []string{"kafka", "verbs"}, "Der Process"
+		if diff := cmp.Diff(expected, updated, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("unexpected updated word (-want +got):\n%s", diff)
+		}
+
+		untagged, err := repo.UpdateWord(ctx, userID, added.ID, vocabulary.WordPatch{Tags: []string{}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(untagged.Tags) != 0 {
+			t.Errorf("expected tags to be removed, got %v", untagged.Tags)
+		}
+
+		if _, err := repo.UpdateWord(ctx, models.UserID(newID()), added.ID, vocabulary.WordPatch{Spelling: &spelling}); !errors.Is(err, vocabulary.ErrWordNotFound) {
+			t.Errorf("expected ErrWordNotFound for word of other user, got %v", err)
+		}
+	})
+
+	t.Run("duplicate word", func(t *testing.T) {
+		t.Parallel()
+		repo := newRepository(t)
+		userID := models.UserID(newID())
+
+		run, err := repo.AddWord(ctx, userID, "run", "бежать", "verb", "en-US", "ru", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.ArchiveWord(ctx, userID, run.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.AddWord(ctx, userID, "Run", "бег", "noun", "en-US", "ru", nil); !errors.Is(err, vocabulary.ErrDuplicateWord) {
+			t.Errorf("expected ErrDuplicateWord for the same spelling in other case, got %v", err)
+		}
+		if _, err := repo.RestoreWord(ctx, userID, run); !errors.Is(err, vocabulary.ErrDuplicateWord) {
+			t.Errorf("expected ErrDuplicateWord for restored word, got %v", err)
+		}
+
+		// the spelling is taken per user and language only
+		if _, err := repo.AddWord(ctx, userID, "run", "бежать", "verb", "en-GB", "ru", nil); err != nil {
+			t.Errorf("unexpected error for other language %s", err)
+		}
+		if _, err := repo.AddWord(ctx, models.UserID(newID()), "run", "бежать", "verb", "en-US", "ru", nil); err != nil {
+			t.Errorf("unexpected error for other user %s", err)
+		}
+
+		walk, err := repo.AddWord(ctx, userID, "walk", "ходить", "verb", "en-US", "ru", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		spelling := "RUN"
+		if _, err := repo.UpdateWord(ctx, userID, walk.ID, vocabulary.WordPatch{Spelling: &spelling}); !errors.Is(err, vocabulary.ErrDuplicateWord) {
+			t.Errorf("expected ErrDuplicateWord for renamed word, got %v", err)
+		}
+		got, err := repo.GetWord(ctx, userID, walk.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Spelling != "walk" {
+			t.Errorf("expected spelling to stay walk, got %s", got.Spelling)
+		}
+
+		// backends fold the case of letters beyond ASCII alike, but keep diacritics
+		if _, err := repo.AddWord(ctx, userID, "äpfel", "яблоки", "noun", "de", "ru", nil); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.AddWord(ctx, userID, "ÄPFEL", "яблоки", "noun", "de", "ru", nil); !errors.Is(err, vocabulary.ErrDuplicateWord) {
+			t.Errorf("expected ErrDuplicateWord for non-ASCII spelling in other case, got %v", err)
+		}
+		if _, err := repo.AddWord(ctx, userID, "Apfel", "яблоко", "noun", "de", "ru", nil); err != nil {
+			t.Errorf("unexpected error for spelling without diacritics %s", err)
+		}
+		page, err := repo.ListWords(ctx, userID, vocabulary.ListFilter{Spelling: "ÄPFEL", Language: "de", Limit: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page.Words) != 1 || page.Words[0].Spelling != "äpfel" {
+			t.Errorf("expected äpfel for non-ASCII spelling in other case, got %+v", page.Words)
+		}
+	})
+
+	t.Run("archive and delete word", func(t *testing.T) {
+		t.Parallel()
+		repo := newRepository(t)
+		userID := models.UserID(newID())
+
+		added, err := repo.AddWord(ctx, userID, "run", "бежать", "verb", "en-US", "ru", exercises)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		archived, err := repo.ArchiveWord(ctx, userID, added.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !archived.Archived {
+			t.Error("expected word to be archived")
+		}
+		page, err := repo.ListWords(ctx, userID, vocabulary.ListFilter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page.Words) != 0 {
+			t.Errorf("expected archived word to be left out, got %v", page.Words)
+		}
+		page, err = repo.ListWords(ctx, userID, vocabulary.ListFilter{IncludeArchived: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page.Words) != 1 {
+			t.Errorf("expected archived word to be listed, got %v", page.Words)
+		}
+
+		if err := repo.DeleteWord(ctx, models.UserID(newID()), added.ID); !errors.Is(err, vocabulary.ErrWordNotFound) {
+			t.Errorf("expected ErrWordNotFound for word of other user, got %v", err)
+		}
+		if err := repo.DeleteWord(ctx, userID, added.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.GetWord(ctx, userID, added.ID); !errors.Is(err, vocabulary.ErrWordNotFound) {
+			t.Errorf("expected ErrWordNotFound for deleted word, got %v", err)
+		}
+		if err := repo.DeleteWord(ctx, userID, added.ID); !errors.Is(err, vocabulary.ErrWordNotFound) {
+			t.Errorf("expected ErrWordNotFound deleting word again, got %v", err)
+		}
+	})
+
+	t.Run("schedule and answers", func(t *testing.T) {
+		t.Parallel()
+		repo := newRepository(t)
+		userID := models.UserID(newID())
+
+		added, err := repo.AddWord(ctx, userID, "run", "бежать", "verb", "en-US", "ru", exercises)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		schedule := models.Schedule{Ease: 2.5, Interval: 24 * time.Hour, Due: now.Add(24 * time.Hour), LastReview: now, Repetitions: 1}
+		scheduled, err := repo.UpdateSchedule(ctx, userID, added.ID, time.Time{}, models.InProgress, schedule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := added
+		expected.LearnStatus, expected.Schedule = models.InProgress, schedule
+		if diff := cmp.Diff(expected, scheduled, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("unexpected scheduled word (-want +got):\n%s", diff)
+		}
+
+		// the word was reviewed meanwhile, so the schedule based on the previous review is refused
+		if _, err := repo.UpdateSchedule(ctx, userID, added.ID, time.Time{}, models.Learned, schedule); !errors.Is(err, vocabulary.ErrScheduleChanged) {
+			t.Errorf("expected ErrScheduleChanged for outdated review, got %v", err)
+		}
+		got, err := repo.GetWord(ctx, userID, added.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(expected, got, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("unexpected word after outdated review (-want +got):\n%s", diff)
+		}
+
+		schedule.Lapses = 1
+		wrong, err := repo.RecordAnswer(ctx, userID, added.ID, 1, false, models.InProgress, schedule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected.Schedule = schedule
+		if diff := cmp.Diff(expected, wrong, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("unexpected word after wrong answer (-want +got):\n%s", diff)
+		}
+
+		schedule.Repetitions = 2
+		right, err := repo.RecordAnswer(ctx, userID, added.ID, 1, true, models.Learned, schedule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected.LearnStatus, expected.Schedule, expected.AnsweredCount = models.Learned, schedule, 1
+		expected.Exercises = []models.SentenceExercise{exercises[0], {Sentence: exercises[1].Sentence, Answered: true}}
+		if diff := cmp.Diff(expected, right, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("unexpected word after right answer (-want +got):\n%s", diff)
+		}
+
+		if _, err := repo.UpdateSchedule(ctx, userID, models.WordID(newID()), time.Time{}, models.InProgress, schedule); !errors.Is(err, vocabulary.ErrWordNotFound) {
+			t.Errorf("expected ErrWordNotFound for unknown word, got %v", err)
+		}
+	})
+
+	t.Run("restore word", func(t *testing.T) {
+		t.Parallel()
+		repo := newRepository(t)
+		userID := models.UserID(newID())
+
+		w := models.Word{
+			ID:                 models.WordID(newID()),
+			UserID:             models.UserID(newID()),
+			Spelling:           "run",
+			Definition:         "бежать",
+			LexicalCategory:    "verb",
+			Language:           "en-US",
+			DefinitionLanguage: "ru",
+			LearnStatus:        models.InProgress,
+			AnsweredCount:      3,
+			Exercises:          []models.SentenceExercise{{Sentence: "She <%runs%> home.", Answered: true}},
+			Archived:           true,
+			Schedule:           models.Schedule{Stability: 3.2, Difficulty: 5.1, Due: now, LastReview: now.Add(-72 * time.Hour), Repetitions: 3, Lapses: 1},
+			Tags:               []string{"kafka"},
+			Source:             "Der Process",
+		}
+		restored, err := repo.RestoreWord(ctx, userID, w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if restored.ID == w.ID || restored.ID == "" {
+			t.Errorf("expected word to get a new ID, got %s", restored.ID)
+		}
+
+		expected := w
+		expected.ID, expected.UserID = restored.ID, userID
+		if diff := cmp.Diff(expected, restored, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("unexpected restored word (-want +got):\n%s", diff)
+		}
+		got, err := repo.GetWord(ctx, userID, restored.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(expected, got, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("unexpected word (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("list words", func(t *testing.T) {
+		t.Parallel()
+		repo := newRepository(t)
+		userID := models.UserID(newID())
+
+		type word struct {
+			spelling, category string
+			lang               models.Language
+			status             models.LearnStatus
+			due                time.Time
+		}
+		var ids []models.WordID
+		for _, w := range []word{
+			{"run", "verb", "en-US", models.InProgress, now.Add(time.Hour)},
+			{"Apple", "noun", "en-US", models.Pending, time.Time{}},
+			{"Haus", "noun", "de", models.InProgress, now.Add(-time.Hour)},
+			{"apply", "verb", "en-US", models.Learned, now.Add(48 * time.Hour)},
+			{"ran", "verb", "en-US", models.InProgress, now},
+		} {
+			added, err := repo.AddWord(ctx, userID, w.spelling, "", w.category, w.lang, "ru", exercises)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if w.status != models.Pending {
+				_, err = repo.UpdateSchedule(ctx, userID, added.ID, time.Time{}, w.status, models.Schedule{Due: w.due})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			ids = append(ids, added.ID)
+		}
+		if _, err := repo.AddWord(ctx, models.UserID(newID()), "run", "", "verb", "en-US", "ru", exercises); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.ArchiveWord(ctx, userID, ids[4]); err != nil {
+			t.Fatal(err)
+		}
+
+		inProgress := models.InProgress
+		filters := map[string]struct {
+			filter   vocabulary.ListFilter
+			expected []models.WordID
+		}{
+			"all":              {vocabulary.ListFilter{}, ids[:4]},
+			"include archived": {vocabulary.ListFilter{IncludeArchived: true}, ids},
+			"language":         {vocabulary.ListFilter{Language: "de"}, ids[2:3]},
+			"learn status":     {vocabulary.ListFilter{LearnStatus: &inProgress}, []models.WordID{ids[0], ids[2]}},
+			"lexical category": {vocabulary.ListFilter{LexicalCategory: "noun"}, ids[1:3]},
+			"spelling":         {vocabulary.ListFilter{Spelling: "APPLE"}, ids[1:2]},
+			"spelling prefix":  {vocabulary.ListFilter{SpellingPrefix: "app"}, ids[3:4]},
+			"due before":       {vocabulary.ListFilter{DueBefore: now, IncludeArchived: true}, []models.WordID{ids[1], ids[2], ids[4]}},
+			"created desc":     {vocabulary.ListFilter{Sort: vocabulary.SortByCreatedDesc}, []models.WordID{ids[3], ids[2], ids[1], ids[0]}},
+			"spelling asc":     {vocabulary.ListFilter{Sort: vocabulary.SortBySpellingAsc}, []models.WordID{ids[1], ids[2], ids[3], ids[0]}},
+			"spelling desc":    {vocabulary.ListFilter{Sort: vocabulary.SortBySpellingDesc}, []models.WordID{ids[0], ids[3], ids[2], ids[1]}},
+			"due":              {vocabulary.ListFilter{Sort: vocabulary.SortByDueAsc, IncludeArchived: true}, []models.WordID{ids[1], ids[2], ids[4], ids[0], ids[3]}},
+		}
+		for name, f := range filters {
+			// every page holds two words at most, so pagination is checked too
+			filter := f.filter
+			filter.Limit = 2
+
+			var actual []models.WordID
+			for {
+				page, err := repo.ListWords(ctx, userID, filter)
+				if err != nil {
+					t.Fatalf("%s: %s", name, err)
+				}
+				for _, w := range page.Words {
+					actual = append(actual, w.ID)
+				}
+				if page.NextCursor == "" {
+					break
+				}
+				filter.Cursor = page.NextCursor
+			}
+			if diff := cmp.Diff(f.expected, actual, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("%s: unexpected words (-want +got):\n%s", name, diff)
+			}
+		}
+
+		if _, err := repo.ListWords(ctx, userID, vocabulary.ListFilter{Cursor: "foo"}); !errors.Is(err, vocabulary.ErrInvalidCursor) {
+			t.Errorf("expected ErrInvalidCursor, got %v", err)
+		}
+
+		counts := map[string]struct {
+			filter   vocabulary.ListFilter
+			dueAt    time.Time
+			expected vocabulary.WordCounts
+		}{
+			"all":              {vocabulary.ListFilter{}, now, vocabulary.WordCounts{Pending: 1, InProgress: 2, Learned: 1, Due: 2}},
+			"include archived": {vocabulary.ListFilter{IncludeArchived: true}, now, vocabulary.WordCounts{Pending: 1, InProgress: 3, Learned: 1, Due: 3}},
+			"language":         {vocabulary.ListFilter{Language: "en-US"}, now.Add(2 * time.Hour), vocabulary.WordCounts{Pending: 1, InProgress: 1, Learned: 1, Due: 2}},
+			"spelling":         {vocabulary.ListFilter{Spelling: "APPLE"}, now, vocabulary.WordCounts{Pending: 1, Due: 1}},
+		}
+		for name, c := range counts {
+			actual, err := repo.CountWords(ctx, userID, c.filter, c.dueAt)
+			if err != nil {
+				t.Fatalf("%s: %s", name, err)
+			}
+			if diff := cmp.Diff(c.expected, actual); diff != "" {
+				t.Errorf("%s: unexpected counts (-want +got):\n%s", name, diff)
+			}
+		}
+	})
+}